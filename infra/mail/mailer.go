@@ -0,0 +1,126 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Ryeom/board-game/log"
+	"github.com/spf13/viper"
+)
+
+// Message 보낼 메일 (본문은 텍스트)
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer 메일 발송 방식. bg.mail.driver로 고른다.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Default 서버가 사용하는 Mailer. Initialize 전에는 로그만 남긴다.
+var Default Mailer = &FileMailer{From: "no-reply@localhost"}
+
+// Initialize bg.mail 설정으로 Default를 만든다.
+// smtp: SMTP 서버로 발송 (비밀번호는 암호화된 smtp.password), file: bg.mail.dir에 .eml 파일로 저장, log: 로그로만 남김
+func Initialize() {
+	from := viper.GetString("bg.mail.from")
+	switch driver := viper.GetString("bg.mail.driver"); driver {
+	case "smtp":
+		Default = &SMTPMailer{
+			Host:     viper.GetString("bg.mail.smtp.host"),
+			Port:     viper.GetInt("bg.mail.smtp.port"),
+			Username: viper.GetString("bg.mail.smtp.username"),
+			Password: viper.GetString("smtp.password"),
+			From:     from,
+		}
+	case "file":
+		Default = &FileMailer{Dir: viper.GetString("bg.mail.dir"), From: from}
+	case "log", "":
+		Default = &FileMailer{From: from}
+	default:
+		log.Logger.Fatalf("unknown mail driver: %s", driver)
+	}
+}
+
+// SMTPMailer SMTP 서버로 발송한다. Username이 있으면 PLAIN 인증 (서버가 STARTTLS를 지원해야 함)
+type SMTPMailer struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+	addr := net.JoinHostPort(m.Host, fmt.Sprint(m.Port))
+	if err := smtp.SendMail(addr, auth, m.From, []string{msg.To}, Format(m.From, msg, time.Now())); err != nil {
+		return fmt.Errorf("failed to send mail to %s: %w", msg.To, err)
+	}
+	return nil
+}
+
+// FileMailer 개발/테스트용. Dir이 있으면 메일을 .eml 파일로 저장하고, 없으면 로그로 남긴다.
+type FileMailer struct {
+	Dir  string
+	From string
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	if m.Dir == "" {
+		log.Logger.Infof("[mail] To: %s | Subject: %s\n%s", msg.To, msg.Subject, msg.Body)
+		return nil
+	}
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create mail dir: %w", err)
+	}
+	now := time.Now()
+	name := fmt.Sprintf("%d-%s.eml", now.UnixNano(), safeFileName(msg.To))
+	if err := os.WriteFile(filepath.Join(m.Dir, name), Format(m.From, msg, now), 0o644); err != nil {
+		return fmt.Errorf("failed to write mail to %s: %w", msg.To, err)
+	}
+	return nil
+}
+
+// Format 메일을 RFC 5322 형식으로 만든다. (UTF-8 텍스트, 본문 base64)
+func Format(from string, msg Message, date time.Time) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+	body := base64.StdEncoding.EncodeToString([]byte(msg.Body))
+	for len(body) > 76 {
+		buf.WriteString(body[:76] + "\r\n")
+		body = body[76:]
+	}
+	buf.WriteString(body + "\r\n")
+	return buf.Bytes()
+}
+
+func safeFileName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' || r < ' ' {
+			return '_'
+		}
+		return r
+	}, s)
+}
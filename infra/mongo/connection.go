@@ -15,8 +15,10 @@ import (
 var Client *mongo.Client
 
 const (
-	DBName         = "board_game"
-	ChatCollection = "chat_messages"
+	DBName                  = "board_game"
+	ChatCollection          = "chat_messages"
+	DirectMessageCollection = "direct_messages"
+	MatchCollection         = "match_results"
 )
 
 func Initialize() {
@@ -29,6 +31,13 @@ func Initialize() {
 	if Client == nil {
 		log.Logger.Fatalf("mongo client not initialized")
 	}
+	// 인덱스가 없어도 조회는 동작하므로 실패해도 서버는 계속 띄운다
+	if err := EnsureIndexes(context.Background()); err != nil {
+		log.Logger.Errorf("MongoDB 인덱스 생성에 실패했습니다: %v", err)
+	}
+	if err := EnsureChatRetention(context.Background(), viper.GetDuration("bg.chat.retention")); err != nil {
+		log.Logger.Errorf("채팅 보관 기간(TTL) 인덱스 설정에 실패했습니다: %v", err)
+	}
 }
 func NewClient(ip, port, user, pw string) *mongo.Client {
 	var mongoURI string
@@ -0,0 +1,99 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// ChatRoomTimestampIndex 방별 채팅 내역을 최신순으로 페이지 조회하기 위한 복합 인덱스 (roomId, timestamp, _id)
+	ChatRoomTimestampIndex = "roomId_timestamp"
+	// DirectMessagePairIndex 두 유저 사이의 DM을 최신순으로 페이지 조회하기 위한 복합 인덱스 (pair, timestamp, _id)
+	DirectMessagePairIndex = "pair_timestamp"
+	// DirectMessageUnreadIndex 받은 사람별 안 읽은 DM 집계용 인덱스 (recipientId, readAt)
+	DirectMessageUnreadIndex = "recipientId_readAt"
+	// ChatRetentionIndex 방 채팅을 보관 기간이 지나면 지우는 TTL 인덱스 (timestamp)
+	ChatRetentionIndex = "timestamp_ttl"
+	// MatchPlayerIndex 유저별 전적을 최신순으로 조회하기 위한 인덱스 (players.userId, endedAt)
+	MatchPlayerIndex = "players_endedAt"
+)
+
+// MongoDB 에러 코드
+const (
+	errCodeNamespaceNotFound    = 26
+	errCodeIndexNotFound        = 27
+	errCodeIndexOptionsConflict = 85
+)
+
+// EnsureIndexes 컬렉션 인덱스를 만든다. 이미 같은 인덱스가 있으면 그대로 둔다.
+func EnsureIndexes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := GetCollection(ChatCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "roomId", Value: 1}, {Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}},
+		Options: options.Index().SetName(ChatRoomTimestampIndex),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = GetCollection(DirectMessageCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "pair", Value: 1}, {Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName(DirectMessagePairIndex),
+		},
+		{
+			Keys:    bson.D{{Key: "recipientId", Value: 1}, {Key: "readAt", Value: 1}},
+			Options: options.Index().SetName(DirectMessageUnreadIndex),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = GetCollection(MatchCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "players.userId", Value: 1}, {Key: "endedAt", Value: -1}},
+		Options: options.Index().SetName(MatchPlayerIndex),
+	})
+	return err
+}
+
+// EnsureChatRetention 방 채팅 TTL 인덱스를 retention에 맞춘다. 0 이하면 TTL 인덱스를 지워 영구 보관한다.
+// 이미 다른 보관 기간으로 만들어져 있으면 collMod로 기간만 바꾼다.
+func EnsureChatRetention(ctx context.Context, retention time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	collection := GetCollection(ChatCollection)
+	if retention <= 0 {
+		_, err := collection.Indexes().DropOne(ctx, ChatRetentionIndex)
+		if hasErrorCode(err, errCodeIndexNotFound) || hasErrorCode(err, errCodeNamespaceNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	expireAfter := int32(retention.Seconds())
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "timestamp", Value: 1}},
+		Options: options.Index().SetName(ChatRetentionIndex).SetExpireAfterSeconds(expireAfter),
+	})
+	if !hasErrorCode(err, errCodeIndexOptionsConflict) {
+		return err
+	}
+	return collection.Database().RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: ChatCollection},
+		{Key: "index", Value: bson.D{{Key: "name", Value: ChatRetentionIndex}, {Key: "expireAfterSeconds", Value: expireAfter}}},
+	}).Err()
+}
+
+func hasErrorCode(err error, code int) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && cmdErr.Code == int32(code)
+}
@@ -12,6 +12,7 @@ const (
 	RedisTargetUser   = "user"
 	RedisTargetPubSub = "pubSub"
 	RedisTargetGame   = "game"
+	RedisTargetQueue  = "queue" // 매칭 대기열 (pub/sub과 같은 queue-index DB 사용)
 )
 
 var Client map[string]*redis.Client
@@ -36,6 +37,7 @@ func Initialize() {
 		panic(err)
 	}
 	Client[RedisTargetPubSub] = pubSubClient
+	Client[RedisTargetQueue] = pubSubClient
 	gameClient, err := CreateClient(viper.GetInt("redis.game-index"))
 	if err != nil {
 		log.Logger.Fatal(err)
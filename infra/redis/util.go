@@ -407,3 +407,38 @@ func LTrimList(target string, key string, start, stop int64) error {
 	}
 	return nil
 }
+
+func Incr(target string, key string) (int64, error) {
+	rdb := Client[target]
+	if rdb == nil {
+		return 0, errors.New(fmt.Sprintf("redis client not found for target: %s", target))
+	}
+	ctx := context.Background()
+	val, err := rdb.Incr(ctx, key).Result()
+	if err != nil {
+		log.Logger.Errorf("Redis Incr ERROR for key %s in target %s: %v", key, target, err)
+		return 0, err
+	}
+	return val, nil
+}
+
+// TakeJSON 키의 JSON 값을 읽고 바로 삭제한다 (GETDEL). 한 번만 쓸 수 있는 토큰용, 키가 없으면 false.
+func TakeJSON(target string, key string, dest interface{}) (bool, error) {
+	rdb := Client[target]
+	if rdb == nil {
+		return false, errors.New(fmt.Sprintf("redis client not found for target: %s", target))
+	}
+	ctx := context.Background()
+	val, err := rdb.GetDel(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return false, nil
+		}
+		log.Logger.Errorf("Redis GetDel ERROR for key %s in target %s: %v", key, target, err)
+		return false, err
+	}
+	if err := json.Unmarshal([]byte(val), dest); err != nil {
+		return false, fmt.Errorf("failed to decode %s: %w", key, err)
+	}
+	return true, nil
+}
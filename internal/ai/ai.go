@@ -21,3 +21,8 @@ func GenerateAIPlayerID(index int) string {
 func GenerateAIPlayerName(index int) string {
 	return fmt.Sprintf("Bot %d", index)
 }
+
+// PlayerName AI 플레이어 ID의 표시 이름 (GenerateAIPlayerID/GenerateAIPlayerName 짝)
+func PlayerName(playerID string) string {
+	return "Bot " + strings.TrimPrefix(playerID, AIPlayerPrefix)
+}
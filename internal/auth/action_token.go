@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	redisutil "github.com/Ryeom/board-game/infra/redis"
+	resp "github.com/Ryeom/board-game/internal/response"
+)
+
+// Purpose 메일로 보내는 일회용 토큰의 용도. 다른 용도의 토큰으로는 사용할 수 없다.
+type Purpose string
+
+const (
+	PurposePasswordReset Purpose = "password-reset" // 비밀번호 재설정
+	PurposeEmailVerify   Purpose = "email-verify"   // 이메일 인증
+)
+
+// ActionToken 사용된 일회용 토큰의 대상
+type ActionToken struct {
+	UserID string `json:"userId"`
+	Email  string `json:"email"` // 발급 시점의 이메일. 이메일이 바뀌었으면 사용하지 않는다.
+	KeyID  string `json:"kid"`   // 서명한 키 (키 교체 후에도 검증)
+}
+
+// IssueActionToken 용도별 일회용 토큰을 발급한다. 토큰은 <id>.<서명> 형식이며 Redis에 ttl 동안 저장된다.
+// 같은 유저의 같은 용도 토큰은 하나만 유효하다. (새로 발급하면 이전 토큰은 사용할 수 없음)
+func IssueActionToken(ctx context.Context, purpose Purpose, userID, email string, ttl time.Duration) (string, error) {
+	key, ok := signingKeys[currentKeyID]
+	if !ok {
+		return "", errors.New("signing key is not initialized")
+	}
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	id := base64.RawURLEncoding.EncodeToString(raw)
+	record := ActionToken{UserID: userID, Email: email, KeyID: currentKeyID}
+
+	latestKey := latestActionTokenKey(purpose, userID)
+	if previous, err := redisutil.GetString(redisutil.RedisTargetUser, latestKey); err == nil && previous != "" {
+		_ = redisutil.Delete(redisutil.RedisTargetUser, actionTokenKey(purpose, previous))
+	}
+	if err := redisutil.SaveJSON(redisutil.RedisTargetUser, actionTokenKey(purpose, id), record, ttl); err != nil {
+		return "", fmt.Errorf("failed to save %s token: %w", purpose, err)
+	}
+	if err := redisutil.SetStringWithTTL(redisutil.RedisTargetUser, latestKey, id, ttl); err != nil {
+		return "", fmt.Errorf("failed to save latest %s token: %w", purpose, err)
+	}
+	return id + "." + signActionToken(key, purpose, id, record), nil
+}
+
+// ConsumeActionToken 토큰을 검증하고 삭제한다. 없거나 만료되었거나 서명이 맞지 않으면 ERROR_AUTH_ACTION_TOKEN_INVALID.
+func ConsumeActionToken(ctx context.Context, purpose Purpose, token string) (*ActionToken, error) {
+	id, signature, ok := strings.Cut(token, ".")
+	if !ok || id == "" || signature == "" {
+		return nil, errors.New(resp.ErrorCodeAuthActionTokenInvalid)
+	}
+	var record ActionToken
+	found, err := redisutil.TakeJSON(redisutil.RedisTargetUser, actionTokenKey(purpose, id), &record)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errors.New(resp.ErrorCodeAuthActionTokenInvalid)
+	}
+	key, ok := signingKeys[record.KeyID]
+	if !ok || !hmac.Equal([]byte(signature), []byte(signActionToken(key, purpose, id, record))) {
+		return nil, errors.New(resp.ErrorCodeAuthActionTokenInvalid)
+	}
+	_ = redisutil.Delete(redisutil.RedisTargetUser, latestActionTokenKey(purpose, record.UserID))
+	return &record, nil
+}
+
+func signActionToken(key []byte, purpose Purpose, id string, record ActionToken) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(string(purpose) + "\n" + id + "\n" + record.UserID + "\n" + record.Email))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func actionTokenKey(purpose Purpose, id string) string {
+	return "auth:token:" + string(purpose) + ":" + id
+}
+
+func latestActionTokenKey(purpose Purpose, userID string) string {
+	return "auth:token:" + string(purpose) + ":user:" + userID
+}
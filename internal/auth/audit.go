@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ryeom/board-game/infra/db"
+	"github.com/Ryeom/board-game/log"
+	"github.com/google/uuid"
+)
+
+// 감사 로그 이벤트
+const (
+	AuditActionLoginLocked   = "login_locked"   // 로그인 실패 누적으로 계정 잠금
+	AuditActionLoginUnlocked = "login_unlocked" // 잠금 해제 (Detail: UnlockReason*)
+)
+
+// AuditLog 계정 보안 이벤트 기록 (PostgreSQL auth_audit_logs). 탈퇴 후에도 익명화하지 않고 보관한다.
+type AuditLog struct {
+	ID        string    `gorm:"type:uuid;primaryKey" json:"id"`
+	Action    string    `gorm:"type:varchar(30);not null;index" json:"action"`  // AuditAction*
+	UserID    string    `gorm:"type:varchar(36);index" json:"userId,omitempty"` // 가입되지 않은 이메일이면 비어 있음
+	Email     string    `gorm:"type:varchar(255);index" json:"email"`
+	IP        string    `gorm:"type:varchar(64)" json:"ip,omitempty"`
+	ActorID   string    `gorm:"type:varchar(36)" json:"actorId,omitempty"` // 관리자가 처리했으면 관리자 ID
+	Detail    string    `gorm:"type:text" json:"detail,omitempty"`
+	CreatedAt time.Time `gorm:"index" json:"createdAt"`
+}
+
+func (AuditLog) TableName() string { return "auth_audit_logs" }
+
+// RecordAudit 감사 로그를 남긴다. DB 저장에 실패해도 서버 로그에는 남는다.
+func RecordAudit(ctx context.Context, entry AuditLog) error {
+	entry.ID = uuid.NewString()
+	entry.CreatedAt = time.Now()
+	log.Logger.Warningf("[audit] %s email=%s user=%s ip=%s actor=%s %s",
+		entry.Action, entry.Email, entry.UserID, entry.IP, entry.ActorID, entry.Detail)
+	return db.DB.WithContext(ctx).Create(&entry).Error
+}
@@ -0,0 +1,232 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	redisutil "github.com/Ryeom/board-game/infra/redis"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/log"
+	"github.com/spf13/viper"
+)
+
+// 로그인 잠금 해제 사유 (AuditActionLoginUnlocked의 Detail)
+const (
+	UnlockReasonPasswordReset = "password_reset" // 비밀번호 재설정
+	UnlockReasonAdmin         = "admin"          // 관리자 해제
+)
+
+// LoginGuardConfig 로그인 실패 제한 설정 (bg.auth.login)
+// 계정(이메일)과 IP별로 실패 횟수를 세고, 허용 횟수를 넘기면 실패할 때마다 대기 시간이 두 배로 늘어난다.
+// 계정 실패가 LockAfter에 도달하면 LockDuration 동안 잠근다. (가입되지 않은 이메일도 똑같이 취급)
+type LoginGuardConfig struct {
+	Window         time.Duration // 마지막 실패 후 이 시간이 지나면 실패 횟수 초기화
+	FreeAttempts   int           // 계정당 대기 없이 허용하는 실패 횟수
+	IPFreeAttempts int           // IP당 대기 없이 허용하는 실패 횟수 (여러 계정을 번갈아 시도하는 경우)
+	BaseDelay      time.Duration // 허용 횟수를 넘긴 첫 실패 후 대기 시간
+	MaxDelay       time.Duration // 대기 시간 상한
+	LockAfter      int           // 계정 잠금까지의 실패 횟수 (0이면 잠그지 않음)
+	LockDuration   time.Duration // 계정 잠금 시간
+}
+
+// LoginGuard 서버 시작 시 bg.auth.login 설정으로 바뀐다.
+var LoginGuard = LoginGuardConfig{
+	Window:         15 * time.Minute,
+	FreeAttempts:   3,
+	IPFreeAttempts: 20,
+	BaseDelay:      time.Second,
+	MaxDelay:       time.Minute,
+	LockAfter:      10,
+	LockDuration:   30 * time.Minute,
+}
+
+// loadLoginGuard bg.auth.login에 지정된 값만 기본값 대신 사용한다.
+func loadLoginGuard() {
+	durations := map[string]*time.Duration{
+		"window":        &LoginGuard.Window,
+		"base-delay":    &LoginGuard.BaseDelay,
+		"max-delay":     &LoginGuard.MaxDelay,
+		"lock-duration": &LoginGuard.LockDuration,
+	}
+	for key, dst := range durations {
+		if viper.IsSet("bg.auth.login." + key) {
+			*dst = viper.GetDuration("bg.auth.login." + key)
+		}
+	}
+	counts := map[string]*int{
+		"free-attempts":    &LoginGuard.FreeAttempts,
+		"ip-free-attempts": &LoginGuard.IPFreeAttempts,
+		"lock-after":       &LoginGuard.LockAfter,
+	}
+	for key, dst := range counts {
+		if viper.IsSet("bg.auth.login." + key) {
+			*dst = viper.GetInt("bg.auth.login." + key)
+		}
+	}
+}
+
+// LoginBlockedError 대기 중이거나 잠긴 계정의 로그인 시도. Error()는 응답 코드.
+type LoginBlockedError struct {
+	Code       string        // ERROR_AUTH_LOGIN_THROTTLED 또는 ERROR_AUTH_ACCOUNT_LOCKED
+	RetryAfter time.Duration // 다시 시도할 수 있을 때까지 남은 시간
+}
+
+func (e *LoginBlockedError) Error() string { return e.Code }
+
+// delay 실패 횟수에 따른 대기 시간. free번까지는 0, 이후 BaseDelay부터 두 배씩 MaxDelay까지.
+func (c LoginGuardConfig) delay(failures int64, free int) time.Duration {
+	over := failures - int64(free)
+	if over <= 0 || c.BaseDelay <= 0 {
+		return 0
+	}
+	d := c.BaseDelay
+	for i := int64(1); i < over && i < 32; i++ {
+		d *= 2
+		if c.MaxDelay > 0 && d >= c.MaxDelay {
+			break
+		}
+	}
+	if c.MaxDelay > 0 && d > c.MaxDelay {
+		d = c.MaxDelay
+	}
+	return d
+}
+
+// CheckLogin 비밀번호 확인 전에 호출한다. 잠긴 계정이면 ERROR_AUTH_ACCOUNT_LOCKED,
+// 계정이나 IP가 대기 중이면 ERROR_AUTH_LOGIN_THROTTLED (*LoginBlockedError).
+// Redis 오류는 로그인을 막지 않는다.
+func CheckLogin(ctx context.Context, email, ip string) error {
+	email = normalizeEmail(email)
+	if ttl := remaining(loginLockKey(email)); ttl > 0 {
+		return &LoginBlockedError{Code: resp.ErrorCodeAuthAccountLocked, RetryAfter: ttl}
+	}
+	wait := remaining(loginWaitKey("account", email))
+	if ttl := remaining(loginWaitKey("ip", ip)); ttl > wait {
+		wait = ttl
+	}
+	if wait > 0 {
+		return &LoginBlockedError{Code: resp.ErrorCodeAuthLoginThrottled, RetryAfter: wait}
+	}
+	return nil
+}
+
+// RecordLoginFailure 비밀번호가 틀린 시도를 기록한다. userID는 가입되지 않은 이메일이면 빈 값.
+// 이번 실패로 계정이 잠겼으면 감사 로그를 남기고 ERROR_AUTH_ACCOUNT_LOCKED (*LoginBlockedError)를 반환한다.
+func RecordLoginFailure(ctx context.Context, email, ip, userID string) error {
+	email = normalizeEmail(email)
+	cfg := LoginGuard
+
+	if ip != "" {
+		ipFailures, err := countFailure(loginFailKey("ip", ip), cfg.Window)
+		if err != nil {
+			return err
+		}
+		if d := cfg.delay(ipFailures, cfg.IPFreeAttempts); d > 0 {
+			if err := redisutil.SetStringWithTTL(redisutil.RedisTargetUser, loginWaitKey("ip", ip), "1", d); err != nil {
+				return err
+			}
+		}
+	}
+
+	failures, err := countFailure(loginFailKey("account", email), cfg.Window)
+	if err != nil {
+		return err
+	}
+	if cfg.LockAfter > 0 && failures >= int64(cfg.LockAfter) {
+		if err := redisutil.SetStringWithTTL(redisutil.RedisTargetUser, loginLockKey(email), "1", cfg.LockDuration); err != nil {
+			return err
+		}
+		// 잠금이 풀리면 실패 횟수도 처음부터 센다
+		clearLoginFailures(email)
+		if err := RecordAudit(ctx, AuditLog{
+			Action: AuditActionLoginLocked,
+			UserID: userID,
+			Email:  email,
+			IP:     ip,
+			Detail: fmt.Sprintf("failures=%d until=%s", failures, time.Now().Add(cfg.LockDuration).Format(time.RFC3339)),
+		}); err != nil {
+			log.Logger.Errorf("RecordLoginFailure - Failed to save audit log for %s: %v", email, err)
+		}
+		return &LoginBlockedError{Code: resp.ErrorCodeAuthAccountLocked, RetryAfter: cfg.LockDuration}
+	}
+	if d := cfg.delay(failures, cfg.FreeAttempts); d > 0 {
+		if err := redisutil.SetStringWithTTL(redisutil.RedisTargetUser, loginWaitKey("account", email), "1", d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordLoginSuccess 로그인에 성공하면 계정의 실패 횟수를 초기화한다. (IP 실패 횟수는 유지)
+func RecordLoginSuccess(ctx context.Context, email string) {
+	clearLoginFailures(normalizeEmail(email))
+}
+
+// UnlockLogin 계정 잠금과 실패 횟수를 초기화한다. 잠겨 있었으면 감사 로그를 남기고 true.
+// actorID는 관리자가 해제한 경우 관리자 ID, reason은 UnlockReason*.
+func UnlockLogin(ctx context.Context, email, userID, actorID, reason string) (bool, error) {
+	email = normalizeEmail(email)
+	lockKey := loginLockKey(email)
+	locked := redisutil.IsExist(redisutil.RedisTargetUser, lockKey)
+	if err := redisutil.Delete(redisutil.RedisTargetUser, lockKey); err != nil {
+		return false, err
+	}
+	clearLoginFailures(email)
+	if !locked {
+		return false, nil
+	}
+	if err := RecordAudit(ctx, AuditLog{
+		Action:  AuditActionLoginUnlocked,
+		UserID:  userID,
+		Email:   email,
+		ActorID: actorID,
+		Detail:  reason,
+	}); err != nil {
+		log.Logger.Errorf("UnlockLogin - Failed to save audit log for %s: %v", email, err)
+	}
+	return true, nil
+}
+
+// countFailure 실패 횟수를 올리고 Window만큼 만료를 미룬다.
+func countFailure(key string, window time.Duration) (int64, error) {
+	n, err := redisutil.Incr(redisutil.RedisTargetUser, key)
+	if err != nil {
+		return 0, err
+	}
+	if window > 0 {
+		redisutil.AddExpire(redisutil.RedisTargetUser, key, int(window.Seconds()))
+	}
+	return n, nil
+}
+
+func clearLoginFailures(email string) {
+	_ = redisutil.Delete(redisutil.RedisTargetUser, loginFailKey("account", email))
+	_ = redisutil.Delete(redisutil.RedisTargetUser, loginWaitKey("account", email))
+}
+
+// remaining 키의 남은 만료 시간. 없거나 조회에 실패하면 0.
+func remaining(key string) time.Duration {
+	if ttl := redisutil.GetExpireTime(redisutil.RedisTargetUser, key); ttl > 0 {
+		return time.Duration(ttl) * time.Second
+	}
+	return 0
+}
+
+// normalizeEmail 대소문자만 다른 이메일로 횟수를 나눠 세지 않도록 소문자로 맞춘다.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func loginFailKey(scope, id string) string {
+	return "auth:login:fail:" + scope + ":" + id
+}
+
+func loginWaitKey(scope, id string) string {
+	return "auth:login:wait:" + scope + ":" + id
+}
+
+func loginLockKey(email string) string {
+	return "auth:login:lock:" + email
+}
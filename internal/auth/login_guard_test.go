@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginGuard_Delay(t *testing.T) {
+	cfg := LoginGuardConfig{BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	cases := []struct {
+		failures int64
+		want     time.Duration
+	}{
+		{0, 0},
+		{3, 0}, // 허용 횟수까지는 대기 없음
+		{4, time.Second},
+		{5, 2 * time.Second},
+		{6, 4 * time.Second},
+		{7, 8 * time.Second},
+		{8, 10 * time.Second}, // 상한
+		{1000, 10 * time.Second},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, cfg.delay(tc.failures, 3), "failures=%d", tc.failures)
+	}
+
+	assert.Zero(t, LoginGuardConfig{}.delay(10, 0), "BaseDelay가 없으면 대기 없음")
+	assert.Equal(t, 64*time.Second, LoginGuardConfig{BaseDelay: time.Second}.delay(7, 0), "상한이 없으면 계속 두 배")
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	assert.Equal(t, "user@example.com", normalizeEmail("  User@Example.COM "))
+}
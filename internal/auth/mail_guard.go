@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	redisutil "github.com/Ryeom/board-game/infra/redis"
+	"github.com/Ryeom/board-game/log"
+	"github.com/spf13/viper"
+)
+
+// 메일 요청 종류 (AllowMailRequest의 purpose)
+const (
+	MailPurposePasswordReset = "password_reset" // 비밀번호 재설정 메일
+	MailPurposeVerifyEmail   = "verify_email"   // 이메일 인증 메일 재발송
+)
+
+// MailGuardConfig 메일 발송 요청 제한 설정 (bg.auth.mail)
+// 요청 종류마다 이메일과 IP별로 Window 안의 요청 수를 센다. 넘긴 요청은 메일을 보내지 않지만 응답은 같다.
+type MailGuardConfig struct {
+	Window   time.Duration // 첫 요청부터 이 시간 동안 요청 수를 센다
+	PerEmail int           // 이메일당 Window 안에 허용하는 요청 수 (0이면 제한 없음)
+	PerIP    int           // IP당 Window 안에 허용하는 요청 수 (0이면 제한 없음)
+}
+
+// MailGuard 서버 시작 시 bg.auth.mail 설정으로 바뀐다.
+var MailGuard = MailGuardConfig{
+	Window:   time.Hour,
+	PerEmail: 3,
+	PerIP:    20,
+}
+
+// loadMailGuard bg.auth.mail에 지정된 값만 기본값 대신 사용한다.
+func loadMailGuard() {
+	if viper.IsSet("bg.auth.mail.window") {
+		MailGuard.Window = viper.GetDuration("bg.auth.mail.window")
+	}
+	if viper.IsSet("bg.auth.mail.per-email") {
+		MailGuard.PerEmail = viper.GetInt("bg.auth.mail.per-email")
+	}
+	if viper.IsSet("bg.auth.mail.per-ip") {
+		MailGuard.PerIP = viper.GetInt("bg.auth.mail.per-ip")
+	}
+}
+
+// AllowMailRequest 메일 발송 요청을 세고 보내도 되는지 반환한다.
+// 제한에 걸린 요청도 세므로 계속 보내면 Window가 지날 때까지 막힌다. Redis 오류는 요청을 막지 않는다.
+func AllowMailRequest(ctx context.Context, purpose, email, ip string) bool {
+	cfg := MailGuard
+	allowed := true
+	if ip != "" && !withinLimit(mailRequestKey(purpose, "ip", ip), cfg.PerIP, cfg.Window) {
+		allowed = false
+	}
+	if !withinLimit(mailRequestKey(purpose, "email", normalizeEmail(email)), cfg.PerEmail, cfg.Window) {
+		allowed = false
+	}
+	return allowed
+}
+
+// withinLimit 요청 수를 올리고 limit 이하인지 확인한다. 첫 요청에서 window만큼 만료를 건다.
+func withinLimit(key string, limit int, window time.Duration) bool {
+	if limit <= 0 {
+		return true
+	}
+	n, err := redisutil.Incr(redisutil.RedisTargetUser, key)
+	if err != nil {
+		log.Logger.Errorf("withinLimit - Failed to count %s: %v", key, err)
+		return true
+	}
+	if n == 1 && window > 0 {
+		redisutil.AddExpire(redisutil.RedisTargetUser, key, int(window.Seconds()))
+	}
+	return n <= int64(limit)
+}
+
+func mailRequestKey(purpose, scope, id string) string {
+	return "auth:mail:" + purpose + ":" + scope + ":" + id
+}
@@ -6,7 +6,6 @@ import (
 
 	resp "github.com/Ryeom/board-game/internal/response"
 	"github.com/Ryeom/board-game/log"
-	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
 )
 
@@ -20,38 +19,21 @@ func JWTMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 
 		tokenOnly := strings.TrimPrefix(tokenStr, "Bearer ")
 
-		token, err := jwt.Parse(tokenOnly, func(token *jwt.Token) (interface{}, error) {
-			return jwtSecret, nil
-		})
-
+		identity, err := Authenticate(c.Request().Context(), tokenOnly)
 		if err != nil {
-			log.Logger.Errorf("JWTMiddleware - Token parsing failed: %v from IP: %s", err, c.RealIP())
-			return errors.New(resp.ErrorCodeAuthInvalidToken)
+			log.Logger.Warningf("JWTMiddleware - Authentication failed: %v from IP: %s", err, c.RealIP())
+			return err
 		}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok || !token.Valid {
-			log.Logger.Errorf("JWTMiddleware - Invalid token claims or token not valid from IP: %s", c.RealIP())
+		// 게스트 토큰은 WebSocket 전용 (DB 사용자가 없으므로 REST API 사용 불가)
+		if identity.Guest {
+			log.Logger.Warningf("JWTMiddleware - Guest token used for REST API by %s from IP: %s", identity.UserID, c.RealIP())
 			return errors.New(resp.ErrorCodeAuthInvalidToken)
 		}
 
-		isBlacklisted, err := IsTokenBlacklisted(c.Request().Context(), tokenOnly)
-		if err != nil {
-			log.Logger.Errorf("JWTMiddleware - Error checking blacklist for token: %v from IP: %s", err, c.RealIP())
-			return errors.New(resp.ErrorCodeAuthTokenBlacklistCheckFailed)
-		}
-		if isBlacklisted {
-			log.Logger.Warningf("JWTMiddleware - Blacklisted token detected for user ID: %s from IP: %s", claims["user_id"], c.RealIP())
-			return errors.New(resp.ErrorCodeAuthTokenBlacklisted)
-		}
-
-		// 클레임에서 "userID" 대신 "user_id" 사용
-		userID, ok := claims["user_id"].(string)
-		if !ok {
-			log.Logger.Errorf("JWTMiddleware - user_id claim not found or invalid type in token from IP: %s", c.RealIP())
-			return errors.New(resp.ErrorCodeAuthInvalidToken)
-		}
-		c.Set("userID", userID)
+		c.Set("userID", identity.UserID)
+		c.Set("sessionID", identity.SessionID)
+		c.Set("role", identity.Role)
 		return next(c)
 	}
 }
@@ -0,0 +1,297 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ryeom/board-game/infra/db"
+	redisutil "github.com/Ryeom/board-game/infra/redis"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/Ryeom/board-game/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// 세션 종료 사유
+const (
+	RevokeReasonLogout        = "logout"          // 로그아웃
+	RevokeReasonUser          = "revoked"         // 세션 목록에서 종료
+	RevokeReasonReuse         = "reuse_detected"  // 이미 교체된 refresh token이 다시 사용됨 (탈취 의심)
+	RevokeReasonPasswordReset = "password_reset"  // 비밀번호 재설정
+	RevokeReasonAccountDelete = "account_deleted" // 회원 탈퇴
+)
+
+// Session 로그인한 기기 하나. refresh token이 교체되어도 같은 세션으로 이어진다. (PostgreSQL auth_sessions)
+type Session struct {
+	ID           string     `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"sessionId"`
+	UserID       string     `gorm:"type:uuid;not null;index" json:"-"`
+	UserAgent    string     `gorm:"type:text" json:"userAgent"`
+	IP           string     `gorm:"type:varchar(64)" json:"ip"`
+	CreatedAt    time.Time  `json:"createdAt"`                                      // 로그인 시각
+	LastUsedAt   time.Time  `json:"lastUsedAt"`                                     // 마지막 토큰 갱신 시각
+	ExpiresAt    time.Time  `json:"expiresAt"`                                      // 현재 refresh token 만료 시각
+	RevokedAt    *time.Time `json:"revokedAt,omitempty"`                            // 종료 시각 (종료되지 않았으면 없음)
+	RevokeReason string     `gorm:"type:varchar(20)" json:"revokeReason,omitempty"` // RevokeReason*
+}
+
+func (Session) TableName() string { return "auth_sessions" }
+
+// RefreshToken 발급한 refresh token. 원문은 저장하지 않고 SHA-256 해시만 저장한다. (PostgreSQL refresh_tokens)
+type RefreshToken struct {
+	ID        string     `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	SessionID string     `gorm:"type:uuid;not null;index"`
+	TokenHash string     `gorm:"type:char(64);not null;uniqueIndex"`
+	ExpiresAt time.Time  `gorm:"not null"`
+	UsedAt    *time.Time // 새 토큰으로 교체된 시각. 교체된 토큰이 다시 오면 재사용으로 본다.
+	CreatedAt time.Time
+}
+
+// TokenPair 로그인/갱신 응답
+type TokenPair struct {
+	Token            string    `json:"token"`            // access token (Authorization: Bearer)
+	ExpiresIn        int       `json:"expiresIn"`        // access token 유효 시간(초)
+	RefreshToken     string    `json:"refreshToken"`     // POST /auth/refresh로 한 번만 사용할 수 있음
+	RefreshExpiresAt time.Time `json:"refreshExpiresAt"` // refresh token 만료 시각
+	SessionID        string    `json:"sessionId"`
+}
+
+// MigrateSessions auth_sessions, refresh_tokens, auth_audit_logs 테이블 생성/갱신
+func MigrateSessions(conn *gorm.DB) error {
+	return conn.AutoMigrate(&Session{}, &RefreshToken{}, &AuditLog{})
+}
+
+// StartSession 로그인한 기기의 세션을 만들고 첫 토큰을 발급한다.
+func StartSession(ctx context.Context, userID string, role user.Role, userAgent, ip string) (*TokenPair, error) {
+	now := time.Now()
+	session := &Session{
+		ID:         uuid.NewString(),
+		UserID:     userID,
+		UserAgent:  userAgent,
+		IP:         ip,
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(refreshTTL),
+	}
+	var pair *TokenPair
+	err := db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		var err error
+		pair, err = issueTokens(tx, session, role, now)
+		return err
+	})
+	if err != nil {
+		return nil, sessionError("StartSession", userID, err)
+	}
+	return pair, nil
+}
+
+// Refresh refresh token을 새 토큰으로 교체한다. 한 번 교체된 토큰이 다시 오면 탈취로 보고 세션 전체를 종료한다.
+// 재사용으로 세션을 종료했으면 *ReuseError를 반환한다. (연결된 WebSocket 정리용)
+func Refresh(ctx context.Context, refreshToken, userAgent, ip string) (*TokenPair, error) {
+	now := time.Now()
+	var stored RefreshToken
+	if err := db.DB.WithContext(ctx).Where("token_hash = ?", hashToken(refreshToken)).Take(&stored).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(resp.ErrorCodeAuthRefreshInvalid)
+		}
+		return nil, sessionError("Refresh", "", err)
+	}
+	var session Session
+	if err := db.DB.WithContext(ctx).Where("id = ?", stored.SessionID).Take(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(resp.ErrorCodeAuthRefreshInvalid)
+		}
+		return nil, sessionError("Refresh", "", err)
+	}
+	if session.RevokedAt != nil || now.After(stored.ExpiresAt) {
+		return nil, errors.New(resp.ErrorCodeAuthRefreshInvalid)
+	}
+	if stored.UsedAt != nil {
+		return nil, reuseDetected(ctx, &session)
+	}
+
+	account, err := user.FindUserByID(session.UserID)
+	if err != nil {
+		return nil, sessionError("Refresh", session.UserID, err)
+	}
+	if account == nil || !account.IsActive {
+		return nil, errors.New(resp.ErrorCodeAuthRefreshInvalid)
+	}
+
+	var pair *TokenPair
+	reused := false
+	err = db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 동시에 같은 토큰으로 갱신하면 한쪽만 성공하고 다른 쪽은 재사용으로 처리된다
+		result := tx.Model(&RefreshToken{}).Where("id = ? AND used_at IS NULL", stored.ID).Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			reused = true
+			return nil
+		}
+		session.UserAgent = userAgent
+		session.IP = ip
+		session.LastUsedAt = now
+		session.ExpiresAt = now.Add(refreshTTL)
+		if err := tx.Model(&session).Select("user_agent", "ip", "last_used_at", "expires_at").Updates(&session).Error; err != nil {
+			return err
+		}
+		pair, err = issueTokens(tx, &session, account.Role, now)
+		return err
+	})
+	if err != nil {
+		return nil, sessionError("Refresh", session.UserID, err)
+	}
+	if reused {
+		return nil, reuseDetected(ctx, &session)
+	}
+	return pair, nil
+}
+
+// reuseDetected 재사용된 refresh token의 세션을 종료하고 ERROR_AUTH_REFRESH_REUSED를 반환한다.
+func reuseDetected(ctx context.Context, session *Session) error {
+	log.Logger.Warningf("Refresh - Reused refresh token for session %s of user %s. Revoking session.", session.ID, session.UserID)
+	if _, err := revoke(ctx, session.UserID, []string{session.ID}, RevokeReasonReuse); err != nil {
+		log.Logger.Errorf("Refresh - Failed to revoke session %s after reuse: %v", session.ID, err)
+	}
+	return &ReuseError{SessionID: session.ID, UserID: session.UserID}
+}
+
+// ReuseError 재사용이 감지되어 종료된 세션. Error()는 응답 에러 코드.
+type ReuseError struct {
+	SessionID string
+	UserID    string
+}
+
+func (e *ReuseError) Error() string { return resp.ErrorCodeAuthRefreshReused }
+
+// ActiveSessions 종료되지 않고 만료되지 않은 세션을 최근 사용 순으로 반환한다.
+func ActiveSessions(ctx context.Context, userID string) ([]Session, error) {
+	var sessions []Session
+	err := db.DB.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, sessionError("ActiveSessions", userID, err)
+	}
+	return sessions, nil
+}
+
+// RevokeSession 유저의 세션 하나를 종료한다. 없거나 이미 종료된 세션이면 ERROR_AUTH_SESSION_NOT_FOUND.
+func RevokeSession(ctx context.Context, userID, sessionID, reason string) error {
+	if _, err := uuid.Parse(sessionID); err != nil {
+		return errors.New(resp.ErrorCodeAuthSessionNotFound)
+	}
+	revoked, err := revoke(ctx, userID, []string{sessionID}, reason)
+	if err != nil {
+		return sessionError("RevokeSession", userID, err)
+	}
+	if len(revoked) == 0 {
+		return errors.New(resp.ErrorCodeAuthSessionNotFound)
+	}
+	return nil
+}
+
+// RevokeAllSessions 유저의 모든 세션을 종료하고 종료한 세션 ID를 반환한다.
+func RevokeAllSessions(ctx context.Context, userID, reason string) ([]string, error) {
+	revoked, err := revoke(ctx, userID, nil, reason)
+	if err != nil {
+		return nil, sessionError("RevokeAllSessions", userID, err)
+	}
+	return revoked, nil
+}
+
+// IsSessionRevoked access token의 세션이 종료되었는지 확인한다. (종료 후 access token 유효 시간 동안 Redis에 표시)
+func IsSessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	val, err := redisutil.GetString(redisutil.RedisTargetUser, revokedSessionKey(sessionID))
+	if err != nil {
+		return false, fmt.Errorf("failed to check revoked session %s: %w", sessionID, err)
+	}
+	return val != "", nil
+}
+
+// revoke sessionIDs(nil이면 전부) 중 유저의 살아 있는 세션을 종료하고, 이미 발급된 access token도 거부되도록 Redis에 표시한다.
+func revoke(ctx context.Context, userID string, sessionIDs []string, reason string) ([]string, error) {
+	now := time.Now()
+	var revoked []string
+	err := db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&Session{}).Where("user_id = ? AND revoked_at IS NULL", userID)
+		if sessionIDs != nil {
+			query = query.Where("id IN ?", sessionIDs)
+		}
+		if err := query.Pluck("id", &revoked).Error; err != nil {
+			return err
+		}
+		if len(revoked) == 0 {
+			return nil
+		}
+		return tx.Model(&Session{}).Where("id IN ?", revoked).
+			Updates(map[string]any{"revoked_at": now, "revoke_reason": reason}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range revoked {
+		if err := redisutil.SetStringWithTTL(redisutil.RedisTargetUser, revokedSessionKey(id), reason, accessTTL); err != nil {
+			log.Logger.Errorf("revoke - Failed to mark session %s as revoked: %v", id, err)
+		}
+	}
+	return revoked, nil
+}
+
+// issueTokens 세션의 access token과 새 refresh token을 발급한다.
+func issueTokens(tx *gorm.DB, session *Session, role user.Role, now time.Time) (*TokenPair, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(raw)
+	if err := tx.Create(&RefreshToken{
+		SessionID: session.ID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: session.ExpiresAt,
+		CreatedAt: now,
+	}).Error; err != nil {
+		return nil, err
+	}
+	accessToken, err := GenerateJWT(session.UserID, session.ID, string(role))
+	if err != nil {
+		return nil, err
+	}
+	return &TokenPair{
+		Token:            accessToken,
+		ExpiresIn:        int(accessTTL.Seconds()),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: session.ExpiresAt,
+		SessionID:        session.ID,
+	}, nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func revokedSessionKey(sessionID string) string {
+	return "auth:session:revoked:" + sessionID
+}
+
+// sessionError 응답 코드가 정해진 에러는 그대로, 그 외에는 로그를 남기고 ERROR_AUTH_SESSION_FAILED로 바꾼다.
+func sessionError(op, userID string, err error) error {
+	if _, ok := resp.GetDefineCode(err.Error(), "ko"); ok {
+		return err
+	}
+	log.Logger.Errorf("%s - Auth session error for user %s: %v", op, userID, err)
+	return errors.New(resp.ErrorCodeAuthSessionFailed)
+}
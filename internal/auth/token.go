@@ -7,106 +7,281 @@ import (
 	"time"
 
 	redisutil "github.com/Ryeom/board-game/infra/redis"
+	resp "github.com/Ryeom/board-game/internal/response"
 	"github.com/Ryeom/board-game/log"
 	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
 	"github.com/spf13/viper"
 )
 
-var jwtSecret []byte
+// 토큰 기본 설정. 서명 알고리즘은 HS256만 허용한다.
+const (
+	defaultKeyID    = "default"
+	defaultIssuer   = "board-game"
+	defaultAudience = "board-game-client"
 
+	RoleGuest = "guest" // 게스트 토큰의 role 클레임 (가입 유저는 user.Role)
+)
+
+var signingMethod = jwt.SigningMethodHS256
+
+// 서명 키. 새 토큰은 currentKeyID로 서명하고, 검증은 kid 헤더로 키를 찾는다.
+var (
+	currentKeyID string
+	signingKeys  = map[string][]byte{}
+	issuer       = defaultIssuer
+	audience     = defaultAudience
+)
+
+// 설정이 없을 때(테스트 등)의 기본 유효 시간
+var (
+	accessTTL  = 15 * time.Minute
+	refreshTTL = 30 * 24 * time.Hour
+)
+
+// Initialize 서명 키와 토큰 설정을 읽는다.
+// jwt.secret은 현재 서명 키(bg.auth.key-id), jwt.previous-keys는 교체 전 키(kid = secret)로 검증에만 쓴다.
 func Initialize() {
-	jwtSecret = []byte(viper.GetString("jwt.secret"))
-	if len(jwtSecret) == 0 {
+	secret := []byte(viper.GetString("jwt.secret"))
+	if len(secret) == 0 {
 		panic("JWT secret is not configured or empty. Please set 'jwt.secret' in settings.toml.")
 	}
+	keyID := viper.GetString("bg.auth.key-id")
+	if keyID == "" {
+		keyID = defaultKeyID
+	}
+	keys := map[string][]byte{}
+	for kid, previous := range viper.GetStringMapString("jwt.previous-keys") {
+		if previous != "" {
+			keys[kid] = []byte(previous)
+		}
+	}
+	keys[keyID] = secret
+	setSigningKeys(keyID, keys)
+
+	if v := viper.GetString("bg.auth.issuer"); v != "" {
+		issuer = v
+	}
+	if v := viper.GetString("bg.auth.audience"); v != "" {
+		audience = v
+	}
+	if ttl := viper.GetDuration("bg.auth.access-ttl"); ttl > 0 {
+		accessTTL = ttl
+	}
+	if ttl := viper.GetDuration("bg.auth.refresh-ttl"); ttl > 0 {
+		refreshTTL = ttl
+	}
+	loadLoginGuard()
+	loadMailGuard()
+}
+
+func setSigningKeys(keyID string, keys map[string][]byte) {
+	currentKeyID = keyID
+	signingKeys = keys
 }
 
-func GenerateJWT(userID string) (string, error) {
-	claims := jwt.MapClaims{
+// GenerateJWT 로그인 세션의 access token 발급. sid 클레임으로 세션 종료 여부를 확인한다.
+func GenerateJWT(userID, sessionID, role string) (string, error) {
+	return signToken(jwt.MapClaims{
 		"user_id": userID,
-		"exp":     time.Now().Add(24 * time.Hour).Unix(),
+		"sid":     sessionID,
+		"role":    role,
+	}, accessTTL)
+}
+
+// GenerateGuestJWT 게스트 전용 토큰 발급. 게스트는 DB 사용자가 없으므로 닉네임을 클레임에 담는다.
+func GenerateGuestJWT(guestID, name string) (string, error) {
+	return signToken(jwt.MapClaims{
+		"user_id": guestID,
+		"name":    name,
+		"guest":   true,
+		"role":    RoleGuest,
+	}, 24*time.Hour)
+}
+
+// signToken 공통 클레임(jti, iat, exp, iss, aud)을 채우고 현재 키로 서명한다.
+func signToken(claims jwt.MapClaims, ttl time.Duration) (string, error) {
+	key, ok := signingKeys[currentKeyID]
+	if !ok {
+		return "", errors.New("signing key is not initialized")
 	}
+	now := time.Now()
+	claims["jti"] = uuid.NewString()
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(ttl).Unix()
+	claims["iss"] = issuer
+	claims["aud"] = audience
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(jwtSecret)
+	token := jwt.NewWithClaims(signingMethod, claims)
+	token.Header["kid"] = currentKeyID
+	return token.SignedString(key)
 }
 
-func ParseJWT(tokenStr string) (string, error) {
+// parseToken 서명 알고리즘(HS256), kid, 서명, 만료, 발급자, 대상, jti를 검증하고 클레임을 반환한다.
+func parseToken(tokenStr string) (jwt.MapClaims, error) {
 	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-		return jwtSecret, nil
-	})
-
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		userID, ok := claims["user_id"].(string)
+		if token.Method.Alg() != signingMethod.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+		key, ok := signingKeys[kid]
 		if !ok {
-			return "", errors.New("user_id claim not found or invalid type")
+			return nil, fmt.Errorf("unknown signing key: %q", kid)
 		}
-		return userID, nil
+		return key, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token claims or token not valid")
+	}
+	if !claims.VerifyIssuer(issuer, true) {
+		return nil, errors.New("invalid issuer")
+	}
+	if !claims.VerifyAudience(audience, true) {
+		return nil, errors.New("invalid audience")
 	}
+	if _, ok := claims["exp"]; !ok {
+		return nil, errors.New("exp claim not found")
+	}
+	if jti, _ := claims["jti"].(string); jti == "" {
+		return nil, errors.New("jti claim not found")
+	}
+	return claims, nil
+}
 
-	return "", err
+// Identity 검증이 끝난 토큰의 주체 정보
+type Identity struct {
+	UserID    string
+	Name      string // 게스트 토큰에서만 채워짐
+	Guest     bool
+	Admin     bool   // user.identify에서 DB 계정의 권한으로 채워짐
+	SessionID string // 로그인 세션 (게스트는 없음)
+	TokenID   string // jti (블랙리스트 키)
+	Role      string // 발급 시점의 role 클레임 (user.Role 또는 RoleGuest). 권한 판단은 DB 계정 기준
 }
 
-func GetTokenRemainingValidity(tokenStr string) (time.Duration, error) {
-	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-		return jwtSecret, nil
-	})
+// Authenticate 토큰의 서명/만료와 블랙리스트 여부를 검증하고 주체 정보를 반환한다.
+// 실패 시 응답 에러 코드를 담은 error를 반환한다.
+func Authenticate(ctx context.Context, tokenStr string) (*Identity, error) {
+	claims, err := parseToken(tokenStr)
+	if err != nil {
+		log.Logger.Warningf("Authenticate - Token parsing failed: %v", err)
+		return nil, errors.New(resp.ErrorCodeAuthInvalidToken)
+	}
 
+	userID, ok := claims["user_id"].(string)
+	if !ok || userID == "" {
+		return nil, errors.New(resp.ErrorCodeAuthInvalidToken)
+	}
+
+	jti := claims["jti"].(string)
+	isBlacklisted, err := IsTokenBlacklisted(ctx, jti)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse token: %w", err)
+		log.Logger.Errorf("Authenticate - Error checking blacklist for user ID %s: %v", userID, err)
+		return nil, errors.New(resp.ErrorCodeAuthTokenBlacklistCheckFailed)
+	}
+	if isBlacklisted {
+		log.Logger.Warningf("Authenticate - Blacklisted token detected for user ID: %s", userID)
+		return nil, errors.New(resp.ErrorCodeAuthTokenBlacklisted)
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
-		return 0, errors.New("invalid token claims or token not valid")
+	identity := &Identity{UserID: userID, TokenID: jti}
+	identity.Role, _ = claims["role"].(string)
+	if sid, _ := claims["sid"].(string); sid != "" {
+		revoked, err := IsSessionRevoked(ctx, sid)
+		if err != nil {
+			log.Logger.Errorf("Authenticate - Error checking session %s for user ID %s: %v", sid, userID, err)
+			return nil, errors.New(resp.ErrorCodeAuthTokenBlacklistCheckFailed)
+		}
+		if revoked {
+			log.Logger.Warningf("Authenticate - Token of revoked session %s used by user ID: %s", sid, userID)
+			return nil, errors.New(resp.ErrorCodeAuthSessionRevoked)
+		}
+		identity.SessionID = sid
+	}
+	if guest, _ := claims["guest"].(bool); guest {
+		identity.Guest = true
+		identity.Name, _ = claims["name"].(string)
+	}
+	return identity, nil
+}
+
+// ParseJWT 토큰을 검증하고 user_id를 반환한다. (블랙리스트는 확인하지 않음)
+func ParseJWT(tokenStr string) (string, error) {
+	claims, err := parseToken(tokenStr)
+	if err != nil {
+		return "", err
+	}
+	userID, ok := claims["user_id"].(string)
+	if !ok {
+		return "", errors.New("user_id claim not found or invalid type")
+	}
+	return userID, nil
+}
+
+// tokenIDAndExpiry 검증된 토큰의 jti와 남은 유효 시간
+func tokenIDAndExpiry(tokenStr string) (string, time.Duration, error) {
+	claims, err := parseToken(tokenStr)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to parse token: %w", err)
 	}
 
 	exp, ok := claims["exp"].(float64)
 	if !ok {
-		return 0, errors.New("exp claim not found or invalid type")
+		return "", 0, errors.New("exp claim not found or invalid type")
 	}
 
 	expirationTime := time.Unix(int64(exp), 0)
 	remainingTime := time.Until(expirationTime)
 
 	if remainingTime <= 0 {
-		return 0, nil // 이미 만료되었거나 남은 시간이 없는 경우
+		return claims["jti"].(string), 0, nil // 이미 만료되었거나 남은 시간이 없는 경우
 	}
 
-	return remainingTime, nil
+	return claims["jti"].(string), remainingTime, nil
+}
+
+func GetTokenRemainingValidity(tokenStr string) (time.Duration, error) {
+	_, remainingTime, err := tokenIDAndExpiry(tokenStr)
+	return remainingTime, err
 }
 
+// AddTokenToBlacklist 토큰의 jti를 남은 유효 시간 동안 블랙리스트에 올린다.
 func AddTokenToBlacklist(ctx context.Context, tokenStr string) error {
-	remainingTime, err := GetTokenRemainingValidity(tokenStr)
+	jti, remainingTime, err := tokenIDAndExpiry(tokenStr)
 	if err != nil {
 		// 토큰 파싱 또는 유효성 검증 실패 시 블랙리스트에 추가하지 않음
-		log.Logger.Warningf("AddTokenToBlacklist: Failed to get token validity for %s: %v", tokenStr, err)
+		log.Logger.Warningf("AddTokenToBlacklist: Failed to get token validity: %v", err)
 		return fmt.Errorf("invalid token for blacklisting: %w", err)
 	}
 	if remainingTime <= 0 {
 		// 이미 만료된 토큰은 블랙리스트에 추가할 필요 없음
-		log.Logger.Infof("AddTokenToBlacklist: Token %s is already expired, no need to blacklist.", tokenStr)
+		log.Logger.Infof("AddTokenToBlacklist: Token %s is already expired, no need to blacklist.", jti)
 		return nil
 	}
 
-	// 키는 "jwt:blacklist:<token_hash_or_jti>"
-	// TODO : Better -> JTI 사용
-	blacklistKey := fmt.Sprintf("jwt:blacklist:%s", tokenStr)
-	err = redisutil.SetStringWithTTL(redisutil.RedisTargetUser, blacklistKey, "blacklisted", remainingTime)
+	err = redisutil.SetStringWithTTL(redisutil.RedisTargetUser, blacklistKey(jti), "blacklisted", remainingTime)
 	if err != nil {
-		log.Logger.Errorf("Failed to add token %s to blacklist: %v", tokenStr, err)
+		log.Logger.Errorf("Failed to add token %s to blacklist: %v", jti, err)
 		return fmt.Errorf("failed to blacklist token: %w", err)
 	}
-	log.Logger.Infof("Token %s added to blacklist with TTL: %v", tokenStr, remainingTime)
+	log.Logger.Infof("Token %s added to blacklist with TTL: %v", jti, remainingTime)
 	return nil
 }
 
-func IsTokenBlacklisted(ctx context.Context, tokenStr string) (bool, error) {
-	blacklistKey := fmt.Sprintf("jwt:blacklist:%s", tokenStr)
-	val, err := redisutil.GetString(redisutil.RedisTargetUser, blacklistKey)
+// IsTokenBlacklisted jti가 블랙리스트에 있는지 확인
+func IsTokenBlacklisted(ctx context.Context, jti string) (bool, error) {
+	val, err := redisutil.GetString(redisutil.RedisTargetUser, blacklistKey(jti))
 	if err != nil {
-		return false, fmt.Errorf("failed to check blacklist for token %s: %w", tokenStr, err)
+		return false, fmt.Errorf("failed to check blacklist for token %s: %w", jti, err)
 	}
 
 	return val == "blacklisted", nil
 }
+
+func blacklistKey(jti string) string {
+	return "jwt:blacklist:" + jti
+}
@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useTestKeys(t *testing.T, keyID string, keys map[string][]byte) {
+	t.Helper()
+	prevID, prevKeys := currentKeyID, signingKeys
+	setSigningKeys(keyID, keys)
+	t.Cleanup(func() { setSigningKeys(prevID, prevKeys) })
+}
+
+func TestGenerateJWT_Claims(t *testing.T) {
+	useTestKeys(t, "k1", map[string][]byte{"k1": []byte("secret-1")})
+
+	tokenStr, err := GenerateJWT("user-1", "session-1", "admin")
+	require.NoError(t, err)
+	claims, err := parseToken(tokenStr)
+	require.NoError(t, err)
+
+	assert.Equal(t, "user-1", claims["user_id"])
+	assert.Equal(t, "session-1", claims["sid"])
+	assert.Equal(t, "admin", claims["role"])
+	assert.Equal(t, issuer, claims["iss"])
+	assert.Equal(t, audience, claims["aud"])
+	assert.NotEmpty(t, claims["jti"])
+	assert.NotNil(t, claims["iat"])
+
+	other, err := GenerateGuestJWT("guest-1", "Guest")
+	require.NoError(t, err)
+	otherClaims, err := parseToken(other)
+	require.NoError(t, err)
+	assert.NotEqual(t, claims["jti"], otherClaims["jti"], "토큰마다 jti가 다름")
+	assert.Equal(t, RoleGuest, otherClaims["role"])
+}
+
+func TestParseToken_KeyRotation(t *testing.T) {
+	useTestKeys(t, "k1", map[string][]byte{"k1": []byte("secret-1")})
+	oldToken, err := GenerateGuestJWT("guest-1", "Guest")
+	require.NoError(t, err)
+
+	// 새 키로 교체해도 이전 키로 서명된 토큰은 만료 전까지 검증됨
+	setSigningKeys("k2", map[string][]byte{"k1": []byte("secret-1"), "k2": []byte("secret-2")})
+	_, err = parseToken(oldToken)
+	assert.NoError(t, err)
+
+	newToken, err := GenerateGuestJWT("guest-2", "Guest")
+	require.NoError(t, err)
+	parsed, _, err := new(jwt.Parser).ParseUnverified(newToken, jwt.MapClaims{})
+	require.NoError(t, err)
+	assert.Equal(t, "k2", parsed.Header["kid"], "새 토큰은 현재 키로 서명")
+
+	// 이전 키를 제거하면 거부
+	setSigningKeys("k2", map[string][]byte{"k2": []byte("secret-2")})
+	_, err = parseToken(oldToken)
+	assert.Error(t, err)
+	_, err = parseToken(newToken)
+	assert.NoError(t, err)
+}
+
+func TestParseToken_Rejects(t *testing.T) {
+	key := []byte("secret-1")
+	useTestKeys(t, "k1", map[string][]byte{"k1": key})
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"user_id": "user-1",
+			"jti":     "jti-1",
+			"iat":     time.Now().Unix(),
+			"exp":     time.Now().Add(time.Hour).Unix(),
+			"iss":     issuer,
+			"aud":     audience,
+		}
+	}
+	sign := func(method jwt.SigningMethod, claims jwt.MapClaims, kid string, key any) string {
+		token := jwt.NewWithClaims(method, claims)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		tokenStr, err := token.SignedString(key)
+		require.NoError(t, err)
+		return tokenStr
+	}
+
+	_, err := parseToken(sign(jwt.SigningMethodHS256, validClaims(), "k1", key))
+	require.NoError(t, err, "기준 토큰은 통과")
+
+	cases := map[string]string{
+		"alg none":     sign(jwt.SigningMethodNone, validClaims(), "k1", jwt.UnsafeAllowNoneSignatureType),
+		"다른 HMAC 알고리즘": sign(jwt.SigningMethodHS512, validClaims(), "k1", key),
+		"kid 없음":       sign(jwt.SigningMethodHS256, validClaims(), "", key),
+		"알 수 없는 kid":   sign(jwt.SigningMethodHS256, validClaims(), "k9", key),
+		"잘못된 서명":       sign(jwt.SigningMethodHS256, validClaims(), "k1", []byte("other")),
+		"만료":           sign(jwt.SigningMethodHS256, with(validClaims(), "exp", time.Now().Add(-time.Minute).Unix()), "k1", key),
+		"다른 발급자":       sign(jwt.SigningMethodHS256, with(validClaims(), "iss", "someone-else"), "k1", key),
+		"다른 대상":        sign(jwt.SigningMethodHS256, with(validClaims(), "aud", "other-client"), "k1", key),
+		"jti 없음":       sign(jwt.SigningMethodHS256, without(validClaims(), "jti"), "k1", key),
+		"exp 없음":       sign(jwt.SigningMethodHS256, without(validClaims(), "exp"), "k1", key),
+	}
+	for name, tokenStr := range cases {
+		_, err := parseToken(tokenStr)
+		assert.Error(t, err, name)
+	}
+}
+
+func with(claims jwt.MapClaims, key string, value any) jwt.MapClaims {
+	claims[key] = value
+	return claims
+}
+
+func without(claims jwt.MapClaims, key string) jwt.MapClaims {
+	delete(claims, key)
+	return claims
+}
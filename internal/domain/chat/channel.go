@@ -0,0 +1,120 @@
+package chat
+
+import (
+	"errors"
+	"strings"
+
+	resp "github.com/Ryeom/board-game/internal/response"
+)
+
+// Channel 방 안의 채팅 채널
+type Channel string
+
+const (
+	ChannelAll        Channel = "all"        // 방 전체 (플레이어 + 관전자)
+	ChannelPlayers    Channel = "players"    // 플레이어끼리
+	ChannelSpectators Channel = "spectators" // 관전자끼리
+
+	teamChannelPrefix = "team:" // 팀 채널 (team:<팀 이름>). 팀이 있는 모드에서 같은 팀 플레이어끼리
+)
+
+// TeamChannel 팀 채널 이름
+func TeamChannel(team string) Channel {
+	return Channel(teamChannelPrefix + team)
+}
+
+// Team 팀 채널이면 팀 이름, 아니면 빈 문자열
+func (c Channel) Team() string {
+	if !strings.HasPrefix(string(c), teamChannelPrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(string(c), teamChannelPrefix)
+}
+
+// Valid 지원하는 채널인지 확인
+func (c Channel) Valid() bool {
+	switch c {
+	case ChannelAll, ChannelPlayers, ChannelSpectators:
+		return true
+	}
+	return c.Team() != ""
+}
+
+// Member 채널 권한을 판단할 때 쓰는 방 안에서의 위치
+type Member struct {
+	Player         bool   // 플레이어
+	Spectator      bool   // 관전자
+	Team           string // 팀이 있는 모드에서 소속 팀
+	GameInProgress bool   // 게임 진행 중 (관전자가 방 전체 채널에 쓸 수 없음)
+}
+
+// Readable 읽을 수 있는 채널 목록. 방에 없으면 비어 있다.
+func (m Member) Readable() []Channel {
+	var channels []Channel
+	if m.Player || m.Spectator {
+		channels = append(channels, ChannelAll)
+	}
+	if m.Player {
+		channels = append(channels, ChannelPlayers)
+		if m.Team != "" {
+			channels = append(channels, TeamChannel(m.Team))
+		}
+	}
+	if m.Spectator {
+		channels = append(channels, ChannelSpectators)
+	}
+	return channels
+}
+
+// CanRead 채널을 읽을 수 있는지 확인
+func (m Member) CanRead(c Channel) bool {
+	for _, readable := range m.Readable() {
+		if readable == c {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultChannel 채널을 지정하지 않은 chat.send의 채널. 관전자는 플레이어에게 정보가 새지 않도록 관전자 채널.
+func (m Member) DefaultChannel() Channel {
+	if m.Spectator {
+		return ChannelSpectators
+	}
+	return ChannelAll
+}
+
+// WriteChannel chat.send로 보낼 채널을 정한다. 비어 있으면 DefaultChannel.
+// 읽을 수 없는 채널이나 게임 중 관전자의 방 전체 채널은 ERROR_CHAT_CHANNEL_FORBIDDEN.
+func (m Member) WriteChannel(requested Channel) (Channel, error) {
+	if !m.Player && !m.Spectator {
+		return "", errors.New(resp.ErrorCodeChatNotInRoom)
+	}
+	if requested == "" {
+		return m.DefaultChannel(), nil
+	}
+	if !requested.Valid() {
+		return "", errors.New(resp.ErrorCodeChatChannelInvalid)
+	}
+	if !m.CanRead(requested) || (m.Spectator && m.GameInProgress && requested == ChannelAll) {
+		return "", errors.New(resp.ErrorCodeChatChannelForbidden)
+	}
+	return requested, nil
+}
+
+// HistoryChannels chat.history로 조회할 채널. 비어 있으면 읽을 수 있는 모든 채널.
+func (m Member) HistoryChannels(requested Channel) ([]Channel, error) {
+	if !m.Player && !m.Spectator {
+		return nil, errors.New(resp.ErrorCodeChatNotInRoom)
+	}
+	if requested == "" {
+		return m.Readable(), nil
+	}
+	if !requested.Valid() {
+		return nil, errors.New(resp.ErrorCodeChatChannelInvalid)
+	}
+	if !m.CanRead(requested) {
+		return nil, errors.New(resp.ErrorCodeChatChannelForbidden)
+	}
+	return []Channel{requested}, nil
+}
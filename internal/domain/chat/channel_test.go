@@ -0,0 +1,77 @@
+package chat
+
+import (
+	"testing"
+
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMember_Readable(t *testing.T) {
+	player := Member{Player: true, Team: "red"}
+	spectator := Member{Spectator: true}
+
+	assert.Equal(t, []Channel{ChannelAll, ChannelPlayers, TeamChannel("red")}, player.Readable())
+	assert.Equal(t, []Channel{ChannelAll, ChannelSpectators}, spectator.Readable())
+	assert.Empty(t, Member{}.Readable(), "방에 없으면 읽을 수 있는 채널이 없음")
+	assert.False(t, player.CanRead(TeamChannel("blue")), "다른 팀 채널")
+}
+
+func TestMember_WriteChannel(t *testing.T) {
+	player := Member{Player: true, GameInProgress: true}
+	spectator := Member{Spectator: true}
+	playingSpectator := Member{Spectator: true, GameInProgress: true}
+
+	c, err := player.WriteChannel("")
+	assert.NoError(t, err)
+	assert.Equal(t, ChannelAll, c)
+
+	c, err = spectator.WriteChannel("")
+	assert.NoError(t, err)
+	assert.Equal(t, ChannelSpectators, c, "관전자 기본 채널")
+
+	c, err = spectator.WriteChannel(ChannelAll)
+	assert.NoError(t, err)
+	assert.Equal(t, ChannelAll, c, "게임 전에는 관전자도 방 전체에 쓸 수 있음")
+
+	_, err = playingSpectator.WriteChannel(ChannelAll)
+	assert.EqualError(t, err, resp.ErrorCodeChatChannelForbidden, "게임 중 관전자는 플레이어에게 보낼 수 없음")
+	_, err = playingSpectator.WriteChannel(ChannelPlayers)
+	assert.EqualError(t, err, resp.ErrorCodeChatChannelForbidden)
+	_, err = player.WriteChannel(ChannelSpectators)
+	assert.EqualError(t, err, resp.ErrorCodeChatChannelForbidden)
+	_, err = player.WriteChannel(TeamChannel("red"))
+	assert.EqualError(t, err, resp.ErrorCodeChatChannelForbidden, "팀이 없는 플레이어")
+	_, err = player.WriteChannel("lobby")
+	assert.EqualError(t, err, resp.ErrorCodeChatChannelInvalid)
+	_, err = player.WriteChannel(TeamChannel(""))
+	assert.EqualError(t, err, resp.ErrorCodeChatChannelInvalid)
+}
+
+func TestMember_HistoryChannels(t *testing.T) {
+	spectator := Member{Spectator: true}
+
+	channels, err := spectator.HistoryChannels("")
+	assert.NoError(t, err)
+	assert.Equal(t, spectator.Readable(), channels)
+
+	channels, err = spectator.HistoryChannels(ChannelSpectators)
+	assert.NoError(t, err)
+	assert.Equal(t, []Channel{ChannelSpectators}, channels)
+
+	_, err = spectator.HistoryChannels(ChannelPlayers)
+	assert.EqualError(t, err, resp.ErrorCodeChatChannelForbidden)
+}
+
+func TestRecordChannel_LegacyRecords(t *testing.T) {
+	assert.Equal(t, ChannelSpectators, (&ChatRecord{Spectator: true}).RecordChannel())
+	assert.Equal(t, ChannelAll, (&ChatRecord{}).RecordChannel())
+	assert.Equal(t, ChannelAll, (&ChatRecord{Spectator: true, Channel: ChannelAll}).RecordChannel(), "채널이 있으면 그대로")
+}
+
+func TestMember_RequiresMembership(t *testing.T) {
+	_, err := Member{}.WriteChannel("")
+	assert.EqualError(t, err, resp.ErrorCodeChatNotInRoom)
+	_, err = Member{}.HistoryChannels("")
+	assert.EqualError(t, err, resp.ErrorCodeChatNotInRoom)
+}
@@ -0,0 +1,106 @@
+package chat
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"unicode"
+)
+
+const (
+	FilterModeMask   = "mask"   // 금칙어를 *로 가리고 전송
+	FilterModeReject = "reject" // 금칙어가 있으면 전송 거부
+)
+
+// WordFilter 금칙어 필터. 대소문자를 구분하지 않고 단어 안에 포함된 경우도 찾는다.
+type WordFilter struct {
+	words [][]rune
+}
+
+// NewWordFilter 금칙어 목록으로 필터 생성. 빈 단어는 무시한다.
+func NewWordFilter(words []string) *WordFilter {
+	f := &WordFilter{}
+	for _, w := range words {
+		w = strings.TrimSpace(w)
+		if w == "" {
+			continue
+		}
+		f.words = append(f.words, lowerRunes(w))
+	}
+	return f
+}
+
+// LoadWordFilter 파일들에서 금칙어를 읽는다. 한 줄에 한 단어, #으로 시작하는 줄은 주석.
+func LoadWordFilter(paths ...string) (*WordFilter, error) {
+	var words []string
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			words = append(words, line)
+		}
+		err = scanner.Err()
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return NewWordFilter(words), nil
+}
+
+// Len 등록된 금칙어 수
+func (f *WordFilter) Len() int {
+	if f == nil {
+		return 0
+	}
+	return len(f.words)
+}
+
+// Mask 금칙어를 글자 수만큼 *로 가린 메시지와 금칙어 포함 여부를 반환한다.
+func (f *WordFilter) Mask(message string) (string, bool) {
+	if f.Len() == 0 {
+		return message, false
+	}
+	runes := []rune(message)
+	lower := lowerRunes(message)
+	matched := false
+	for _, w := range f.words {
+		for i := 0; i+len(w) <= len(lower); i++ {
+			if !hasRunesAt(lower, w, i) {
+				continue
+			}
+			matched = true
+			for j := i; j < i+len(w); j++ {
+				runes[j] = '*'
+			}
+		}
+	}
+	if !matched {
+		return message, false
+	}
+	return string(runes), true
+}
+
+func lowerRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+func hasRunesAt(s, sub []rune, at int) bool {
+	for j, r := range sub {
+		if s[at+j] != r {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,50 @@
+package chat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordFilter_Mask(t *testing.T) {
+	f := NewWordFilter([]string{"badword", "시발", " "})
+	assert.Equal(t, 2, f.Len(), "빈 단어는 무시")
+
+	masked, matched := f.Mask("this is a BadWord!")
+	assert.True(t, matched)
+	assert.Equal(t, "this is a *******!", masked, "대소문자 무시, 글자 수만큼 가림")
+
+	masked, matched = f.Mask("아 시발진짜")
+	assert.True(t, matched)
+	assert.Equal(t, "아 **진짜", masked, "한글은 글자 단위로 가림")
+
+	masked, matched = f.Mask("안녕하세요 good game")
+	assert.False(t, matched)
+	assert.Equal(t, "안녕하세요 good game", masked)
+
+	var empty *WordFilter
+	masked, matched = empty.Mask("badword")
+	assert.False(t, matched, "필터가 없으면 그대로 통과")
+	assert.Equal(t, "badword", masked)
+}
+
+func TestLoadWordFilter_SkipsComments(t *testing.T) {
+	dir := t.TempDir()
+	ko := filepath.Join(dir, "ko.txt")
+	en := filepath.Join(dir, "en.txt")
+	require.NoError(t, os.WriteFile(ko, []byte("# 주석\n바보\n\n"), 0o644))
+	require.NoError(t, os.WriteFile(en, []byte("# comment\nnoob\n"), 0o644))
+
+	f, err := LoadWordFilter(ko, en)
+	require.NoError(t, err)
+	assert.Equal(t, 2, f.Len())
+
+	masked, _ := f.Mask("바보 noob")
+	assert.Equal(t, "** ****", masked)
+
+	_, err = LoadWordFilter(filepath.Join(dir, "missing.txt"))
+	assert.Error(t, err)
+}
@@ -1,10 +1,48 @@
 package chat
 
-import "time"
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RecordType 채팅 타임라인 기록 종류
+type RecordType string
+
+const (
+	RecordUser   RecordType = "user"   // 유저가 보낸 채팅
+	RecordSystem RecordType = "system" // 입장/퇴장/강퇴/방장 변경 등 방 이벤트
+	RecordGame   RecordType = "game"   // 게임 시작/힌트/플레이/결과 등 게임 이벤트
+)
 
 type ChatRecord struct {
-	SenderID   string    `json:"senderId"`   // 메시지 보낸 사용자 ID
-	SenderName string    `json:"senderName"` // 메시지 보낸 사용자 닉네임
-	Message    string    `json:"message"`    // 채팅 내용
-	Timestamp  time.Time `json:"timestamp"`  // 메시지 전송 시간
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`                        // 메시지 ID (chat.history의 before 커서)
+	RoomID     string             `json:"roomId,omitempty" bson:"roomId,omitempty"`       // 메시지가 속한 방
+	SenderID   string             `json:"senderId" bson:"senderId"`                       // 메시지 보낸 사용자 ID
+	SenderName string             `json:"senderName" bson:"senderName"`                   // 메시지 보낸 사용자 닉네임
+	Message    string             `json:"message" bson:"message"`                         // 채팅 내용
+	Timestamp  time.Time          `json:"timestamp" bson:"timestamp"`                     // 메시지 전송 시간
+	Spectator  bool               `json:"spectator,omitempty" bson:"spectator,omitempty"` // 보낸 사람이 관전자
+	Channel    Channel            `json:"channel" bson:"channel,omitempty"`               // 채팅 채널 (이전 기록은 비어 있으며 spectator면 관전자 채널, 아니면 방 전체)
+	Type       RecordType         `json:"type" bson:"type,omitempty"`                     // 기록 종류 (이전 기록은 비어 있으며 user로 취급)
+	Key        string             `json:"key,omitempty" bson:"key,omitempty"`             // system/game 기록의 메시지 템플릿 코드 (code.json)
+	Params     map[string]string  `json:"params,omitempty" bson:"params,omitempty"`       // 템플릿의 {이름} 자리에 들어갈 값
+	Subjects   []Subject          `json:"-" bson:"subjects,omitempty"`                     // params에 이름이 들어간 사용자 (탈퇴 시 익명화 기준)
+}
+
+// Subject system/game 기록의 params 중 사용자 이름이 들어간 자리와 그 사용자의 ID
+type Subject struct {
+	Param  string `bson:"param"`
+	UserID string `bson:"userId"`
+}
+
+// RecordChannel 기록의 채널. 채널이 없던 이전 기록은 관전자 채팅이면 관전자 채널, 아니면 방 전체.
+func (r *ChatRecord) RecordChannel() Channel {
+	if r.Channel != "" {
+		return r.Channel
+	}
+	if r.Spectator {
+		return ChannelSpectators
+	}
+	return ChannelAll
 }
@@ -0,0 +1,103 @@
+package chat
+
+import (
+	"context"
+	"time"
+
+	redisutil "github.com/Ryeom/board-game/infra/redis"
+	"github.com/Ryeom/board-game/log"
+	"github.com/redis/go-redis/v9"
+)
+
+// 채팅 제재 (user DB)
+//
+//	chat_mute:<roomID>:<userID>   방장이 건 방 단위 음소거, 만료 시각에 맞춘 TTL
+//	chat_mute:global:<userID>     관리자가 건 전체 음소거
+//	chat_flood:<userID>           도배 방지 카운터, 윈도우 길이만큼 TTL
+const (
+	muteKeyPrefix  = "chat_mute:"
+	floodKeyPrefix = "chat_flood:"
+	globalScope    = "global"
+
+	MutedBySystem = "system" // 도배로 자동 음소거된 경우의 MutedBy
+)
+
+// Mute 채팅 음소거 정보
+type Mute struct {
+	UserID  string    `json:"userId"`
+	RoomID  string    `json:"roomId,omitempty"` // 비어 있으면 전체 음소거
+	MutedBy string    `json:"mutedBy"`
+	Reason  string    `json:"reason,omitempty"`
+	Until   time.Time `json:"until"`
+}
+
+// Global 관리자가 건 전체 음소거인지
+func (m *Mute) Global() bool {
+	return m.RoomID == ""
+}
+
+func muteKey(roomID, userID string) string {
+	if roomID == "" {
+		roomID = globalScope
+	}
+	return muteKeyPrefix + roomID + ":" + userID
+}
+
+func floodKey(userID string) string {
+	return floodKeyPrefix + userID
+}
+
+// SetMute 음소거를 저장한다. 같은 범위의 기존 음소거는 덮어쓴다.
+func SetMute(ctx context.Context, m *Mute) error {
+	return redisutil.SaveJSON(redisutil.RedisTargetUser, muteKey(m.RoomID, m.UserID), m, time.Until(m.Until))
+}
+
+// ClearMute 음소거 해제. 해제할 음소거가 있었으면 true.
+func ClearMute(ctx context.Context, roomID, userID string) (bool, error) {
+	rdb := redisutil.Client[redisutil.RedisTargetUser]
+	n, err := rdb.Del(ctx, muteKey(roomID, userID)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// ActiveMute 채팅을 막고 있는 음소거. 전체 음소거를 먼저 확인하고 없으면 nil.
+func ActiveMute(ctx context.Context, roomID, userID string) *Mute {
+	keys := []string{muteKey("", userID)}
+	if roomID != "" {
+		keys = append(keys, muteKey(roomID, userID))
+	}
+	for _, key := range keys {
+		var m Mute
+		if redisutil.GetJSON(redisutil.RedisTargetUser, key, &m) && time.Now().Before(m.Until) {
+			return &m
+		}
+	}
+	return nil
+}
+
+// KEYS: 카운터 / ARGV: 윈도우(ms). 윈도우 안의 전송 횟수를 반환한다.
+var floodScript = redis.NewScript(`
+local n = redis.call('INCR', KEYS[1])
+if n == 1 then
+  redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return n
+`)
+
+// AllowMessage 유저의 채팅 전송 횟수를 세고 window 안에 limit을 넘으면 false.
+// 같은 유저가 여러 연결/인스턴스에서 보내도 합산된다. limit이 0 이하면 제한하지 않는다.
+func AllowMessage(ctx context.Context, userID string, limit int, window time.Duration) bool {
+	if limit <= 0 || window <= 0 {
+		return true
+	}
+	rdb := redisutil.Client[redisutil.RedisTargetUser]
+	n, err := floodScript.Run(ctx, rdb, []string{floodKey(userID)}, window.Milliseconds()).Int()
+	if err != nil {
+		// Redis 장애로 채팅 전체가 막히지 않도록 통과시킨다 (WS 이벤트 토큰 버킷은 그대로 적용됨)
+		log.Logger.Errorf("chat.AllowMessage - Failed to count messages for %s: %v", userID, err)
+		return true
+	}
+	return n <= limit
+}
@@ -2,50 +2,114 @@ package chat
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/Ryeom/board-game/infra/mongo"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/user"
 	"github.com/Ryeom/board-game/log"
 )
 
-const MaxChatHistory = 50
+const (
+	MaxChatHistory      = 50  // chat.history 기본 페이지 크기
+	MaxChatHistoryLimit = 100 // 한 번에 조회할 수 있는 최대 메시지 수
+)
 
 func SaveChatMessage(ctx context.Context, roomID string, record *ChatRecord) error {
 	collection := mongo.GetCollection(mongo.ChatCollection)
 
+	if record.Type == "" {
+		record.Type = RecordUser
+	}
+	if record.Channel == "" {
+		record.Channel = ChannelAll
+	}
+	record.RoomID = roomID
 	messageDoc := bson.M{
 		"roomId":     roomID,
 		"senderId":   record.SenderID,
 		"senderName": record.SenderName,
 		"message":    record.Message,
 		"timestamp":  record.Timestamp,
+		"type":       record.Type,
+		"channel":    record.Channel,
+	}
+	if record.Spectator {
+		messageDoc["spectator"] = true
+	}
+	if record.Key != "" {
+		messageDoc["key"] = record.Key
+		if len(record.Params) > 0 {
+			messageDoc["params"] = record.Params
+		}
 	}
 
-	_, err := collection.InsertOne(ctx, messageDoc)
+	result, err := collection.InsertOne(ctx, messageDoc)
 	if err != nil {
 		log.Logger.Errorf("chat.Service - Failed to insert chat message into MongoDB for room %s: %v", roomID, err)
 		return fmt.Errorf("failed to save chat message to MongoDB: %w", err)
 	}
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		record.ID = id
+	}
 
 	return nil
 }
 
-func GetChatHistory(ctx context.Context, roomID string) ([]*ChatRecord, error) {
+// HistoryQuery chat.history 조회 조건
+type HistoryQuery struct {
+	Before  string  `json:"before,omitempty"`  // 이전 페이지의 nextCursor(메시지 ID) 또는 RFC3339 시각. 이보다 오래된 메시지만 조회
+	Limit   int     `json:"limit,omitempty"`   // 기본 MaxChatHistory, 최대 MaxChatHistoryLimit
+	Search  string  `json:"search,omitempty"`  // 메시지 부분 일치 (대소문자 무시)
+	Channel Channel `json:"channel,omitempty"` // 한 채널만 조회 (비우면 읽을 수 있는 모든 채널)
+
+	Channels []Channel `json:"-"` // 조회할 채널 (Member.HistoryChannels). 비어 있으면 채널 구분 없이 모두
+}
+
+// GetChatHistory 방의 채팅을 최신순으로 한 페이지 읽어 오래된 순으로 반환한다.
+// 더 오래된 메시지가 남아 있으면 nextCursor(이번 페이지에서 가장 오래된 메시지 ID)를 함께 반환한다.
+func GetChatHistory(ctx context.Context, roomID string, q HistoryQuery) ([]*ChatRecord, string, error) {
 	collection := mongo.GetCollection(mongo.ChatCollection)
 
+	if q.Limit <= 0 {
+		q.Limit = MaxChatHistory
+	}
+	if q.Limit > MaxChatHistoryLimit {
+		return nil, "", errors.New(resp.ErrorCodeChatHistoryInvalidQuery)
+	}
+
 	filter := bson.M{"roomId": roomID}
+	if len(q.Channels) > 0 {
+		filter["$and"] = bson.A{bson.M{"$or": channelFilter(q.Channels)}}
+	}
+	if search := strings.TrimSpace(q.Search); search != "" {
+		filter["message"] = bson.M{"$regex": regexp.QuoteMeta(search), "$options": "i"}
+	}
+	if q.Before != "" {
+		before, err := beforeFilter(ctx, collection, roomID, q.Before)
+		if err != nil {
+			return nil, "", err
+		}
+		filter["$or"] = before
+	}
 
 	findOptions := options.Find()
-	findOptions.SetSort(bson.D{{Key: "timestamp", Value: -1}})
-	findOptions.SetLimit(MaxChatHistory)
+	findOptions.SetSort(bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}})
+	findOptions.SetLimit(int64(q.Limit + 1))
 
 	cursor, err := collection.Find(ctx, filter, findOptions)
 	if err != nil {
 		log.Logger.Errorf("chat.Service - Failed to retrieve chat history from MongoDB for room %s: %v", roomID, err)
-		return nil, fmt.Errorf("failed to retrieve chat history from MongoDB: %w", err)
+		return nil, "", fmt.Errorf("failed to retrieve chat history from MongoDB: %w", err)
 	}
 	defer cursor.Close(ctx)
 
@@ -56,17 +120,118 @@ func GetChatHistory(ctx context.Context, roomID string) ([]*ChatRecord, error) {
 			log.Logger.Errorf("chat.Service - Failed to decode chat record from MongoDB: %v", err)
 			continue
 		}
+		if record.Type == "" {
+			record.Type = RecordUser
+		}
+		record.Channel = record.RecordChannel()
 		chatRecords = append(chatRecords, &record)
 	}
 
 	if err = cursor.Err(); err != nil {
 		log.Logger.Errorf("chat.Service - Cursor error during chat history retrieval: %v", err)
-		return nil, fmt.Errorf("cursor error during chat history retrieval: %w", err)
+		return nil, "", fmt.Errorf("cursor error during chat history retrieval: %w", err)
+	}
+
+	nextCursor := ""
+	if len(chatRecords) > q.Limit {
+		chatRecords = chatRecords[:q.Limit]
+		nextCursor = chatRecords[q.Limit-1].ID.Hex()
 	}
 
 	for i, j := 0, len(chatRecords)-1; i < j; i, j = i+1, j-1 {
 		chatRecords[i], chatRecords[j] = chatRecords[j], chatRecords[i]
 	}
 
-	return chatRecords, nil
+	return chatRecords, nextCursor, nil
+}
+
+// beforeFilter before 커서보다 오래된 메시지 조건. 메시지 ID면 같은 시각의 메시지는 _id로 순서를 정한다.
+func beforeFilter(ctx context.Context, collection *mongodriver.Collection, roomID string, before string) (bson.A, error) {
+	if id, err := primitive.ObjectIDFromHex(before); err == nil {
+		var anchor ChatRecord
+		err := collection.FindOne(ctx, bson.M{"_id": id, "roomId": roomID}).Decode(&anchor)
+		if err != nil {
+			if errors.Is(err, mongodriver.ErrNoDocuments) {
+				return nil, errors.New(resp.ErrorCodeChatHistoryInvalidQuery)
+			}
+			return nil, fmt.Errorf("failed to load chat history cursor: %w", err)
+		}
+		return bson.A{
+			bson.M{"timestamp": bson.M{"$lt": anchor.Timestamp}},
+			bson.M{"timestamp": anchor.Timestamp, "_id": bson.M{"$lt": id}},
+		}, nil
+	}
+	ts, err := time.Parse(time.RFC3339Nano, before)
+	if err != nil {
+		return nil, errors.New(resp.ErrorCodeChatHistoryInvalidQuery)
+	}
+	return bson.A{bson.M{"timestamp": bson.M{"$lt": ts}}}, nil
+}
+
+// channelFilter channels 중 하나에 속한 메시지 조건. 채널이 없던 이전 기록은 spectator 여부로 방 전체/관전자 채널에 속한다.
+func channelFilter(channels []Channel) bson.A {
+	conditions := bson.A{bson.M{"channel": bson.M{"$in": channels}}}
+	for _, c := range channels {
+		switch c {
+		case ChannelAll:
+			conditions = append(conditions, bson.M{"channel": bson.M{"$exists": false}, "spectator": bson.M{"$ne": true}})
+		case ChannelSpectators:
+			conditions = append(conditions, bson.M{"channel": bson.M{"$exists": false}, "spectator": true})
+		}
+	}
+	return conditions
+}
+
+// MessagesBySender 유저가 보낸 방 채팅을 모든 방에서 오래된 순으로 반환한다. (개인 정보 내보내기)
+func MessagesBySender(ctx context.Context, senderID string) ([]*ChatRecord, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := mongo.GetCollection(mongo.ChatCollection).Find(ctx, bson.M{"senderId": senderID}, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve chat messages by sender: %w", err)
+	}
+	records := []*ChatRecord{}
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("failed to decode chat messages by sender: %w", err)
+	}
+	for _, record := range records {
+		if record.Type == "" {
+			record.Type = RecordUser
+		}
+		record.Channel = record.RecordChannel()
+	}
+	return records, nil
+}
+
+// AnonymizeSender 탈퇴한 유저가 보낸 방 채팅의 보낸 사람과 system/game 기록에 남은 이름을 익명으로 바꾼다.
+// 내용은 대화 흐름을 위해 남긴다.
+func AnonymizeSender(ctx context.Context, senderID string) error {
+	collection := mongo.GetCollection(mongo.ChatCollection)
+	_, err := collection.UpdateMany(ctx,
+		bson.M{"senderId": senderID},
+		bson.M{"$set": bson.M{"senderId": user.DeletedUserID, "senderName": user.DeletedUserName}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to anonymize chat messages: %w", err)
+	}
+
+	cursor, err := collection.Find(ctx, bson.M{"subjects.userId": senderID})
+	if err != nil {
+		return fmt.Errorf("failed to find timeline records of user: %w", err)
+	}
+	records := []*ChatRecord{}
+	if err := cursor.All(ctx, &records); err != nil {
+		return fmt.Errorf("failed to decode timeline records of user: %w", err)
+	}
+	for _, record := range records {
+		record.anonymizeSubject(senderID)
+		_, err := collection.UpdateByID(ctx, record.ID, bson.M{"$set": bson.M{
+			"params":   record.Params,
+			"subjects": record.Subjects,
+			"message":  record.Message,
+		}})
+		if err != nil {
+			return fmt.Errorf("failed to anonymize timeline record %s: %w", record.ID.Hex(), err)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,63 @@
+package chat
+
+import (
+	"strings"
+	"time"
+
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/Ryeom/board-game/internal/util"
+)
+
+// NewEventRecord 서버가 남기는 system/game 기록. Message는 기본 언어로 채워 저장되고(검색용),
+// 전달/조회할 때 Localize로 수신자 언어에 맞춰 다시 만든다. subjects는 params에 이름이 들어간 사용자.
+func NewEventRecord(recordType RecordType, key string, params map[string]string, subjects ...Subject) *ChatRecord {
+	record := &ChatRecord{
+		Type:      recordType,
+		Key:       key,
+		Params:    params,
+		Subjects:  subjects,
+		Timestamp: time.Now(),
+	}
+	record.Localize(util.DefaultLanguage)
+	return record
+}
+
+// Localize 템플릿으로 만든 기록의 Message를 lang으로 다시 만든다. 유저 채팅은 그대로 둔다.
+func (r *ChatRecord) Localize(lang string) {
+	if r.Key == "" {
+		return
+	}
+	r.Message = Render(r.Key, r.Params, lang)
+}
+
+// anonymizeSubject userID의 이름이 들어간 params를 탈퇴한 사용자로 바꾸고 저장용 메시지를 다시 만든다.
+func (r *ChatRecord) anonymizeSubject(userID string) {
+	for i, subject := range r.Subjects {
+		if subject.UserID != userID {
+			continue
+		}
+		r.Subjects[i].UserID = user.DeletedUserID
+		if _, ok := r.Params[subject.Param]; ok {
+			r.Params[subject.Param] = user.DeletedUserName
+		}
+	}
+	r.Localize(util.DefaultLanguage)
+}
+
+// Render code.json의 key 메시지를 lang으로 찾아 {이름} 자리에 params 값을 넣는다. 정의되지 않은 key면 key 그대로.
+func Render(key string, params map[string]string, lang string) string {
+	def, ok := resp.GetDefineCode(key, util.NormalizeLanguage(lang))
+	if !ok {
+		return key
+	}
+	message := def.Message
+	if len(params) == 0 {
+		return message
+	}
+	pairs := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(message)
+}
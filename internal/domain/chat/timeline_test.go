@@ -0,0 +1,42 @@
+package chat
+
+import (
+	"testing"
+
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender_FillsParamsPerLanguage(t *testing.T) {
+	params := map[string]string{"from": "Alice", "to": "Bob", "color": "red", "count": "2"}
+
+	assert.Equal(t, "Alice hinted Bob: 2 red", Render(resp.TimelineCodeHintColor, params, "en-US"))
+	assert.Equal(t, "Alice님이 Bob님에게 힌트: red 2장", Render(resp.TimelineCodeHintColor, params, "ko"))
+	assert.Equal(t, "UNKNOWN_KEY", Render("UNKNOWN_KEY", params, "en"), "정의되지 않은 템플릿은 key 그대로")
+}
+
+func TestLocalize_KeepsUserMessages(t *testing.T) {
+	record := NewEventRecord(RecordSystem, resp.TimelineCodeJoined, map[string]string{"user": "Alice"})
+	assert.Equal(t, "Alice님이 입장했습니다.", record.Message, "저장용 메시지는 기본 언어")
+
+	record.Localize("en")
+	assert.Equal(t, "Alice joined the room.", record.Message)
+
+	userRecord := &ChatRecord{Type: RecordUser, Message: "hello"}
+	userRecord.Localize("en")
+	assert.Equal(t, "hello", userRecord.Message)
+}
+
+func TestAnonymizeSubject_ReplacesOnlyDeletedUsersName(t *testing.T) {
+	record := NewEventRecord(RecordGame, resp.TimelineCodeHintColor,
+		map[string]string{"from": "Alice", "to": "Bob", "color": "red", "count": "2"},
+		Subject{Param: "from", UserID: "alice-id"}, Subject{Param: "to", UserID: "bob-id"})
+
+	record.anonymizeSubject("alice-id")
+	assert.Equal(t, user.DeletedUserName, record.Params["from"])
+	assert.Equal(t, "Bob", record.Params["to"], "다른 사용자의 이름은 남김")
+	assert.Equal(t, []Subject{{Param: "from", UserID: user.DeletedUserID}, {Param: "to", UserID: "bob-id"}}, record.Subjects)
+	assert.NotContains(t, record.Message, "Alice", "저장용 메시지도 다시 만듦")
+	assert.Contains(t, record.Message, "Bob")
+}
@@ -0,0 +1,19 @@
+package dm
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Message 친구 사이의 1:1 메시지
+type Message struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`                  // 메시지 ID (dm.history의 before 커서)
+	Pair        string             `json:"-" bson:"pair"`                            // 대화 키 (PairKey)
+	SenderID    string             `json:"senderId" bson:"senderId"`                 // 보낸 사용자 ID
+	SenderName  string             `json:"senderName" bson:"senderName"`             // 보낸 사용자 닉네임
+	RecipientID string             `json:"recipientId" bson:"recipientId"`           // 받는 사용자 ID
+	Message     string             `json:"message" bson:"message"`                   // 내용
+	Timestamp   time.Time          `json:"timestamp" bson:"timestamp"`               // 전송 시각
+	ReadAt      *time.Time         `json:"readAt,omitempty" bson:"readAt,omitempty"` // 받는 사람이 읽은 시각 (안 읽었으면 없음)
+}
@@ -0,0 +1,191 @@
+package dm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/Ryeom/board-game/infra/mongo"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/user"
+)
+
+const (
+	DefaultHistoryLimit = 50  // dm.history 기본 페이지 크기
+	MaxHistoryLimit     = 100 // 한 번에 조회할 수 있는 최대 메시지 수
+)
+
+// PairKey 두 유저의 대화 키. 순서와 관계없이 같은 값이 나온다.
+func PairKey(a, b string) string {
+	if a > b {
+		a, b = b, a
+	}
+	return a + ":" + b
+}
+
+// Save 메시지를 저장하고 ID를 채운다.
+func Save(ctx context.Context, m *Message) error {
+	m.Pair = PairKey(m.SenderID, m.RecipientID)
+	m.ID = primitive.NilObjectID
+	result, err := mongo.GetCollection(mongo.DirectMessageCollection).InsertOne(ctx, m)
+	if err != nil {
+		return fmt.Errorf("failed to save direct message: %w", err)
+	}
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		m.ID = id
+	}
+	return nil
+}
+
+// HistoryQuery dm.history 조회 조건
+type HistoryQuery struct {
+	Before string `json:"before,omitempty"` // 이전 페이지의 nextCursor(메시지 ID) 또는 RFC3339 시각
+	Limit  int    `json:"limit,omitempty"`  // 기본 DefaultHistoryLimit, 최대 MaxHistoryLimit
+}
+
+// History me와 other의 대화를 최신순으로 한 페이지 읽어 오래된 순으로 반환한다.
+// 더 오래된 메시지가 있으면 nextCursor(이번 페이지에서 가장 오래된 메시지 ID)를 함께 반환한다.
+func History(ctx context.Context, me, other string, q HistoryQuery) ([]*Message, string, error) {
+	collection := mongo.GetCollection(mongo.DirectMessageCollection)
+
+	if q.Limit <= 0 {
+		q.Limit = DefaultHistoryLimit
+	}
+	if q.Limit > MaxHistoryLimit {
+		return nil, "", errors.New(resp.ErrorCodeDMInvalidQuery)
+	}
+
+	pair := PairKey(me, other)
+	filter := bson.M{"pair": pair}
+	if q.Before != "" {
+		before, err := beforeFilter(ctx, collection, pair, q.Before)
+		if err != nil {
+			return nil, "", err
+		}
+		filter["$or"] = before
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}})
+	findOptions.SetLimit(int64(q.Limit + 1))
+
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to retrieve direct messages: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	messages := make([]*Message, 0, q.Limit+1)
+	if err = cursor.All(ctx, &messages); err != nil {
+		return nil, "", fmt.Errorf("failed to decode direct messages: %w", err)
+	}
+
+	nextCursor := ""
+	if len(messages) > q.Limit {
+		messages = messages[:q.Limit]
+		nextCursor = messages[q.Limit-1].ID.Hex()
+	}
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+	return messages, nextCursor, nil
+}
+
+// beforeFilter before 커서보다 오래된 메시지 조건. 메시지 ID는 같은 대화의 메시지여야 한다.
+func beforeFilter(ctx context.Context, collection *mongodriver.Collection, pair string, before string) (bson.A, error) {
+	if id, err := primitive.ObjectIDFromHex(before); err == nil {
+		var anchor Message
+		err := collection.FindOne(ctx, bson.M{"_id": id, "pair": pair}).Decode(&anchor)
+		if err != nil {
+			if errors.Is(err, mongodriver.ErrNoDocuments) {
+				return nil, errors.New(resp.ErrorCodeDMInvalidQuery)
+			}
+			return nil, fmt.Errorf("failed to load direct message cursor: %w", err)
+		}
+		return bson.A{
+			bson.M{"timestamp": bson.M{"$lt": anchor.Timestamp}},
+			bson.M{"timestamp": anchor.Timestamp, "_id": bson.M{"$lt": id}},
+		}, nil
+	}
+	ts, err := time.Parse(time.RFC3339Nano, before)
+	if err != nil {
+		return nil, errors.New(resp.ErrorCodeDMInvalidQuery)
+	}
+	return bson.A{bson.M{"timestamp": bson.M{"$lt": ts}}}, nil
+}
+
+// MarkRead other가 me에게 보낸 안 읽은 메시지를 모두 읽음 처리하고 처리한 수를 반환한다.
+func MarkRead(ctx context.Context, me, other string) (int64, error) {
+	result, err := mongo.GetCollection(mongo.DirectMessageCollection).UpdateMany(ctx,
+		bson.M{"pair": PairKey(me, other), "recipientId": me, "readAt": nil},
+		bson.M{"$set": bson.M{"readAt": time.Now()}},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark direct messages read: %w", err)
+	}
+	return result.ModifiedCount, nil
+}
+
+// UnreadCounts me가 안 읽은 메시지 수 (보낸 사람 ID별). 안 읽은 메시지가 없는 상대는 빠진다.
+func UnreadCounts(ctx context.Context, me string) (map[string]int64, error) {
+	cursor, err := mongo.GetCollection(mongo.DirectMessageCollection).Aggregate(ctx, mongodriver.Pipeline{
+		{{Key: "$match", Value: bson.M{"recipientId": me, "readAt": nil}}},
+		{{Key: "$group", Value: bson.M{"_id": "$senderId", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread direct messages: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		SenderID string `bson:"_id"`
+		Count    int64  `bson:"count"`
+	}
+	if err = cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode unread direct message counts: %w", err)
+	}
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.SenderID] = row.Count
+	}
+	return counts, nil
+}
+
+// SentBy 유저가 보낸 메시지를 오래된 순으로 반환한다. (개인 정보 내보내기)
+func SentBy(ctx context.Context, senderID string) ([]*Message, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := mongo.GetCollection(mongo.DirectMessageCollection).Find(ctx, bson.M{"senderId": senderID}, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve sent direct messages: %w", err)
+	}
+	messages := []*Message{}
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, fmt.Errorf("failed to decode sent direct messages: %w", err)
+	}
+	return messages, nil
+}
+
+// AnonymizeUser 탈퇴한 유저가 보내고 받은 메시지의 ID와 이름을 익명으로 바꾼다. (탈퇴)
+// 상대방이 대화 기록을 계속 볼 수 있도록 메시지와 대화 키(pair)는 남긴다.
+func AnonymizeUser(ctx context.Context, userID string) error {
+	collection := mongo.GetCollection(mongo.DirectMessageCollection)
+	if _, err := collection.UpdateMany(ctx,
+		bson.M{"senderId": userID},
+		bson.M{"$set": bson.M{"senderId": user.DeletedUserID, "senderName": user.DeletedUserName}},
+	); err != nil {
+		return fmt.Errorf("failed to anonymize sent direct messages: %w", err)
+	}
+	if _, err := collection.UpdateMany(ctx,
+		bson.M{"recipientId": userID},
+		bson.M{"$set": bson.M{"recipientId": user.DeletedUserID}},
+	); err != nil {
+		return fmt.Errorf("failed to anonymize received direct messages: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package dm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPairKey_IsOrderIndependent(t *testing.T) {
+	assert.Equal(t, PairKey("a", "b"), PairKey("b", "a"))
+	assert.NotEqual(t, PairKey("a", "b"), PairKey("a", "c"))
+}
@@ -0,0 +1,21 @@
+package friend
+
+import "time"
+
+type Status string
+
+const (
+	StatusPending  Status = "pending"  // UserID가 FriendID에게 보낸 친구 요청
+	StatusAccepted Status = "accepted" // 친구 (양쪽 방향 두 행)
+	StatusBlocked  Status = "blocked"  // UserID가 FriendID를 차단
+)
+
+// Friendship UserID → FriendID 방향의 관계 한 건.
+// 친구는 양쪽 방향 accepted 두 행, 요청과 차단은 건 쪽에서 한 행으로 저장한다.
+type Friendship struct {
+	UserID    string `gorm:"type:uuid;primaryKey"`
+	FriendID  string `gorm:"type:uuid;primaryKey;index"`
+	Status    Status `gorm:"type:varchar(20);not null;index"`
+	CreatedAt time.Time
+	UpdatedAt time.Time // 마지막 상태 변경 시각 (accepted면 친구가 된 시각)
+}
@@ -0,0 +1,197 @@
+package friend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Ryeom/board-game/infra/db"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Migrate friendships 테이블 생성/갱신
+func Migrate(conn *gorm.DB) error {
+	return conn.AutoMigrate(&Friendship{})
+}
+
+// validTarget 상대가 자기 자신이 아니고 활성 회원인지 확인한다.
+func validTarget(me, target string) error {
+	if me == target {
+		return errors.New(resp.ErrorCodeFriendInvalidTarget)
+	}
+	if _, err := uuid.Parse(target); err != nil {
+		return errors.New(resp.ErrorCodeFriendInvalidTarget)
+	}
+	u, err := user.FindUserByID(target)
+	if err != nil {
+		return fmt.Errorf("failed to look up user %s: %w", target, err)
+	}
+	if u == nil || !u.IsActive {
+		return errors.New(resp.ErrorCodeUserNotFound)
+	}
+	return nil
+}
+
+// relation from → to 방향의 관계. 없으면 nil.
+func relation(tx *gorm.DB, from, to string) (*Friendship, error) {
+	var f Friendship
+	err := tx.Where("user_id = ? AND friend_id = ?", from, to).Take(&f).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &f, nil
+}
+
+// save from → to 관계를 status로 저장한다 (이미 있으면 상태만 바꾼다).
+func save(tx *gorm.DB, from, to string, status Status) error {
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "friend_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "updated_at"}),
+	}).Create(&Friendship{UserID: from, FriendID: to, Status: status}).Error
+}
+
+// Request from이 to에게 친구 요청. to가 이미 from에게 요청해 둔 상태면 바로 친구가 되고 StatusAccepted를 반환한다.
+func Request(ctx context.Context, from, to string) (Status, error) {
+	if err := validTarget(from, to); err != nil {
+		return "", err
+	}
+	var result Status
+	err := db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		out, err := relation(tx, from, to)
+		if err != nil {
+			return err
+		}
+		in, err := relation(tx, to, from)
+		if err != nil {
+			return err
+		}
+		switch {
+		case out != nil && out.Status == StatusBlocked, in != nil && in.Status == StatusBlocked:
+			return errors.New(resp.ErrorCodeFriendBlocked)
+		case out != nil && out.Status == StatusAccepted:
+			return errors.New(resp.ErrorCodeFriendAlreadyFriends)
+		case out != nil:
+			return errors.New(resp.ErrorCodeFriendRequestExists)
+		case in != nil:
+			result = StatusAccepted
+			return accept(tx, to, from)
+		}
+		result = StatusPending
+		return save(tx, from, to, StatusPending)
+	})
+	return result, err
+}
+
+func accept(tx *gorm.DB, requester, addressee string) error {
+	if err := save(tx, requester, addressee, StatusAccepted); err != nil {
+		return err
+	}
+	return save(tx, addressee, requester, StatusAccepted)
+}
+
+// Accept me가 받은 requester의 요청을 수락한다.
+func Accept(ctx context.Context, me, requester string) error {
+	return db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		req, err := relation(tx, requester, me)
+		if err != nil {
+			return err
+		}
+		if req == nil || req.Status != StatusPending {
+			return errors.New(resp.ErrorCodeFriendRequestNotFound)
+		}
+		return accept(tx, requester, me)
+	})
+}
+
+// Decline 받은 요청 거절 또는 보낸 요청 취소
+func Decline(ctx context.Context, me, other string) error {
+	result := db.DB.WithContext(ctx).
+		Where("((user_id = ? AND friend_id = ?) OR (user_id = ? AND friend_id = ?)) AND status = ?", other, me, me, other, StatusPending).
+		Delete(&Friendship{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(resp.ErrorCodeFriendRequestNotFound)
+	}
+	return nil
+}
+
+// Remove 친구 삭제 (양쪽 행 모두)
+func Remove(ctx context.Context, me, other string) error {
+	result := db.DB.WithContext(ctx).
+		Where("((user_id = ? AND friend_id = ?) OR (user_id = ? AND friend_id = ?)) AND status = ?", me, other, other, me, StatusAccepted).
+		Delete(&Friendship{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(resp.ErrorCodeFriendNotFound)
+	}
+	return nil
+}
+
+// Block me가 target을 차단한다. 둘 사이의 친구 관계와 요청은 지우고, target이 me를 차단한 기록은 그대로 둔다.
+func Block(ctx context.Context, me, target string) error {
+	if err := validTarget(me, target); err != nil {
+		return err
+	}
+	return db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("((user_id = ? AND friend_id = ?) OR (user_id = ? AND friend_id = ?)) AND status <> ?", me, target, target, me, StatusBlocked).
+			Delete(&Friendship{}).Error
+		if err != nil {
+			return err
+		}
+		return save(tx, me, target, StatusBlocked)
+	})
+}
+
+// Unblock 차단 해제. 친구 관계는 복구하지 않는다.
+func Unblock(ctx context.Context, me, target string) error {
+	result := db.DB.WithContext(ctx).
+		Where("user_id = ? AND friend_id = ? AND status = ?", me, target, StatusBlocked).
+		Delete(&Friendship{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(resp.ErrorCodeFriendNotBlocked)
+	}
+	return nil
+}
+
+// Outgoing me가 건 관계 목록 (친구, 보낸 요청, 차단). 최근 변경 순.
+func Outgoing(ctx context.Context, me string, status Status) ([]Friendship, error) {
+	var list []Friendship
+	err := db.DB.WithContext(ctx).
+		Where("user_id = ? AND status = ?", me, status).
+		Order("updated_at DESC").
+		Find(&list).Error
+	return list, err
+}
+
+// Incoming me가 받은 친구 요청 목록. 최근 요청 순.
+func Incoming(ctx context.Context, me string) ([]Friendship, error) {
+	var list []Friendship
+	err := db.DB.WithContext(ctx).
+		Where("friend_id = ? AND status = ?", me, StatusPending).
+		Order("updated_at DESC").
+		Find(&list).Error
+	return list, err
+}
+
+// AreFriends a와 b가 친구인지 (accepted 행은 항상 양쪽에 있으므로 한쪽만 확인)
+func AreFriends(ctx context.Context, a, b string) (bool, error) {
+	var count int64
+	err := db.DB.WithContext(ctx).Model(&Friendship{}).
+		Where("user_id = ? AND friend_id = ? AND status = ?", a, b, StatusAccepted).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,183 @@
+package match
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	redisutil "github.com/Ryeom/board-game/infra/redis"
+	"github.com/Ryeom/board-game/internal/game"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/log"
+	"github.com/redis/go-redis/v9"
+)
+
+// 매칭 대기열 (queue DB)
+//
+//	match:queue:<gameMode>  sorted set, score = 등록 시각(ms), member = 유저 ID → 먼저 온 순서
+//	match:ticket:<userID>   티켓 JSON (TTL). 한 유저는 한 대기열에만 등록된다.
+//
+// 등록/취소/매칭 확정은 Lua 스크립트로 처리하므로 여러 서버의 매처가 같은 티켓을 두 번 가져가지 않는다.
+const (
+	queueKeyPrefix  = "match:queue:"
+	ticketKeyPrefix = "match:ticket:"
+
+	// maxPendingPerMode 한 번의 매칭에서 모드별로 읽는 최대 티켓 수
+	maxPendingPerMode = 500
+)
+
+// QueueModes 빠른 대전을 지원하는 게임 모드 (게임 시작이 구현된 모드만)
+var QueueModes = []game.Mode{game.ModeHanabi}
+
+func queueKey(mode game.Mode) string {
+	return queueKeyPrefix + string(mode)
+}
+
+func ticketKey(userID string) string {
+	return ticketKeyPrefix + userID
+}
+
+// KEYS: 티켓, 대기열 / ARGV: 유저 ID, 티켓 JSON, TTL(ms), score
+var enqueueScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+  return 0
+end
+redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+redis.call('ZADD', KEYS[2], ARGV[4], ARGV[1])
+return 1
+`)
+
+// KEYS: 티켓 / ARGV: 유저 ID, 대기열 prefix
+var cancelScript = redis.NewScript(`
+local raw = redis.call('GET', KEYS[1])
+if not raw then
+  return 0
+end
+local ok, t = pcall(cjson.decode, raw)
+if ok and type(t['gameMode']) == 'string' then
+  redis.call('ZREM', ARGV[2] .. t['gameMode'], ARGV[1])
+end
+redis.call('DEL', KEYS[1])
+return 1
+`)
+
+// KEYS: 대기열, 티켓들 / ARGV: 유저 ID들 (KEYS[2..]와 같은 순서)
+// 모든 티켓이 아직 대기 중일 때만 한꺼번에 꺼낸다.
+var claimScript = redis.NewScript(`
+for i = 1, #ARGV do
+  if not redis.call('ZSCORE', KEYS[1], ARGV[i]) or redis.call('EXISTS', KEYS[i + 1]) == 0 then
+    return 0
+  end
+end
+for i = 1, #ARGV do
+  redis.call('ZREM', KEYS[1], ARGV[i])
+  redis.call('DEL', KEYS[i + 1])
+end
+return 1
+`)
+
+func queueClient() (*redis.Client, error) {
+	rdb := redisutil.Client[redisutil.RedisTargetQueue]
+	if rdb == nil {
+		return nil, errors.New(resp.ErrorCodeMatchQueueFailed)
+	}
+	return rdb, nil
+}
+
+// Enqueue 티켓 등록. 이미 대기 중이면 ErrorCodeMatchAlreadyQueued.
+func Enqueue(ctx context.Context, t *Ticket, ttl time.Duration) error {
+	rdb, err := queueClient()
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	added, err := enqueueScript.Run(ctx, rdb,
+		[]string{ticketKey(t.UserID), queueKey(t.GameMode)},
+		t.UserID, payload, ttl.Milliseconds(), t.EnqueuedAt.UnixMilli(),
+	).Int()
+	if err != nil {
+		return err
+	}
+	if added == 0 {
+		return errors.New(resp.ErrorCodeMatchAlreadyQueued)
+	}
+	return nil
+}
+
+// Cancel 대기 중인 티켓 제거. 대기 중이 아니었으면(이미 매칭되었거나 만료) false.
+func Cancel(ctx context.Context, userID string) (bool, error) {
+	rdb, err := queueClient()
+	if err != nil {
+		return false, err
+	}
+	removed, err := cancelScript.Run(ctx, rdb, []string{ticketKey(userID)}, userID, queueKeyPrefix).Int()
+	if err != nil {
+		return false, err
+	}
+	return removed == 1, nil
+}
+
+// Pending 모드의 대기 티켓을 등록 순서대로 조회. TTL이 지나 티켓이 사라진 항목은 대기열에서 정리한다.
+func Pending(ctx context.Context, mode game.Mode) ([]*Ticket, error) {
+	rdb, err := queueClient()
+	if err != nil {
+		return nil, err
+	}
+	ids, err := rdb.ZRange(ctx, queueKey(mode), 0, maxPendingPerMode-1).Result()
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = ticketKey(id)
+	}
+	vals, err := rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	tickets := make([]*Ticket, 0, len(ids))
+	var stale []any
+	for i, v := range vals {
+		raw, ok := v.(string)
+		if !ok {
+			stale = append(stale, ids[i])
+			continue
+		}
+		var t Ticket
+		if err := json.Unmarshal([]byte(raw), &t); err != nil {
+			log.Logger.Warningf("Pending - Failed to decode ticket %s: %v", ids[i], err)
+			continue
+		}
+		tickets = append(tickets, &t)
+	}
+	if len(stale) > 0 {
+		if err := rdb.ZRem(ctx, queueKey(mode), stale...).Err(); err != nil {
+			log.Logger.Warningf("Pending - Failed to remove expired tickets from %s: %v", mode, err)
+		}
+	}
+	return tickets, nil
+}
+
+// Claim 그룹의 티켓을 대기열에서 꺼낸다. 그 사이 한 명이라도 취소했거나 다른 매처가 가져갔으면 false.
+func Claim(ctx context.Context, g *Group) (bool, error) {
+	rdb, err := queueClient()
+	if err != nil {
+		return false, err
+	}
+	keys := []string{queueKey(g.GameMode)}
+	args := make([]any, len(g.Tickets))
+	for i, t := range g.Tickets {
+		keys = append(keys, ticketKey(t.UserID))
+		args[i] = t.UserID
+	}
+	claimed, err := claimScript.Run(ctx, rdb, keys, args...).Int()
+	if err != nil {
+		return false, err
+	}
+	return claimed == 1, nil
+}
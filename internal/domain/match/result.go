@@ -0,0 +1,75 @@
+package match
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/Ryeom/board-game/infra/mongo"
+	"github.com/Ryeom/board-game/internal/game"
+	"github.com/Ryeom/board-game/internal/user"
+)
+
+// Participant 전적에 남는 참가자
+type Participant struct {
+	UserID string `json:"userId" bson:"userId"`
+	Name   string `json:"name" bson:"name"`
+}
+
+// Result 끝난 게임 한 판의 전적 (MongoDB match_results)
+type Result struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	RoomID    string             `json:"roomId" bson:"roomId"`
+	GameMode  game.Mode          `json:"gameMode" bson:"gameMode"`
+	Players   []Participant      `json:"players" bson:"players"` // 턴 순서
+	Score     int                `json:"score" bson:"score"`
+	EndReason string             `json:"endReason" bson:"endReason"` // 하나비: perfect, miss_depleted, deck_exhausted
+	EndedAt   time.Time          `json:"endedAt" bson:"endedAt"`
+}
+
+// SaveResult 전적을 저장하고 ID를 채운다.
+func SaveResult(ctx context.Context, r *Result) error {
+	r.ID = primitive.NilObjectID
+	result, err := mongo.GetCollection(mongo.MatchCollection).InsertOne(ctx, r)
+	if err != nil {
+		return fmt.Errorf("failed to save match result: %w", err)
+	}
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		r.ID = id
+	}
+	return nil
+}
+
+// ResultsByUser 유저가 참가한 전적을 최신순으로 반환한다.
+func ResultsByUser(ctx context.Context, userID string) ([]*Result, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "endedAt", Value: -1}, {Key: "_id", Value: -1}})
+	cursor, err := mongo.GetCollection(mongo.MatchCollection).Find(ctx, bson.M{"players.userId": userID}, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve match results: %w", err)
+	}
+	results := []*Result{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode match results: %w", err)
+	}
+	return results, nil
+}
+
+// AnonymizeResults 탈퇴한 유저를 전적에서 익명 참가자로 바꾼다. 다른 참가자의 전적은 그대로 남는다.
+func AnonymizeResults(ctx context.Context, userID string) error {
+	_, err := mongo.GetCollection(mongo.MatchCollection).UpdateMany(ctx,
+		bson.M{"players.userId": userID},
+		bson.M{"$set": bson.M{
+			"players.$[p].userId": user.DeletedUserID,
+			"players.$[p].name":   user.DeletedUserName,
+		}},
+		options.Update().SetArrayFilters(options.ArrayFilters{Filters: []any{bson.M{"p.userId": userID}}}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to anonymize match results: %w", err)
+	}
+	return nil
+}
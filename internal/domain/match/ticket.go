@@ -0,0 +1,100 @@
+package match
+
+import (
+	"time"
+
+	"github.com/Ryeom/board-game/internal/game"
+)
+
+// Ticket 빠른 대전 대기열에 등록된 한 명의 요청
+type Ticket struct {
+	UserID      string    `json:"userId"`
+	UserName    string    `json:"userName"`
+	GameMode    game.Mode `json:"gameMode"`
+	PlayerCount int       `json:"playerCount"`          // 원하는 게임 인원 (본인 포함)
+	Ranked      bool      `json:"ranked,omitempty"`     // 레이팅 매칭. 레이팅 매칭 티켓끼리만 묶인다.
+	Rating      int       `json:"rating,omitempty"`     // 레이팅 매칭이면 계정의 레이팅 (서버가 채움)
+	RatingBand  int       `json:"ratingBand,omitempty"` // 허용하는 레이팅 차이, 0이면 제한 없음
+	EnqueuedAt  time.Time `json:"enqueuedAt"`
+}
+
+// Group 한 방으로 묶인 티켓들. Bots만큼 AI 플레이어로 빈 자리를 채운다.
+type Group struct {
+	GameMode    game.Mode
+	PlayerCount int
+	Ranked      bool
+	Tickets     []*Ticket
+	Bots        int
+}
+
+// compatible 두 티켓이 같은 방에 들어갈 수 있는지. 레이팅 매칭과 일반 매칭은 섞지 않고,
+// 레이팅 매칭이면 레이팅 차이가 양쪽이 허용하는 범위 안이어야 한다.
+func (t *Ticket) compatible(o *Ticket) bool {
+	if t.GameMode != o.GameMode || t.PlayerCount != o.PlayerCount || t.Ranked != o.Ranked {
+		return false
+	}
+	if !t.Ranked {
+		return true
+	}
+	diff := t.Rating - o.Rating
+	if diff < 0 {
+		diff = -diff
+	}
+	if t.RatingBand > 0 && diff > t.RatingBand {
+		return false
+	}
+	if o.RatingBand > 0 && diff > o.RatingBand {
+		return false
+	}
+	return true
+}
+
+// FormGroups 먼저 들어온 순서(tickets 순서)대로 기준 티켓을 잡고, 조건이 맞는 뒤 티켓으로 인원을 채운다.
+// 인원을 채우지 못해도 기준 티켓이 backfillAfter 이상 기다렸으면 모인 만큼으로 묶고 나머지는 봇으로 채운다. (backfillAfter <= 0이면 봇 없음)
+func FormGroups(tickets []*Ticket, now time.Time, backfillAfter time.Duration) []Group {
+	used := make([]bool, len(tickets))
+	var groups []Group
+	for i, anchor := range tickets {
+		if used[i] {
+			continue
+		}
+		members := []int{i}
+		for j := i + 1; j < len(tickets) && len(members) < anchor.PlayerCount; j++ {
+			if used[j] || !fitsAll(tickets, members, tickets[j]) {
+				continue
+			}
+			members = append(members, j)
+		}
+
+		bots := anchor.PlayerCount - len(members)
+		if bots > 0 && (backfillAfter <= 0 || now.Sub(anchor.EnqueuedAt) < backfillAfter) {
+			continue
+		}
+
+		g := Group{GameMode: anchor.GameMode, PlayerCount: anchor.PlayerCount, Ranked: anchor.Ranked, Bots: bots}
+		for _, idx := range members {
+			used[idx] = true
+			g.Tickets = append(g.Tickets, tickets[idx])
+		}
+		groups = append(groups, g)
+	}
+	return groups
+}
+
+func fitsAll(tickets []*Ticket, members []int, candidate *Ticket) bool {
+	for _, idx := range members {
+		if !tickets[idx].compatible(candidate) {
+			return false
+		}
+	}
+	return true
+}
+
+// UserIDs 그룹에 속한 사람 플레이어 ID (대기열 순서)
+func (g *Group) UserIDs() []string {
+	ids := make([]string, len(g.Tickets))
+	for i, t := range g.Tickets {
+		ids[i] = t.UserID
+	}
+	return ids
+}
@@ -0,0 +1,95 @@
+package match
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Ryeom/board-game/internal/game"
+	"github.com/stretchr/testify/assert"
+)
+
+func ticket(id string, players int, waited time.Duration, now time.Time) *Ticket {
+	return &Ticket{UserID: id, GameMode: game.ModeHanabi, PlayerCount: players, EnqueuedAt: now.Add(-waited)}
+}
+
+func TestFormGroups_FillsByQueueOrder(t *testing.T) {
+	now := time.Now()
+	tickets := []*Ticket{
+		ticket("a", 3, 0, now),
+		ticket("b", 2, 0, now),
+		ticket("c", 3, 0, now),
+		ticket("d", 2, 0, now),
+		ticket("e", 3, 0, now),
+		ticket("f", 3, 0, now),
+	}
+
+	groups := FormGroups(tickets, now, 0)
+	if assert.Len(t, groups, 2) {
+		assert.Equal(t, []string{"a", "c", "e"}, groups[0].UserIDs())
+		assert.Equal(t, []string{"b", "d"}, groups[1].UserIDs())
+		assert.Zero(t, groups[0].Bots)
+	}
+}
+
+func ranked(id string, rating, band int, now time.Time) *Ticket {
+	t := ticket(id, 2, 0, now)
+	t.Ranked, t.Rating, t.RatingBand = true, rating, band
+	return t
+}
+
+func TestFormGroups_RatingBand(t *testing.T) {
+	now := time.Now()
+	a := ranked("a", 1500, 100, now)
+	far := ranked("far", 1800, 0, now)
+	near := ranked("near", 1450, 30, now) // a는 허용하지만 near의 범위(30)를 넘음
+	inBand := ranked("inBand", 1580, 0, now)
+
+	groups := FormGroups([]*Ticket{a, far, near, inBand}, now, 0)
+	if assert.Len(t, groups, 1, "far와 near는 차이가 범위를 넘어 대기") {
+		assert.Equal(t, []string{"a", "inBand"}, groups[0].UserIDs())
+		assert.True(t, groups[0].Ranked)
+	}
+}
+
+func TestFormGroups_RankedNeverMixesWithUnranked(t *testing.T) {
+	now := time.Now()
+	rankedTicket := ranked("ranked", 1500, 0, now)
+	unranked := ticket("unranked", 2, time.Minute, now)
+	unranked.Rating = 1500 // 일반 매칭 티켓의 레이팅은 무시
+
+	assert.Empty(t, FormGroups([]*Ticket{rankedTicket, unranked}, now, 0), "레이팅 매칭과 일반 매칭은 같은 방이 되지 않음")
+
+	groups := FormGroups([]*Ticket{unranked, rankedTicket}, now, 30*time.Second)
+	if assert.Len(t, groups, 1, "오래 기다린 일반 매칭 티켓도 봇으로만 채움") {
+		assert.Equal(t, []string{"unranked"}, groups[0].UserIDs())
+		assert.False(t, groups[0].Ranked)
+		assert.Equal(t, 1, groups[0].Bots)
+	}
+}
+
+func TestFormGroups_BotBackfillAfterTimeout(t *testing.T) {
+	now := time.Now()
+	tickets := []*Ticket{
+		ticket("old", 4, time.Minute, now),
+		ticket("new", 3, time.Second, now),
+	}
+
+	assert.Empty(t, FormGroups(tickets, now, 0), "봇 채우기가 꺼져 있으면 인원이 찰 때까지 대기")
+
+	groups := FormGroups(tickets, now, 30*time.Second)
+	if assert.Len(t, groups, 1) {
+		assert.Equal(t, []string{"old"}, groups[0].UserIDs())
+		assert.Equal(t, 3, groups[0].Bots)
+	}
+}
+
+func TestFormGroups_LargeQueue(t *testing.T) {
+	now := time.Now()
+	tickets := make([]*Ticket, 10)
+	for i := range tickets {
+		tickets[i] = ticket(fmt.Sprintf("u%d", i), 4, 0, now)
+	}
+	groups := FormGroups(tickets, now, 0)
+	assert.Len(t, groups, 2, "남은 2명은 다음 주기까지 대기")
+}
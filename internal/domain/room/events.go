@@ -0,0 +1,123 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	redisutil "github.com/Ryeom/board-game/infra/redis"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/redis/go-redis/v9"
+)
+
+// MaxBufferedEvents 재접속 시 재전송을 위해 방마다 보관하는 최대 이벤트 수
+const MaxBufferedEvents = 200
+
+// EventBufferTTL 시퀀스 카운터와 이벤트 버퍼의 만료 시간. 쓸 때마다 갱신되므로 활동이 멈춘 방의 키만 만료된다.
+// 방 정리(deleteEventBuffer)가 누락되어도 키가 남지 않게 하며, 만료 후 재접속은 전체 동기화로 처리된다.
+const EventBufferTTL = 24 * time.Hour
+
+func eventSeqKey(roomID string) string {
+	return "room_seq:" + roomID
+}
+
+func eventBufferKey(roomID string) string {
+	return "room_events:" + roomID
+}
+
+// NextEventSeq 방 이벤트 스트림의 다음 시퀀스 번호를 발급한다. (인스턴스 간 단조 증가 보장)
+func NextEventSeq(ctx context.Context, roomID string) (int64, error) {
+	rdb := redisutil.Client[redisutil.RedisTargetRoom]
+	if rdb == nil {
+		return 0, errors.New(resp.ErrorCodeRoomUpdateFailed)
+	}
+	key := eventSeqKey(roomID)
+	var incr *redis.IntCmd
+	_, err := rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, EventBufferTTL)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+// LastEventSeq 방에서 마지막으로 발급된 시퀀스 번호를 반환한다.
+func LastEventSeq(ctx context.Context, roomID string) (int64, error) {
+	val, err := redisutil.GetString(redisutil.RedisTargetRoom, eventSeqKey(roomID))
+	if err != nil {
+		return 0, err
+	}
+	if val == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(val, 10, 64)
+}
+
+// BufferEvent 시퀀스가 부여된 이벤트를 방 버퍼에 저장하고 MaxBufferedEvents를 넘는 오래된 이벤트를 제거한다.
+func BufferEvent(ctx context.Context, roomID string, seq int64, payload []byte) error {
+	rdb := redisutil.Client[redisutil.RedisTargetRoom]
+	if rdb == nil {
+		return errors.New(resp.ErrorCodeRoomUpdateFailed)
+	}
+	key := eventBufferKey(roomID)
+	_, err := rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, key, redis.Z{Score: float64(seq), Member: string(payload)})
+		pipe.ZRemRangeByRank(ctx, key, 0, -(MaxBufferedEvents + 1))
+		pipe.Expire(ctx, key, EventBufferTTL)
+		pipe.Expire(ctx, eventSeqKey(roomID), EventBufferTTL)
+		return nil
+	})
+	return err
+}
+
+// EventsSince afterSeq 이후에 발생한 이벤트를 시퀀스 순서대로 반환한다.
+// 버퍼가 afterSeq 바로 다음부터 이어지지 않으면(너무 오래 끊겼거나 시퀀스가 초기화된 경우) complete는 false이다.
+func EventsSince(ctx context.Context, roomID string, afterSeq int64) (events []json.RawMessage, lastSeq int64, complete bool, err error) {
+	lastSeq, err = LastEventSeq(ctx, roomID)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	if afterSeq == lastSeq {
+		return nil, lastSeq, true, nil
+	}
+	if afterSeq > lastSeq {
+		return nil, lastSeq, false, nil
+	}
+
+	rdb := redisutil.Client[redisutil.RedisTargetRoom]
+	if rdb == nil {
+		return nil, lastSeq, false, errors.New(resp.ErrorCodeRoomNotFound)
+	}
+	buffered, err := rdb.ZRangeByScoreWithScores(ctx, eventBufferKey(roomID), &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(afterSeq, 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, lastSeq, false, err
+	}
+	if len(buffered) == 0 || int64(buffered[0].Score) != afterSeq+1 {
+		return nil, lastSeq, false, nil
+	}
+
+	events = make([]json.RawMessage, 0, len(buffered))
+	for _, z := range buffered {
+		if member, ok := z.Member.(string); ok {
+			events = append(events, json.RawMessage(member))
+		}
+	}
+	return events, lastSeq, true, nil
+}
+
+// deleteEventBuffer 방 삭제 시 시퀀스 카운터와 이벤트 버퍼를 함께 정리한다.
+func deleteEventBuffer(ctx context.Context, roomID string) error {
+	rdb := redisutil.Client[redisutil.RedisTargetRoom]
+	if rdb == nil {
+		return errors.New(resp.ErrorCodeRoomDeleteFailed)
+	}
+	return rdb.Del(ctx, eventSeqKey(roomID), eventBufferKey(roomID)).Err()
+}
@@ -0,0 +1,210 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	redisutil "github.com/Ryeom/board-game/infra/redis"
+	"github.com/Ryeom/board-game/internal/game"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/log"
+	"github.com/redis/go-redis/v9"
+)
+
+// 방 목록 인덱스 (room DB의 sorted set, member는 방 ID)
+//
+//	rooms:created          score = 생성 시각(ms)               → 최근 생성순
+//	rooms:players          score = 인원 수 * 1e13 + 생성 시각(ms) → 인원 많은순, 같으면 최근 생성순
+//	rooms:mode:<gameMode>  score = 생성 시각(ms)
+//	rooms:status:<status>  score = 생성 시각(ms)
+//
+// 방 JSON과 인덱스는 Lua 스크립트로 한 번에 갱신되므로 목록 조회 중 어긋난 상태를 보지 않는다.
+const (
+	indexCreatedKey    = "rooms:created"
+	indexPlayersKey    = "rooms:players"
+	indexModePrefix    = "rooms:mode:"
+	indexStatusPrefix  = "rooms:status:"
+	statusWaiting      = "waiting"
+	statusPlaying      = "playing"
+	playersScoreWeight = 1e13 // 생성 시각(ms)보다 충분히 커서 인원 수가 우선 정렬되도록 함 (float64 정수 정밀도 안)
+)
+
+func roomKey(roomID string) string {
+	return "room:" + roomID
+}
+
+func modeIndexKey(mode game.Mode) string {
+	return indexModePrefix + string(mode)
+}
+
+func statusIndexKey(status string) string {
+	return indexStatusPrefix + status
+}
+
+func (r *Room) status() string {
+	if r.IsGameStarted {
+		return statusPlaying
+	}
+	return statusWaiting
+}
+
+func createdScore(r *Room) float64 {
+	return float64(r.CreatedAt.UnixMilli())
+}
+
+func playersScore(r *Room) float64 {
+	return float64(len(r.Players))*playersScoreWeight + createdScore(r)
+}
+
+// saveScript 이전 게임 모드 인덱스에서 빼고 방 JSON과 모든 인덱스를 원자적으로 갱신
+// KEYS: 방, created, players, 현재 mode, 현재 status, 다른 status
+// ARGV: 방 ID, JSON, created score, players score, mode 인덱스 prefix
+var saveScript = redis.NewScript(`
+local old = redis.call('GET', KEYS[1])
+if old then
+  local ok, prev = pcall(cjson.decode, old)
+  if ok and type(prev['gameMode']) == 'string' then
+    redis.call('ZREM', ARGV[5] .. prev['gameMode'], ARGV[1])
+  end
+end
+redis.call('SET', KEYS[1], ARGV[2])
+redis.call('ZADD', KEYS[2], ARGV[3], ARGV[1])
+redis.call('ZADD', KEYS[3], ARGV[4], ARGV[1])
+redis.call('ZADD', KEYS[4], ARGV[3], ARGV[1])
+redis.call('ZREM', KEYS[6], ARGV[1])
+redis.call('ZADD', KEYS[5], ARGV[3], ARGV[1])
+return 1
+`)
+
+// deleteScript 방 JSON과 모든 인덱스 항목을 원자적으로 제거
+// KEYS: 방, created, players, waiting, playing
+// ARGV: 방 ID, mode 인덱스 prefix
+var deleteScript = redis.NewScript(`
+local old = redis.call('GET', KEYS[1])
+if old then
+  local ok, prev = pcall(cjson.decode, old)
+  if ok and type(prev['gameMode']) == 'string' then
+    redis.call('ZREM', ARGV[2] .. prev['gameMode'], ARGV[1])
+  end
+end
+redis.call('DEL', KEYS[1])
+for i = 2, #KEYS do
+  redis.call('ZREM', KEYS[i], ARGV[1])
+end
+return 1
+`)
+
+func saveScriptArgs(r *Room, payload []byte) ([]string, []any) {
+	other := statusWaiting
+	if r.status() == statusWaiting {
+		other = statusPlaying
+	}
+	keys := []string{
+		roomKey(r.ID),
+		indexCreatedKey,
+		indexPlayersKey,
+		modeIndexKey(r.GameMode),
+		statusIndexKey(r.status()),
+		statusIndexKey(other),
+	}
+	return keys, []any{r.ID, payload, createdScore(r), playersScore(r), indexModePrefix}
+}
+
+func deleteScriptArgs(roomID string) ([]string, []any) {
+	keys := []string{
+		roomKey(roomID),
+		indexCreatedKey,
+		indexPlayersKey,
+		statusIndexKey(statusWaiting),
+		statusIndexKey(statusPlaying),
+	}
+	return keys, []any{roomID, indexModePrefix}
+}
+
+func saveIndexed(ctx context.Context, rdb *redis.Client, r *Room, payload []byte) error {
+	keys, args := saveScriptArgs(r, payload)
+	return saveScript.Run(ctx, rdb, keys, args...).Err()
+}
+
+func deleteIndexed(ctx context.Context, rdb *redis.Client, roomID string) error {
+	keys, args := deleteScriptArgs(roomID)
+	return deleteScript.Run(ctx, rdb, keys, args...).Err()
+}
+
+// loadRooms 방 ID 목록을 MGET 한 번으로 불러온다. 인덱스에만 남은 방은 nil로 반환한다.
+func loadRooms(ctx context.Context, rdb *redis.Client, ids []string) ([]*Room, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = roomKey(id)
+	}
+	vals, err := rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	rooms := make([]*Room, len(vals))
+	for i, v := range vals {
+		raw, ok := v.(string)
+		if !ok {
+			continue
+		}
+		r, err := decodeRoom([]byte(raw))
+		if err != nil {
+			log.Logger.Warningf("loadRooms - Failed to decode room %s: %v", ids[i], err)
+			continue
+		}
+		rooms[i] = r
+	}
+	return rooms, nil
+}
+
+// rankAfter 정렬 순서(score 내림차순, 같으면 ID 내림차순)에서 커서 바로 다음 항목의 순위
+func rankAfter(ctx context.Context, rdb *redis.Client, key string, c *listCursor) (int64, error) {
+	score := strconv.FormatFloat(c.Score, 'f', -1, 64)
+	rank, err := rdb.ZCount(ctx, key, "("+score, "+inf").Result()
+	if err != nil {
+		return 0, err
+	}
+	ties, err := rdb.ZRangeByScore(ctx, key, &redis.ZRangeBy{Min: score, Max: score}).Result()
+	if err != nil {
+		return 0, err
+	}
+	for _, id := range ties {
+		if id >= c.ID {
+			rank++
+		}
+	}
+	return rank, nil
+}
+
+// RebuildIndexes 인덱스가 없는 기존 데이터를 위해 방 키를 SCAN하여 인덱스를 다시 만든다. (인덱스가 이미 있으면 아무것도 하지 않음)
+func RebuildIndexes(ctx context.Context) (int, error) {
+	rdb := redisutil.Client[redisutil.RedisTargetRoom]
+	if rdb == nil {
+		return 0, errors.New(resp.ErrorCodeRoomNotFound)
+	}
+	exists, err := rdb.Exists(ctx, indexCreatedKey).Result()
+	if err != nil || exists > 0 {
+		return 0, err
+	}
+
+	rebuilt := 0
+	for _, key := range redisutil.ScanKeyList(redisutil.RedisTargetRoom, "room:*") {
+		raw, err := rdb.Get(ctx, key).Bytes()
+		if err != nil {
+			continue
+		}
+		r, err := decodeRoom(raw)
+		if err != nil || roomKey(r.ID) != key {
+			continue
+		}
+		if err := saveIndexed(ctx, rdb, r, raw); err != nil {
+			return rebuilt, err
+		}
+		rebuilt++
+	}
+	return rebuilt, nil
+}
@@ -0,0 +1,208 @@
+package room
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"time"
+
+	redisutil "github.com/Ryeom/board-game/infra/redis"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/log"
+	"github.com/redis/go-redis/v9"
+)
+
+// 초대 코드 (room DB)
+//
+//	invite:<code>          hash (roomId, createdBy, maxUses, uses, createdAt, expiresAt), 만료 시각에 맞춘 TTL
+//	room_invites:<roomID>  방에서 발급한 코드 set. 방이 삭제되면 모든 코드를 폐기한다.
+const (
+	InviteCodeLength = 8
+	// inviteAlphabet 헷갈리기 쉬운 0/O, 1/I를 뺀 32자
+	inviteAlphabet   = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	maxCodeAttempts  = 5
+	inviteKeyPrefix  = "invite:"
+	roomInvitePrefix = "room_invites:"
+)
+
+// Invite 방 초대 코드. MaxUses가 0이면 만료될 때까지 여러 번 사용할 수 있다.
+type Invite struct {
+	Code      string    `json:"code" redis:"-"`
+	RoomID    string    `json:"roomId" redis:"roomId"`
+	CreatedBy string    `json:"createdBy" redis:"createdBy"`
+	MaxUses   int       `json:"maxUses" redis:"maxUses"`
+	Uses      int       `json:"uses" redis:"uses"`
+	CreatedAt time.Time `json:"createdAt" redis:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt" redis:"expiresAt"`
+}
+
+func inviteKey(code string) string {
+	return inviteKeyPrefix + code
+}
+
+func roomInvitesKey(roomID string) string {
+	return roomInvitePrefix + roomID
+}
+
+// NormalizeInviteCode 사용자가 입력한 코드를 저장 형식(대문자, 공백/하이픈 제거)으로 변환
+func NormalizeInviteCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
+
+func generateInviteCode() (string, error) {
+	buf := make([]byte, InviteCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	for i, b := range buf {
+		buf[i] = inviteAlphabet[int(b)%len(inviteAlphabet)]
+	}
+	return string(buf), nil
+}
+
+// KEYS: 초대, 방 초대 set / ARGV: 코드, TTL(ms), hash 필드/값...
+var createInviteScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+  return 0
+end
+redis.call('HSET', KEYS[1], unpack(ARGV, 3))
+redis.call('PEXPIRE', KEYS[1], ARGV[2])
+redis.call('SADD', KEYS[2], ARGV[1])
+return 1
+`)
+
+// useInviteScript 사용 횟수를 올린다. 없으면 -1, 사용 횟수를 다 썼으면 -2, 성공 시 사용 횟수.
+var useInviteScript = redis.NewScript(`
+local max = redis.call('HGET', KEYS[1], 'maxUses')
+if not max then
+  return -1
+end
+max = tonumber(max)
+local uses = tonumber(redis.call('HGET', KEYS[1], 'uses') or '0')
+if max > 0 and uses >= max then
+  return -2
+end
+return redis.call('HINCRBY', KEYS[1], 'uses', 1)
+`)
+
+// releaseInviteScript 초대 코드로 입장하지 못했을 때 사용 횟수를 되돌린다.
+var releaseInviteScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 and tonumber(redis.call('HGET', KEYS[1], 'uses') or '0') > 0 then
+  redis.call('HINCRBY', KEYS[1], 'uses', -1)
+end
+return 1
+`)
+
+// CreateInvite 방의 초대 코드를 발급한다.
+func CreateInvite(ctx context.Context, roomID string, createdBy string, ttl time.Duration, maxUses int) (*Invite, error) {
+	rdb := redisutil.Client[redisutil.RedisTargetRoom]
+	if rdb == nil {
+		return nil, errors.New(resp.ErrorCodeInviteCreateFailed)
+	}
+	now := time.Now()
+	inv := &Invite{
+		RoomID:    roomID,
+		CreatedBy: createdBy,
+		MaxUses:   maxUses,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	fields := []any{
+		"roomId", inv.RoomID,
+		"createdBy", inv.CreatedBy,
+		"maxUses", inv.MaxUses,
+		"uses", 0,
+		"createdAt", inv.CreatedAt.Format(time.RFC3339Nano),
+		"expiresAt", inv.ExpiresAt.Format(time.RFC3339Nano),
+	}
+
+	for attempt := 0; attempt < maxCodeAttempts; attempt++ {
+		code, err := generateInviteCode()
+		if err != nil {
+			return nil, err
+		}
+		args := append([]any{code, ttl.Milliseconds()}, fields...)
+		created, err := createInviteScript.Run(ctx, rdb, []string{inviteKey(code), roomInvitesKey(roomID)}, args...).Int()
+		if err != nil {
+			return nil, err
+		}
+		if created == 1 {
+			inv.Code = code
+			return inv, nil
+		}
+	}
+	return nil, errors.New(resp.ErrorCodeInviteCreateFailed)
+}
+
+// GetInvite 초대 코드 조회. 없거나 만료/폐기되었으면 ErrorCodeInviteNotFound.
+func GetInvite(ctx context.Context, code string) (*Invite, error) {
+	rdb := redisutil.Client[redisutil.RedisTargetRoom]
+	if rdb == nil {
+		return nil, errors.New(resp.ErrorCodeInviteNotFound)
+	}
+	res := rdb.HGetAll(ctx, inviteKey(code))
+	vals, err := res.Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) == 0 {
+		return nil, errors.New(resp.ErrorCodeInviteNotFound)
+	}
+	var inv Invite
+	if err := res.Scan(&inv); err != nil {
+		return nil, err
+	}
+	inv.Code = code
+	return &inv, nil
+}
+
+// UseInvite 초대 코드 사용 횟수를 하나 차감하고 코드를 반환한다.
+// 입장에 실패하면 ReleaseInvite로 되돌려야 한다.
+func UseInvite(ctx context.Context, code string) (*Invite, error) {
+	rdb := redisutil.Client[redisutil.RedisTargetRoom]
+	if rdb == nil {
+		return nil, errors.New(resp.ErrorCodeInviteNotFound)
+	}
+	used, err := useInviteScript.Run(ctx, rdb, []string{inviteKey(code)}).Int()
+	if err != nil {
+		return nil, err
+	}
+	switch used {
+	case -1:
+		return nil, errors.New(resp.ErrorCodeInviteNotFound)
+	case -2:
+		return nil, errors.New(resp.ErrorCodeInviteExhausted)
+	}
+	return GetInvite(ctx, code)
+}
+
+// ReleaseInvite UseInvite로 차감한 사용 횟수를 되돌린다.
+func ReleaseInvite(ctx context.Context, code string) {
+	rdb := redisutil.Client[redisutil.RedisTargetRoom]
+	if rdb == nil {
+		return
+	}
+	if err := releaseInviteScript.Run(ctx, rdb, []string{inviteKey(code)}).Err(); err != nil {
+		log.Logger.Warningf("ReleaseInvite - Failed to release invite %s: %v", code, err)
+	}
+}
+
+// RevokeInvites 방에서 발급한 모든 초대 코드를 폐기한다.
+func RevokeInvites(ctx context.Context, roomID string) error {
+	rdb := redisutil.Client[redisutil.RedisTargetRoom]
+	if rdb == nil {
+		return errors.New(resp.ErrorCodeRoomDeleteFailed)
+	}
+	codes, err := rdb.SMembers(ctx, roomInvitesKey(roomID)).Result()
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(codes)+1)
+	for _, code := range codes {
+		keys = append(keys, inviteKey(code))
+	}
+	keys = append(keys, roomInvitesKey(roomID))
+	return rdb.Del(ctx, keys...).Err()
+}
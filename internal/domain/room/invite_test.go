@@ -0,0 +1,37 @@
+package room
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateInviteCode_Alphabet(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		code, err := generateInviteCode()
+		assert.NoError(t, err)
+		assert.Len(t, code, InviteCodeLength)
+		for _, c := range code {
+			assert.True(t, strings.ContainsRune(inviteAlphabet, c), "허용되지 않은 문자 %q", c)
+		}
+		seen[code] = true
+	}
+	assert.Greater(t, len(seen), 95, "코드가 거의 겹치지 않아야 함")
+}
+
+func TestNormalizeInviteCode(t *testing.T) {
+	assert.Equal(t, "ABCD2345", NormalizeInviteCode(" abcd-2345 "))
+}
+
+func TestJoinInvited_SkipsPassword(t *testing.T) {
+	r := &Room{Host: "a", Players: []string{"a"}, MaxPlayers: 3, Password: "hashed", HasPassword: true}
+
+	_, err := r.Join("b", "wrong")
+	assert.Error(t, err, "초대 없이 틀린 비밀번호로는 입장 불가")
+
+	joined, err := r.JoinInvited("b")
+	assert.NoError(t, err)
+	assert.True(t, joined)
+}
@@ -0,0 +1,50 @@
+package room
+
+import (
+	"errors"
+	"slices"
+
+	resp "github.com/Ryeom/board-game/internal/response"
+)
+
+// IsBanned 방장이 차단한 유저인지 확인
+func (r *Room) IsBanned(userID string) bool {
+	return slices.Contains(r.Banned, userID)
+}
+
+// Ban 유저를 차단 목록에 올리고 플레이어/관전자 목록에서 제거한다. 방에 있던 유저였으면 true.
+// 방장은 차단할 수 없다.
+func (r *Room) Ban(userID string) (bool, error) {
+	if userID == r.Host {
+		return false, errors.New(resp.ErrorCodeRoomInvalidRequest)
+	}
+	if !r.IsBanned(userID) {
+		r.Banned = append(r.Banned, userID)
+	}
+	if r.RemoveSpectator(userID) {
+		return true, nil
+	}
+	return r.Leave(userID), nil
+}
+
+// Unban 차단 해제. 차단된 유저가 아니면 ErrorCodeRoomUserNotBanned.
+func (r *Room) Unban(userID string) error {
+	idx := slices.Index(r.Banned, userID)
+	if idx < 0 {
+		return errors.New(resp.ErrorCodeRoomUserNotBanned)
+	}
+	r.Banned = slices.Delete(r.Banned, idx, idx+1)
+	return nil
+}
+
+// TransferHost 방장을 다른 플레이어에게 넘긴다.
+func (r *Room) TransferHost(userID string) error {
+	if userID == r.Host {
+		return errors.New(resp.ErrorCodeRoomInvalidRequest)
+	}
+	if !r.HasPlayer(userID) {
+		return errors.New(resp.ErrorCodeRoomUserNotInRoom)
+	}
+	r.Host = userID
+	return nil
+}
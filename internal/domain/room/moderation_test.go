@@ -0,0 +1,57 @@
+package room
+
+import (
+	"testing"
+
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBan_RemovesAndBlocksRejoin(t *testing.T) {
+	r := &Room{Host: "a", Players: []string{"a", "b"}, ReadyPlayers: map[string]bool{}, MaxPlayers: 4, MaxSpectators: 5}
+
+	_, err := r.Ban("a")
+	assert.EqualError(t, err, resp.ErrorCodeRoomInvalidRequest, "방장은 차단할 수 없어야 함")
+
+	removed, err := r.Ban("b")
+	assert.NoError(t, err)
+	assert.True(t, removed)
+	assert.Equal(t, []string{"a"}, r.Players)
+
+	_, err = r.Join("b", "")
+	assert.EqualError(t, err, resp.ErrorCodeRoomBanned)
+	_, err = r.Spectate("b", "")
+	assert.EqualError(t, err, resp.ErrorCodeRoomBanned)
+
+	removed, err = r.Ban("c")
+	assert.NoError(t, err)
+	assert.False(t, removed, "방에 없던 유저도 미리 차단할 수 있어야 함")
+
+	assert.NoError(t, r.Unban("b"))
+	assert.EqualError(t, r.Unban("b"), resp.ErrorCodeRoomUserNotBanned)
+	joined, err := r.Join("b", "")
+	assert.NoError(t, err)
+	assert.True(t, joined)
+}
+
+func TestLocked_BlocksNewJoinsButAllowsPromotion(t *testing.T) {
+	r := &Room{Host: "a", Players: []string{"a"}, ReadyPlayers: map[string]bool{}, MaxPlayers: 4, MaxSpectators: 5, Spectators: []string{"s"}, Locked: true}
+
+	_, err := r.Join("b", "")
+	assert.EqualError(t, err, resp.ErrorCodeRoomLocked)
+	_, err = r.JoinInvited("b")
+	assert.EqualError(t, err, resp.ErrorCodeRoomLocked, "초대 코드로도 잠긴 방에는 들어갈 수 없어야 함")
+	_, err = r.Spectate("b", "")
+	assert.EqualError(t, err, resp.ErrorCodeRoomLocked)
+
+	assert.NoError(t, r.Promote("s"), "이미 방에 있는 관전자는 자리로 옮길 수 있어야 함")
+}
+
+func TestTransferHost(t *testing.T) {
+	r := &Room{Host: "a", Players: []string{"a", "b"}}
+
+	assert.EqualError(t, r.TransferHost("a"), resp.ErrorCodeRoomInvalidRequest)
+	assert.EqualError(t, r.TransferHost("x"), resp.ErrorCodeRoomUserNotInRoom)
+	assert.NoError(t, r.TransferHost("b"))
+	assert.Equal(t, "b", r.Host)
+}
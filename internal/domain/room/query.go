@@ -0,0 +1,195 @@
+package room
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	redisutil "github.com/Ryeom/board-game/infra/redis"
+	"github.com/Ryeom/board-game/internal/game"
+	resp "github.com/Ryeom/board-game/internal/response"
+)
+
+const (
+	SortNewest      = "newest"       // 최근 생성순 (기본값)
+	SortMostPlayers = "most_players" // 참여 인원 많은순, 같으면 최근 생성순
+
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+// ListQuery 방 목록 조회 조건. 값이 비어 있는 조건은 적용하지 않는다.
+type ListQuery struct {
+	GameMode    game.Mode `json:"gameMode,omitempty"`
+	HasPassword *bool     `json:"hasPassword,omitempty"` // true: 비밀번호 방만, false: 공개 방만
+	Joinable    bool      `json:"joinable,omitempty"`    // 시작 전이고 자리가 남은 방만
+	NotStarted  bool      `json:"notStarted,omitempty"`  // 게임이 시작되지 않은 방만
+	Search      string    `json:"search,omitempty"`      // 방 이름 부분 일치 (대소문자 무시)
+	Sort        string    `json:"sort,omitempty"`        // newest, most_players
+	Cursor      string    `json:"cursor,omitempty"`      // 이전 페이지 응답의 nextCursor
+	Limit       int       `json:"limit,omitempty"`
+}
+
+// listCursor 마지막으로 내려준 방의 인덱스 위치(score, ID). 커서 이후 항목만 반환하므로 그 사이에 방이 생기거나 사라져도 중복/누락이 없다.
+type listCursor struct {
+	Sort  string  `json:"s"`
+	Score float64 `json:"c"`
+	ID    string  `json:"i"`
+}
+
+// maxScanPerPage 한 번의 조회에서 필터 확인을 위해 읽는 최대 인덱스 항목 수.
+// 조건에 맞는 방이 드물어도 지연 시간이 방 개수에 비례해 늘지 않도록, 넘으면 찾은 만큼과 커서를 반환한다.
+const maxScanPerPage = 2000
+
+// QueryRooms 조건에 맞는 방을 정렬하여 한 페이지 반환. 다음 페이지가 없으면 nextCursor는 빈 문자열.
+// 정렬 기준과 가장 선택적인 조건에 맞는 인덱스를 순서대로 읽고, 나머지 조건은 불러온 방에 적용한다.
+func QueryRooms(ctx context.Context, q ListQuery) ([]*Room, string, error) {
+	if err := q.normalize(); err != nil {
+		return nil, "", err
+	}
+	var after *listCursor
+	if q.Cursor != "" {
+		c, err := decodeListCursor(q.Cursor)
+		if err != nil || c.Sort != q.Sort {
+			return nil, "", errors.New(resp.ErrorCodeRoomListInvalidQuery)
+		}
+		after = c
+	}
+
+	rdb := redisutil.Client[redisutil.RedisTargetRoom]
+	if rdb == nil {
+		return nil, "", errors.New(resp.ErrorCodeRoomNotFound)
+	}
+	key := q.indexKey()
+
+	start := int64(0)
+	if after != nil {
+		rank, err := rankAfter(ctx, rdb, key, after)
+		if err != nil {
+			return nil, "", err
+		}
+		start = rank
+	}
+
+	batch := int64(max(q.Limit+1, 50))
+	page := make([]*Room, 0, q.Limit+1)
+	positions := make([]listCursor, 0, q.Limit+1)
+	var last *listCursor
+	scanned := 0
+	for len(page) <= q.Limit && scanned < maxScanPerPage {
+		entries, err := rdb.ZRevRangeWithScores(ctx, key, start, start+batch-1).Result()
+		if err != nil {
+			return nil, "", err
+		}
+		if len(entries) == 0 {
+			last = nil
+			break
+		}
+		start += int64(len(entries))
+		scanned += len(entries)
+
+		ids := make([]string, len(entries))
+		for i, z := range entries {
+			ids[i], _ = z.Member.(string)
+		}
+		rooms, err := loadRooms(ctx, rdb, ids)
+		if err != nil {
+			return nil, "", err
+		}
+		for i, r := range rooms {
+			pos := listCursor{Sort: q.Sort, Score: entries[i].Score, ID: ids[i]}
+			last = &pos
+			if r == nil || !q.matches(r) {
+				continue
+			}
+			page = append(page, r)
+			positions = append(positions, pos)
+			if len(page) > q.Limit {
+				break
+			}
+		}
+		if int64(len(entries)) < batch {
+			if len(page) <= q.Limit {
+				last = nil // 인덱스 끝까지 읽음
+			}
+			break
+		}
+	}
+
+	if len(page) > q.Limit {
+		return page[:q.Limit], encodeListCursor(positions[q.Limit-1]), nil
+	}
+	if last != nil {
+		// 읽기 한도에 도달: 찾은 방과 함께 마지막으로 확인한 위치부터 이어서 조회할 커서를 반환
+		return page, encodeListCursor(*last), nil
+	}
+	return page, "", nil
+}
+
+// indexKey 정렬 기준과 조건에 맞는 인덱스. 인원 많은순은 인원 인덱스만 사용한다.
+func (q *ListQuery) indexKey() string {
+	switch {
+	case q.Sort == SortMostPlayers:
+		return indexPlayersKey
+	case q.GameMode != "":
+		return modeIndexKey(q.GameMode)
+	case q.NotStarted || q.Joinable:
+		return statusIndexKey(statusWaiting)
+	}
+	return indexCreatedKey
+}
+
+func (q *ListQuery) normalize() error {
+	if q.Sort == "" {
+		q.Sort = SortNewest
+	}
+	if q.Sort != SortNewest && q.Sort != SortMostPlayers {
+		return errors.New(resp.ErrorCodeRoomListInvalidQuery)
+	}
+	if q.Limit <= 0 {
+		q.Limit = DefaultListLimit
+	}
+	if q.Limit > MaxListLimit {
+		q.Limit = MaxListLimit
+	}
+	q.Search = strings.ToLower(strings.TrimSpace(q.Search))
+	return nil
+}
+
+func (q *ListQuery) matches(r *Room) bool {
+	if q.GameMode != "" && r.GameMode != q.GameMode {
+		return false
+	}
+	if q.HasPassword != nil && r.HasPassword != *q.HasPassword {
+		return false
+	}
+	if (q.NotStarted || q.Joinable) && r.IsGameStarted {
+		return false
+	}
+	if q.Joinable && len(r.Players) >= r.MaxPlayers {
+		return false
+	}
+	if q.Search != "" && !strings.Contains(strings.ToLower(r.RoomName), q.Search) {
+		return false
+	}
+	return true
+}
+
+func encodeListCursor(c listCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeListCursor(s string) (*listCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c listCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,97 @@
+package room
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ryeom/board-game/internal/game"
+)
+
+func TestListQuery_Matches(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	open := &Room{ID: "a", RoomName: "Hanabi Night", Players: []string{"p1"}, MaxPlayers: 4, GameMode: game.ModeHanabi, CreatedAt: base}
+	locked := &Room{ID: "b", RoomName: "고수방", Players: []string{"p1"}, MaxPlayers: 4, GameMode: game.ModeHanabi, HasPassword: true, CreatedAt: base}
+	full := &Room{ID: "c", RoomName: "full hanabi", Players: []string{"p1", "p2"}, MaxPlayers: 2, GameMode: game.ModeHanabi, CreatedAt: base}
+	started := &Room{ID: "d", RoomName: "tiles", Players: []string{"p1"}, MaxPlayers: 4, GameMode: game.ModeTilePush, IsGameStarted: true, CreatedAt: base}
+
+	noPassword := false
+	cases := []struct {
+		name  string
+		query ListQuery
+		room  *Room
+		want  bool
+	}{
+		{"조건 없음", ListQuery{}, started, true},
+		{"게임 모드 불일치", ListQuery{GameMode: game.ModeHanabi}, started, false},
+		{"공개 방만 - 비밀번호 방", ListQuery{HasPassword: &noPassword}, locked, false},
+		{"공개 방만 - 공개 방", ListQuery{HasPassword: &noPassword}, open, true},
+		{"참여 가능 - 가득 참", ListQuery{Joinable: true}, full, false},
+		{"참여 가능 - 시작됨", ListQuery{Joinable: true}, started, false},
+		{"시작 전 - 가득 참", ListQuery{NotStarted: true}, full, true},
+		{"이름 검색", ListQuery{Search: " HANABI "}, full, true},
+		{"이름 검색 불일치", ListQuery{Search: "tiles"}, open, false},
+	}
+	for _, tc := range cases {
+		q := tc.query
+		if err := q.normalize(); err != nil {
+			t.Fatalf("%s: normalize 실패: %v", tc.name, err)
+		}
+		if got := q.matches(tc.room); got != tc.want {
+			t.Errorf("%s: %v 이어야 하지만 %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestListQuery_IndexKey(t *testing.T) {
+	cases := []struct {
+		query ListQuery
+		want  string
+	}{
+		{ListQuery{Sort: SortNewest}, indexCreatedKey},
+		{ListQuery{Sort: SortNewest, GameMode: game.ModeHanabi, Joinable: true}, modeIndexKey(game.ModeHanabi)},
+		{ListQuery{Sort: SortNewest, Joinable: true}, statusIndexKey(statusWaiting)},
+		{ListQuery{Sort: SortMostPlayers, GameMode: game.ModeHanabi}, indexPlayersKey},
+	}
+	for _, tc := range cases {
+		if got := tc.query.indexKey(); got != tc.want {
+			t.Errorf("%+v: %s 이어야 하지만 %s", tc.query, tc.want, got)
+		}
+	}
+}
+
+func TestPlayersScore_OrdersByPlayersThenNewest(t *testing.T) {
+	older := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(365 * 24 * time.Hour)
+
+	crowdedOld := &Room{Players: []string{"p1", "p2", "p3"}, CreatedAt: older}
+	emptyNew := &Room{Players: []string{"p1"}, CreatedAt: newer}
+	crowdedNew := &Room{Players: []string{"p1", "p2", "p3"}, CreatedAt: newer}
+
+	if playersScore(crowdedOld) <= playersScore(emptyNew) {
+		t.Error("인원 수가 생성 시각보다 우선해야 함")
+	}
+	if playersScore(crowdedNew) <= playersScore(crowdedOld) {
+		t.Error("인원 수가 같으면 최근 생성된 방이 앞서야 함")
+	}
+}
+
+func TestListQuery_NormalizeAndCursor(t *testing.T) {
+	q := ListQuery{Sort: "oldest"}
+	if err := q.normalize(); err == nil {
+		t.Error("알 수 없는 정렬 기준은 거절되어야 함")
+	}
+
+	q = ListQuery{Limit: 1000}
+	if err := q.normalize(); err != nil || q.Sort != SortNewest || q.Limit != MaxListLimit {
+		t.Errorf("기본 정렬과 최대 limit이 적용되어야 하지만 %+v (%v)", q, err)
+	}
+
+	want := listCursor{Sort: SortMostPlayers, Score: 3*playersScoreWeight + 1767225600000, ID: "room:u1:1"}
+	got, err := decodeListCursor(encodeListCursor(want))
+	if err != nil || *got != want {
+		t.Errorf("커서 왕복 변환 실패: %+v (%v)", got, err)
+	}
+	if _, err := decodeListCursor("!!not-base64"); err == nil {
+		t.Error("손상된 커서는 거절되어야 함")
+	}
+}
@@ -0,0 +1,88 @@
+package room
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+
+	resp "github.com/Ryeom/board-game/internal/response"
+)
+
+// 재대결 좌석 순서
+const (
+	RematchSeatsKeep    = "keep"    // 이전 게임과 같은 순서
+	RematchSeatsRotate  = "rotate"  // 선 플레이어를 다음 사람으로 (한 칸씩 회전)
+	RematchSeatsShuffle = "shuffle" // 무작위로 섞음
+)
+
+// ValidRematchSeats 지원하는 좌석 순서인지 확인
+func ValidRematchSeats(seats string) bool {
+	return seats == RematchSeatsKeep || seats == RematchSeatsRotate || seats == RematchSeatsShuffle
+}
+
+// RematchVote 게임이 끝난 뒤 같은 방, 같은 인원으로 다시 하기 위한 투표
+type RematchVote struct {
+	Accepted map[string]bool `json:"accepted"` // 수락한 플레이어. 거절한 플레이어는 방에서 나간다.
+	Deadline time.Time       `json:"deadline"`
+}
+
+// OpenRematch 재대결 투표를 연다. 이전 투표가 있었다면 덮어쓴다.
+func (r *Room) OpenRematch(deadline time.Time) {
+	r.Rematch = &RematchVote{Accepted: make(map[string]bool), Deadline: deadline}
+}
+
+// AcceptRematch 재대결 수락 기록
+func (r *Room) AcceptRematch(userID string) error {
+	if r.Rematch == nil || r.IsGameStarted {
+		return errors.New(resp.ErrorCodeGameRematchNotOpen)
+	}
+	if !r.HasPlayer(userID) {
+		return errors.New(resp.ErrorCodeGamePlayerNotInRoom)
+	}
+	if r.Rematch.Accepted == nil {
+		r.Rematch.Accepted = make(map[string]bool)
+	}
+	r.Rematch.Accepted[userID] = true
+	return nil
+}
+
+// RematchAccepted 남아 있는 플레이어(2명 이상) 모두가 수락했는지
+func (r *Room) RematchAccepted() bool {
+	if r.Rematch == nil || len(r.Players) < 2 {
+		return false
+	}
+	for _, pid := range r.Players {
+		if !r.Rematch.Accepted[pid] {
+			return false
+		}
+	}
+	return true
+}
+
+// CloseRematch 투표를 닫고 대기 상태로 돌아간다. 수락한 플레이어는 준비 완료로 남겨 방장이 바로 시작할 수 있게 한다.
+func (r *Room) CloseRematch() {
+	r.ResetReady()
+	if r.Rematch != nil {
+		for _, pid := range r.Players {
+			if r.Rematch.Accepted[pid] {
+				r.ReadyPlayers[pid] = true
+			}
+		}
+	}
+	r.Rematch = nil
+}
+
+// ApplyRematchSeats RematchSeats 설정에 따라 플레이어 순서를 바꾼다. (첫 번째 플레이어가 선)
+func (r *Room) ApplyRematchSeats() {
+	if len(r.Players) < 2 {
+		return
+	}
+	switch r.RematchSeats {
+	case RematchSeatsRotate:
+		r.Players = append(r.Players[1:len(r.Players):len(r.Players)], r.Players[0])
+	case RematchSeatsShuffle:
+		rand.Shuffle(len(r.Players), func(i, j int) {
+			r.Players[i], r.Players[j] = r.Players[j], r.Players[i]
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package room
+
+import (
+	"testing"
+	"time"
+
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRematch_AcceptAndClose(t *testing.T) {
+	r := &Room{Host: "a", Players: []string{"a", "b", "c"}, ReadyPlayers: map[string]bool{}}
+
+	assert.EqualError(t, r.AcceptRematch("a"), resp.ErrorCodeGameRematchNotOpen)
+
+	r.OpenRematch(time.Now().Add(time.Minute))
+	assert.EqualError(t, r.AcceptRematch("x"), resp.ErrorCodeGamePlayerNotInRoom)
+	assert.NoError(t, r.AcceptRematch("a"))
+	assert.NoError(t, r.AcceptRematch("b"))
+	assert.False(t, r.RematchAccepted())
+
+	// 거절한 c가 나가면 남은 전원이 수락한 상태
+	assert.True(t, r.Leave("c"))
+	assert.True(t, r.RematchAccepted())
+
+	r.CloseRematch()
+	assert.Nil(t, r.Rematch)
+	assert.True(t, r.AllPlayersReady(), "수락한 플레이어는 준비 완료로 남아야 함")
+}
+
+func TestApplyRematchSeats(t *testing.T) {
+	r := &Room{Players: []string{"a", "b", "c"}, RematchSeats: RematchSeatsKeep}
+	r.ApplyRematchSeats()
+	assert.Equal(t, []string{"a", "b", "c"}, r.Players)
+
+	r.RematchSeats = RematchSeatsRotate
+	r.ApplyRematchSeats()
+	assert.Equal(t, []string{"b", "c", "a"}, r.Players)
+
+	r.RematchSeats = RematchSeatsShuffle
+	r.ApplyRematchSeats()
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, r.Players)
+}
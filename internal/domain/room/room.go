@@ -2,11 +2,13 @@ package room
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	redisutil "github.com/Ryeom/board-game/infra/redis"
 	"github.com/Ryeom/board-game/internal/game"
 	resp "github.com/Ryeom/board-game/internal/response"
 	"github.com/Ryeom/board-game/internal/util"
+	"github.com/Ryeom/board-game/log"
 	"time"
 )
 
@@ -16,14 +18,52 @@ type Room struct {
 	Host          string          `json:"host"` // 방장
 	Players       []string        `json:"players"`
 	ReadyPlayers  map[string]bool `json:"readyPlayers"`
-	Password      string          `json:"-"`
+	Password      string          `json:"-"` // bcrypt 해시. 클라이언트에 보내지 않고 Redis에는 storedRoom으로 저장한다.
+	HasPassword   bool            `json:"hasPassword"`
 	MaxPlayers    int             `json:"maxPlayers"`
 	GameMode      game.Mode       `json:"gameMode"`
+	Settings      game.Settings   `json:"settings"` // 게임 모드 스키마로 검증된 설정 (game.LookupRules)
 	IsGameStarted bool            `json:"isGameStarted"`
 	CreatedAt     time.Time       `json:"createdAt"`
+	UpdatedAt     time.Time       `json:"updatedAt"` // 마지막으로 방 상태가 바뀐 시각 (유휴 방 정리 기준)
+
+	Spectators         []string `json:"spectators"`
+	MaxSpectators      int      `json:"maxSpectators"`                // 0이면 관전 불가
+	SpectatorView      string   `json:"spectatorView"`                // hidden, delayed
+	SpectatorDelaySecs int      `json:"spectatorDelaySecs,omitempty"` // delayed 모드의 지연 시간
+
+	RematchSeats string       `json:"rematchSeats"`      // 재대결 좌석 순서: keep, rotate, shuffle
+	Rematch      *RematchVote `json:"rematch,omitempty"` // 진행 중인 재대결 투표 (게임 종료 직후)
+
+	Banned []string `json:"banned,omitempty"` // 방장이 차단한 유저. 플레이어/관전자로 들어올 수 없다.
+	Locked bool     `json:"locked"`           // 잠긴 방은 새로 참여/관전할 수 없다.
+
+	Teams map[string]string `json:"teams,omitempty"` // 팀이 있는 모드의 플레이어별 팀 (팀 채팅 채널). 현재 모드는 사용하지 않는다.
+}
+
+// TeamOf 플레이어의 팀. 팀이 없으면 빈 문자열.
+func (r *Room) TeamOf(playerID string) string {
+	return r.Teams[playerID]
 }
 
-func CreateRoom(ctx context.Context, roomID string, hostID string, roomName string, password string, maxPlayers int) (*Room, error) { // 인자 추가
+// CreateRoom 방 생성. maxPlayers가 0이면 모드의 최대 인원, settings의 빈 항목은 모드 기본값을 쓴다.
+func CreateRoom(ctx context.Context, roomID string, hostID string, roomName string, password string, maxPlayers int, mode game.Mode, settings game.Settings) (*Room, error) {
+	rules, ok := game.LookupRules(mode)
+	if !ok {
+		return nil, errors.New(resp.ErrorCodeRoomUnsupportedGameMode)
+	}
+	if maxPlayers == 0 {
+		maxPlayers = rules.MaxPlayers
+	}
+	if !rules.AcceptsPlayers(maxPlayers) {
+		return nil, errors.New(resp.ErrorCodeRoomInvalidMaxPlayers)
+	}
+	settings, err := rules.NormalizeSettings(settings)
+	if err != nil {
+		log.Logger.Debugf("CreateRoom - room %s: %v", roomID, err)
+		return nil, errors.New(resp.ErrorCodeRoomInvalidSettings)
+	}
+
 	hashedPassword := ""
 	if password != "" {
 		var err error
@@ -33,9 +73,6 @@ func CreateRoom(ctx context.Context, roomID string, hostID string, roomName stri
 		}
 	}
 
-	if maxPlayers < 2 { // TODO 게임 모드별 최대 인원 제한
-		maxPlayers = 2
-	}
 	r := &Room{
 		ID:            roomID,
 		RoomName:      roomName,
@@ -43,10 +80,19 @@ func CreateRoom(ctx context.Context, roomID string, hostID string, roomName stri
 		Players:       []string{hostID},
 		ReadyPlayers:  make(map[string]bool),
 		Password:      hashedPassword,
+		HasPassword:   hashedPassword != "",
 		MaxPlayers:    maxPlayers,
-		GameMode:      game.ModeHanabi,
+		GameMode:      mode,
+		Settings:      settings,
 		IsGameStarted: false,
 		CreatedAt:     time.Now(),
+
+		Spectators:         []string{},
+		MaxSpectators:      DefaultMaxSpectators,
+		SpectatorView:      SpectatorViewHidden,
+		SpectatorDelaySecs: DefaultSpectatorDelaySecs,
+
+		RematchSeats: RematchSeatsKeep,
 	}
 	if err := r.Save(); err != nil {
 		return nil, err
@@ -55,9 +101,34 @@ func CreateRoom(ctx context.Context, roomID string, hostID string, roomName stri
 }
 
 func GetRoom(ctx context.Context, roomID string) (*Room, bool) {
-	var r Room
-	ok := redisutil.GetJSON("room", "room:"+roomID, &r)
-	return &r, ok
+	stored := storedRoom{Room: &Room{}}
+	ok := redisutil.GetJSON(redisutil.RedisTargetRoom, roomKey(roomID), &stored)
+	return stored.room(), ok
+}
+
+// storedRoom Redis 저장 형식. 클라이언트 응답(Room JSON)에서 빠지는 비밀번호 해시를 함께 저장한다.
+type storedRoom struct {
+	*Room
+	PasswordHash string `json:"passwordHash,omitempty"`
+}
+
+func (s storedRoom) room() *Room {
+	s.Room.Password = s.PasswordHash
+	return s.Room
+}
+
+// encodeRoom 방을 Redis 저장 형식으로 직렬화
+func encodeRoom(r *Room) ([]byte, error) {
+	return json.Marshal(storedRoom{Room: r, PasswordHash: r.Password})
+}
+
+// decodeRoom Redis에 저장된 방을 읽는다.
+func decodeRoom(raw []byte) (*Room, error) {
+	stored := storedRoom{Room: &Room{}}
+	if err := json.Unmarshal(raw, &stored); err != nil {
+		return nil, err
+	}
+	return stored.room(), nil
 }
 
 func DeleteRoom(ctx context.Context, roomID string) error {
@@ -65,34 +136,133 @@ func DeleteRoom(ctx context.Context, roomID string) error {
 	if rdb == nil {
 		return errors.New(resp.ErrorCodeRoomDeleteFailed)
 	}
-	return redisutil.Delete("room", "room:"+roomID)
+	if err := deleteEventBuffer(ctx, roomID); err != nil {
+		return err
+	}
+	if err := RevokeInvites(ctx, roomID); err != nil {
+		log.Logger.Warningf("DeleteRoom - Failed to revoke invites for room %s: %v", roomID, err)
+	}
+	return deleteIndexed(ctx, rdb, roomID)
 }
 
+// ListRooms 전체 방을 최근 생성순으로 조회 (로비 목록은 페이지 단위인 QueryRooms 사용)
 func ListRooms(ctx context.Context) []*Room {
-	rdb := redisutil.Client["room"]
+	rdb := redisutil.Client[redisutil.RedisTargetRoom]
 	if rdb == nil {
 		return nil
 	}
-	keys, err := rdb.Keys(ctx, "room:*").Result()
+	ids, err := rdb.ZRevRange(ctx, indexCreatedKey, 0, -1).Result()
 	if err != nil {
 		return nil
 	}
 
+	const chunk = 500
 	var rooms []*Room
-	for _, key := range keys {
-		var r Room
-		if ok := redisutil.GetJSON("room", key, &r); ok {
-			rooms = append(rooms, &r)
+	for start := 0; start < len(ids); start += chunk {
+		end := min(start+chunk, len(ids))
+		loaded, err := loadRooms(ctx, rdb, ids[start:end])
+		if err != nil {
+			return rooms
+		}
+		for _, r := range loaded {
+			if r != nil {
+				rooms = append(rooms, r)
+			}
 		}
 	}
 	return rooms
 }
 
+// Summary 로비 목록에 노출하는 방 요약 정보
+type Summary struct {
+	ID          string    `json:"id"`
+	RoomName    string    `json:"roomName"`
+	Host        string    `json:"host"`
+	PlayerCount int       `json:"playerCount"`
+	MaxPlayers  int       `json:"maxPlayers"`
+	GameMode    string    `json:"gameMode"`
+	HasPassword bool      `json:"hasPassword"`
+	IsStarted   bool      `json:"isStarted"`
+	CreatedAt   time.Time `json:"createdAt"`
+
+	SpectatorCount int  `json:"spectatorCount"`
+	MaxSpectators  int  `json:"maxSpectators"`
+	Locked         bool `json:"locked"`
+}
+
+func Summaries(rooms []*Room) []Summary {
+	summaryList := make([]Summary, 0, len(rooms))
+	for _, r := range rooms {
+		summaryList = append(summaryList, Summary{
+			ID:          r.ID,
+			RoomName:    r.RoomName,
+			Host:        r.Host,
+			PlayerCount: len(r.Players),
+			MaxPlayers:  r.MaxPlayers,
+			GameMode:    string(r.GameMode),
+			HasPassword: r.HasPassword,
+			IsStarted:   r.IsGameStarted,
+			CreatedAt:   r.CreatedAt,
+
+			SpectatorCount: len(r.Spectators),
+			MaxSpectators:  r.MaxSpectators,
+			Locked:         r.Locked,
+		})
+	}
+	return summaryList
+}
+
+// Save 방 JSON과 목록 인덱스를 함께 저장
 func (r *Room) Save() error {
-	return redisutil.SaveJSON("room", "room:"+r.ID, r, 0)
+	rdb := redisutil.Client[redisutil.RedisTargetRoom]
+	if rdb == nil {
+		return errors.New(resp.ErrorCodeRoomUpdateFailed)
+	}
+	r.UpdatedAt = time.Now()
+	payload, err := encodeRoom(r)
+	if err != nil {
+		return err
+	}
+	return saveIndexed(context.Background(), rdb, r, payload)
 }
-func (r *Room) Join(ctx context.Context, userID string, password string) (bool, error) {
-	// 1. 방 참여 인원 제한 확인
+
+// LastActivity 마지막 상태 변경 시각. 이전 버전에서 저장되어 UpdatedAt이 없으면 생성 시각.
+func (r *Room) LastActivity() time.Time {
+	if r.UpdatedAt.IsZero() {
+		return r.CreatedAt
+	}
+	return r.UpdatedAt
+}
+
+// Join 입장 조건을 확인하고 플레이어를 추가한다. 이미 참여 중이면 false. (저장은 호출자가 room.Update로 수행)
+func (r *Room) Join(userID string, password string) (bool, error) {
+	return r.join(userID, password, false)
+}
+
+// JoinInvited 초대 코드로 입장. 비밀번호 확인을 건너뛰고 나머지 조건은 Join과 같다.
+func (r *Room) JoinInvited(userID string) (bool, error) {
+	return r.join(userID, "", true)
+}
+
+func (r *Room) join(userID string, password string, invited bool) (bool, error) {
+	// 1. 이미 참여 중인지 확인
+	for _, p := range r.Players {
+		if p == userID {
+			return false, nil
+		}
+	}
+
+	// 관전자는 이미 입장 조건을 통과했으므로 비밀번호를 다시 묻지 않음 (자리로 이동)
+	spectating := r.HasSpectator(userID)
+
+	if r.IsBanned(userID) {
+		return false, errors.New(resp.ErrorCodeRoomBanned)
+	}
+	if r.Locked && !spectating {
+		return false, errors.New(resp.ErrorCodeRoomLocked)
+	}
+
+	// 2. 방 참여 인원 제한 확인
 	if len(r.Players) >= r.MaxPlayers {
 		return false, errors.New(resp.ErrorCodeRoomFull)
 	}
@@ -101,29 +271,52 @@ func (r *Room) Join(ctx context.Context, userID string, password string) (bool,
 		return false, errors.New(resp.ErrorCodeGameAlreadyStarted)
 	}
 
-	// 2. 비밀번호가 설정된 방인 경우, 비밀번호 검증
-	if r.Password != "" {
+	// 3. 비밀번호가 설정된 방인 경우, 비밀번호 검증
+	if r.Password != "" && !invited && !spectating {
 		if !util.CheckPasswordHash(password, r.Password) {
 			return false, errors.New(resp.ErrorCodeRoomWrongPassword)
 		}
 	}
 
-	// 3. 이미 참여 중인지 확인
-	for _, p := range r.Players {
-		if p == userID {
-			return true, nil // 이미 참여 중
-		}
-	}
-
 	// 4. 플레이어 추가 및 레디 상태 초기화
+	if spectating {
+		r.RemoveSpectator(userID)
+	}
 	r.Players = append(r.Players, userID)
 	r.ResetReady()
-	if err := r.Save(); err != nil {
-		return false, errors.New(resp.ErrorCodeRoomJoinFailed)
-	}
 	return true, nil
 }
 
+// Leave 플레이어를 제거하고 레디 상태를 초기화한다. 방장이 나가면 남은 첫 플레이어가 방장이 된다.
+// 방에 없던 플레이어면 false.
+func (r *Room) Leave(userID string) bool {
+	remaining := make([]string, 0, len(r.Players))
+	for _, pid := range r.Players {
+		if pid != userID {
+			remaining = append(remaining, pid)
+		}
+	}
+	if len(remaining) == len(r.Players) {
+		return false
+	}
+	r.Players = remaining
+	r.ResetReady()
+	if r.Host == userID && len(remaining) > 0 {
+		r.Host = remaining[0]
+	}
+	return true
+}
+
+// HasPlayer 방에 참여 중인 플레이어인지 확인
+func (r *Room) HasPlayer(userID string) bool {
+	for _, pid := range r.Players {
+		if pid == userID {
+			return true
+		}
+	}
+	return false
+}
+
 // ToggleReady 플레이어의 레디 상태를 토글
 func (r *Room) ToggleReady(userID string) bool {
 	if r.ReadyPlayers == nil {
@@ -0,0 +1,51 @@
+package room
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoin_FullRoomAndDuplicate(t *testing.T) {
+	r := &Room{Host: "a", Players: []string{"a"}, ReadyPlayers: map[string]bool{"a": true}, MaxPlayers: 2}
+
+	joined, err := r.Join("a", "")
+	assert.NoError(t, err)
+	assert.False(t, joined, "이미 참여한 플레이어는 다시 추가되지 않아야 함")
+
+	joined, err = r.Join("b", "")
+	assert.NoError(t, err)
+	assert.True(t, joined)
+	assert.Empty(t, r.ReadyPlayers, "참여 시 레디 상태가 초기화되어야 함")
+
+	_, err = r.Join("c", "")
+	assert.Error(t, err, "정원이 찬 방에는 참여할 수 없어야 함")
+	assert.Equal(t, []string{"a", "b"}, r.Players)
+}
+
+func TestLeave_HostHandover(t *testing.T) {
+	r := &Room{Host: "a", Players: []string{"a", "b", "c"}, ReadyPlayers: map[string]bool{"b": true}}
+
+	assert.False(t, r.Leave("x"), "방에 없는 플레이어")
+	assert.True(t, r.Leave("a"))
+	assert.Equal(t, "b", r.Host, "방장이 나가면 남은 첫 플레이어가 방장이 되어야 함")
+	assert.Equal(t, []string{"b", "c"}, r.Players)
+	assert.Empty(t, r.ReadyPlayers)
+	assert.False(t, r.HasPlayer("a"))
+}
+
+func TestEncodeRoom_KeepsPasswordOutOfClientJSON(t *testing.T) {
+	r := &Room{ID: "room:1", Password: "hash", HasPassword: true}
+
+	client, err := json.Marshal(r)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(client), "hash", "클라이언트 응답에는 해시가 없어야 함")
+
+	raw, err := encodeRoom(r)
+	assert.NoError(t, err)
+	decoded, err := decodeRoom(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, "hash", decoded.Password, "저장 후 다시 읽어도 해시가 남아야 함")
+	assert.True(t, decoded.HasPassword)
+}
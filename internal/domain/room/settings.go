@@ -0,0 +1,60 @@
+package room
+
+import (
+	"errors"
+
+	"github.com/Ryeom/board-game/internal/game"
+	resp "github.com/Ryeom/board-game/internal/response"
+)
+
+// Rules 방 게임 모드의 인원 제한과 설정 스키마
+func (r *Room) Rules() (game.Rules, error) {
+	rules, ok := game.LookupRules(r.GameMode)
+	if !ok {
+		return game.Rules{}, errors.New(resp.ErrorCodeRoomUnsupportedGameMode)
+	}
+	return rules, nil
+}
+
+// SetGameMode 게임 모드를 바꾼다. 최대 인원은 새 모드 범위로 맞추고 설정은 새 모드 기본값으로 초기화한다.
+// 이미 들어온 플레이어가 새 모드의 최대 인원보다 많으면 ErrorCodeRoomInvalidMaxPlayers.
+func (r *Room) SetGameMode(mode game.Mode) error {
+	rules, ok := game.LookupRules(mode)
+	if !ok {
+		return errors.New(resp.ErrorCodeRoomUnsupportedGameMode)
+	}
+	if len(r.Players) > rules.MaxPlayers {
+		return errors.New(resp.ErrorCodeRoomInvalidMaxPlayers)
+	}
+	r.GameMode = mode
+	r.MaxPlayers = min(max(r.MaxPlayers, rules.MinPlayers), rules.MaxPlayers)
+	r.Settings = rules.DefaultSettings()
+	return nil
+}
+
+// SetMaxPlayers 최대 인원 변경. 모드 범위를 벗어나거나 현재 인원보다 작으면 ErrorCodeRoomInvalidMaxPlayers.
+func (r *Room) SetMaxPlayers(n int) error {
+	rules, err := r.Rules()
+	if err != nil {
+		return err
+	}
+	if !rules.AcceptsPlayers(n) || n < len(r.Players) {
+		return errors.New(resp.ErrorCodeRoomInvalidMaxPlayers)
+	}
+	r.MaxPlayers = n
+	return nil
+}
+
+// SetSettings 모드 스키마로 검증한 뒤 저장한다. 비어 있는 항목은 기본값으로 채운다.
+func (r *Room) SetSettings(s game.Settings) error {
+	rules, err := r.Rules()
+	if err != nil {
+		return err
+	}
+	normalized, err := rules.NormalizeSettings(s)
+	if err != nil {
+		return errors.New(resp.ErrorCodeRoomInvalidSettings)
+	}
+	r.Settings = normalized
+	return nil
+}
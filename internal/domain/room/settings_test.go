@@ -0,0 +1,40 @@
+package room
+
+import (
+	"testing"
+
+	"github.com/Ryeom/board-game/internal/game"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetGameMode_ClampsMaxPlayersAndResetsSettings(t *testing.T) {
+	r := &Room{Host: "a", Players: []string{"a"}, MaxPlayers: 5, GameMode: game.ModeHanabi, Settings: game.Settings{TurnSeconds: 120, Variant: game.VariantStandard}}
+
+	assert.NoError(t, r.SetGameMode(game.ModeTilePush))
+	assert.Equal(t, 2, r.MaxPlayers)
+	assert.Equal(t, game.Settings{TurnSeconds: 30, BoardRows: 5, BoardColumns: 5}, r.Settings)
+
+	assert.EqualError(t, r.SetGameMode(game.Mode("chess")), resp.ErrorCodeRoomUnsupportedGameMode)
+
+	r = &Room{Host: "a", Players: []string{"a", "b", "c"}, MaxPlayers: 4, GameMode: game.ModeHanabi}
+	assert.EqualError(t, r.SetGameMode(game.ModeTilePush), resp.ErrorCodeRoomInvalidMaxPlayers, "이미 들어온 인원이 새 모드 최대 인원보다 많으면 거부")
+	assert.Equal(t, game.ModeHanabi, r.GameMode)
+}
+
+func TestSetMaxPlayers_RespectsModeRange(t *testing.T) {
+	r := &Room{Host: "a", Players: []string{"a", "b", "c"}, MaxPlayers: 4, GameMode: game.ModeHanabi}
+
+	assert.EqualError(t, r.SetMaxPlayers(6), resp.ErrorCodeRoomInvalidMaxPlayers)
+	assert.EqualError(t, r.SetMaxPlayers(2), resp.ErrorCodeRoomInvalidMaxPlayers, "현재 인원보다 작게 줄일 수 없음")
+	assert.NoError(t, r.SetMaxPlayers(5))
+	assert.Equal(t, 5, r.MaxPlayers)
+}
+
+func TestSetSettings_Validates(t *testing.T) {
+	r := &Room{Host: "a", Players: []string{"a"}, MaxPlayers: 4, GameMode: game.ModeHanabi}
+
+	assert.EqualError(t, r.SetSettings(game.Settings{TurnSeconds: 1000}), resp.ErrorCodeRoomInvalidSettings)
+	assert.NoError(t, r.SetSettings(game.Settings{TurnSeconds: 45}))
+	assert.Equal(t, game.Settings{TurnSeconds: 45, Variant: game.VariantStandard}, r.Settings)
+}
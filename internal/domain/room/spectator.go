@@ -0,0 +1,95 @@
+package room
+
+import (
+	"errors"
+	"time"
+
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/util"
+)
+
+// 관전자 게임 화면
+const (
+	SpectatorViewHidden  = "hidden"  // 모든 손패를 가린 화면을 실시간으로
+	SpectatorViewDelayed = "delayed" // 모든 손패가 보이는 화면을 SpectatorDelaySecs 만큼 늦게
+)
+
+const (
+	DefaultMaxSpectators      = 10
+	MaxSpectatorsLimit        = 50
+	DefaultSpectatorDelaySecs = 30
+	MaxSpectatorDelaySecs     = 600
+)
+
+// ValidSpectatorView 지원하는 관전 화면인지 확인
+func ValidSpectatorView(view string) bool {
+	return view == SpectatorViewHidden || view == SpectatorViewDelayed
+}
+
+// SpectatorDelay delayed 모드에서 관전자에게 상태를 늦춰 보내는 시간
+func (r *Room) SpectatorDelay() time.Duration {
+	if r.SpectatorDelaySecs <= 0 {
+		return DefaultSpectatorDelaySecs * time.Second
+	}
+	return time.Duration(r.SpectatorDelaySecs) * time.Second
+}
+
+// Spectate 관전자로 입장. 게임 중에도 가능하며 플레이어 인원과 별도로 MaxSpectators까지 받는다. 이미 관전 중이면 false.
+func (r *Room) Spectate(userID string, password string) (bool, error) {
+	if r.HasPlayer(userID) {
+		return false, errors.New(resp.ErrorCodeRoomAlreadyJoined)
+	}
+	if r.HasSpectator(userID) {
+		return false, nil
+	}
+	if r.IsBanned(userID) {
+		return false, errors.New(resp.ErrorCodeRoomBanned)
+	}
+	if r.Locked {
+		return false, errors.New(resp.ErrorCodeRoomLocked)
+	}
+	if len(r.Spectators) >= r.MaxSpectators {
+		return false, errors.New(resp.ErrorCodeRoomSpectatorsFull)
+	}
+	if r.Password != "" && !util.CheckPasswordHash(password, r.Password) {
+		return false, errors.New(resp.ErrorCodeRoomWrongPassword)
+	}
+	r.Spectators = append(r.Spectators, userID)
+	return true, nil
+}
+
+// HasSpectator 관전 중인 유저인지 확인
+func (r *Room) HasSpectator(userID string) bool {
+	for _, sid := range r.Spectators {
+		if sid == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveSpectator 관전자 목록에서 제거. 관전 중이 아니었으면 false.
+func (r *Room) RemoveSpectator(userID string) bool {
+	for i, sid := range r.Spectators {
+		if sid == userID {
+			r.Spectators = append(r.Spectators[:i:i], r.Spectators[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Promote 관전자를 플레이어로 옮긴다. 게임과 게임 사이(시작 전)에만 가능하다.
+func (r *Room) Promote(userID string) error {
+	if !r.HasSpectator(userID) {
+		return errors.New(resp.ErrorCodeRoomNotSpectator)
+	}
+	joined, err := r.join(userID, "", true)
+	if err != nil {
+		return err
+	}
+	if !joined {
+		return errors.New(resp.ErrorCodeRoomAlreadyJoined)
+	}
+	return nil
+}
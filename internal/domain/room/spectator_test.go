@@ -0,0 +1,42 @@
+package room
+
+import (
+	"testing"
+
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpectate_SeparateCapDuringGame(t *testing.T) {
+	r := &Room{Host: "a", Players: []string{"a", "b"}, MaxPlayers: 2, MaxSpectators: 1, IsGameStarted: true}
+
+	_, err := r.Spectate("a", "")
+	assert.EqualError(t, err, resp.ErrorCodeRoomAlreadyJoined, "플레이어는 관전자로 들어올 수 없어야 함")
+
+	added, err := r.Spectate("s1", "")
+	assert.NoError(t, err)
+	assert.True(t, added, "정원이 찬 게임 중인 방에도 관전은 가능해야 함")
+
+	added, err = r.Spectate("s1", "")
+	assert.NoError(t, err)
+	assert.False(t, added)
+
+	_, err = r.Spectate("s2", "")
+	assert.EqualError(t, err, resp.ErrorCodeRoomSpectatorsFull)
+
+	assert.True(t, r.RemoveSpectator("s1"))
+	assert.False(t, r.HasSpectator("s1"))
+	assert.Equal(t, []string{"a", "b"}, r.Players)
+}
+
+func TestPromote_MovesSpectatorToSeat(t *testing.T) {
+	r := &Room{Host: "a", Players: []string{"a"}, ReadyPlayers: map[string]bool{}, MaxPlayers: 2, MaxSpectators: 5, Spectators: []string{"s1", "s2"}}
+
+	assert.EqualError(t, r.Promote("x"), resp.ErrorCodeRoomNotSpectator)
+	assert.NoError(t, r.Promote("s1"))
+	assert.Equal(t, []string{"a", "s1"}, r.Players)
+	assert.Equal(t, []string{"s2"}, r.Spectators)
+
+	assert.Error(t, r.Promote("s2"), "빈 자리가 없으면 옮길 수 없어야 함")
+	assert.True(t, r.HasSpectator("s2"))
+}
@@ -0,0 +1,54 @@
+package room
+
+import (
+	"context"
+	"errors"
+
+	redisutil "github.com/Ryeom/board-game/infra/redis"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/log"
+)
+
+// sweepBatchSize AllRooms가 한 번에 MGET으로 불러오는 방 수
+const sweepBatchSize = 200
+
+// AllRooms 인덱스에 등록된 모든 방을 불러온다. 방 JSON이 사라지고 인덱스에만 남은 항목은 정리한다. (정리 작업용)
+func AllRooms(ctx context.Context) ([]*Room, error) {
+	rdb := redisutil.Client[redisutil.RedisTargetRoom]
+	if rdb == nil {
+		return nil, errors.New(resp.ErrorCodeRoomNotFound)
+	}
+	ids, err := rdb.ZRange(ctx, indexCreatedKey, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	rooms := make([]*Room, 0, len(ids))
+	for start := 0; start < len(ids); start += sweepBatchSize {
+		batch := ids[start:min(start+sweepBatchSize, len(ids))]
+		loaded, err := loadRooms(ctx, rdb, batch)
+		if err != nil {
+			return nil, err
+		}
+		for i, r := range loaded {
+			if r != nil {
+				rooms = append(rooms, r)
+				continue
+			}
+			if err := deleteIndexed(ctx, rdb, batch[i]); err != nil {
+				log.Logger.Warningf("AllRooms - Failed to remove stale index entry %s: %v", batch[i], err)
+			}
+		}
+	}
+	return rooms, nil
+}
+
+// Exists 방이 저장되어 있는지 확인
+func Exists(ctx context.Context, roomID string) (bool, error) {
+	rdb := redisutil.Client[redisutil.RedisTargetRoom]
+	if rdb == nil {
+		return false, errors.New(resp.ErrorCodeRoomNotFound)
+	}
+	n, err := rdb.Exists(ctx, roomKey(roomID)).Result()
+	return n > 0, err
+}
@@ -0,0 +1,106 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	redisutil "github.com/Ryeom/board-game/infra/redis"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/log"
+	"github.com/redis/go-redis/v9"
+)
+
+// Mutation Update에 넘긴 변경 함수가 방을 어떻게 처리할지 알려주는 값
+type Mutation int
+
+const (
+	MutationSave   Mutation = iota // 변경 내용을 저장
+	MutationSkip                   // 바뀐 것이 없으므로 저장하지 않음
+	MutationDelete                 // 방을 삭제 (마지막 플레이어가 나간 경우 등)
+)
+
+// MaxUpdateRetries 동시 변경으로 트랜잭션이 실패했을 때 다시 시도하는 최대 횟수
+const MaxUpdateRetries = 20
+
+// Update 방을 WATCH 한 상태로 읽어 mutate를 적용하고 MULTI/EXEC로 저장한다.
+// 읽은 뒤 다른 요청이 방을 바꾸면 EXEC가 실패하므로 최신 상태로 mutate를 다시 호출한다.
+// 따라서 mutate는 여러 번 호출될 수 있으며, 방 외부의 상태를 바꾸면 안 된다. (결과는 클로저 변수에 매번 새로 기록)
+// mutate가 에러를 반환하면 저장하지 않고 그 에러를 그대로 반환한다.
+func Update(ctx context.Context, roomID string, mutate func(r *Room) (Mutation, error)) (*Room, Mutation, error) {
+	rdb := redisutil.Client[redisutil.RedisTargetRoom]
+	if rdb == nil {
+		return nil, MutationSkip, errors.New(resp.ErrorCodeRoomUpdateFailed)
+	}
+	key := roomKey(roomID)
+
+	for attempt := 0; attempt < MaxUpdateRetries; attempt++ {
+		var (
+			result   *Room
+			mutation Mutation
+		)
+		err := rdb.Watch(ctx, func(tx *redis.Tx) error {
+			raw, err := tx.Get(ctx, key).Bytes()
+			if errors.Is(err, redis.Nil) {
+				return errors.New(resp.ErrorCodeRoomNotFound)
+			}
+			if err != nil {
+				return err
+			}
+			r, err := decodeRoom(raw)
+			if err != nil {
+				return err
+			}
+
+			mutation, err = mutate(r)
+			if err != nil {
+				return err
+			}
+			result = r
+
+			switch mutation {
+			case MutationSkip:
+				return nil
+			case MutationDelete:
+				_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+					pipe.Del(ctx, eventSeqKey(roomID), eventBufferKey(roomID))
+					keys, args := deleteScriptArgs(roomID)
+					deleteScript.Eval(ctx, pipe, keys, args...)
+					return nil
+				})
+				return err
+			default:
+				r.UpdatedAt = time.Now()
+				payload, err := encodeRoom(r)
+				if err != nil {
+					return err
+				}
+				_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+					keys, args := saveScriptArgs(r, payload)
+					saveScript.Eval(ctx, pipe, keys, args...)
+					return nil
+				})
+				return err
+			}
+		}, key)
+
+		if errors.Is(err, redis.TxFailedErr) {
+			// 다른 요청과 겹침: 잠깐 쉬었다가 최신 상태로 재시도 (동시에 재시도하며 계속 부딪히지 않도록 지터 추가)
+			time.Sleep(time.Duration(attempt+1)*time.Millisecond + rand.N(time.Millisecond))
+			continue
+		}
+		if err != nil {
+			return nil, MutationSkip, err
+		}
+		if mutation == MutationDelete {
+			if err := RevokeInvites(ctx, roomID); err != nil {
+				log.Logger.Warningf("Update - Failed to revoke invites for deleted room %s: %v", roomID, err)
+			}
+		}
+		return result, mutation, nil
+	}
+
+	log.Logger.Warningf("Update - Gave up updating room %s after %d conflicting attempts", roomID, MaxUpdateRetries)
+	return nil, MutationSkip, errors.New(resp.ErrorCodeRoomUpdateConflict)
+}
@@ -13,3 +13,14 @@ type Engine interface {
 
 type State interface {
 }
+
+// ActionResult 액션 한 번의 결과(델타). 플레이어별로 가려야 하는 정보는 ViewFor에서 처리한다.
+type ActionResult interface {
+	StateVersion() int64
+	ViewFor(playerID string) any
+}
+
+// SpectatorViewer 관전자용 화면을 제공하는 상태/결과. omniscient면 모든 손패를 공개한다.
+type SpectatorViewer interface {
+	SpectatorView(omniscient bool) any
+}
@@ -0,0 +1,52 @@
+package hanabi
+
+// ActionResult 액션 한 번으로 바뀐 내용만 담은 델타.
+// 클라이언트는 Version이 자신이 가진 상태 버전 + 1이 아니면 game.sync로 전체 상태를 다시 요청한다.
+type ActionResult struct {
+	Version  int64  `json:"version"`
+	Action   string `json:"action"` // give_hint, play_card, discard
+	PlayerID string `json:"playerId"`
+	Forced   bool   `json:"forced,omitempty"` // 턴 타이머 만료로 서버가 대신 수행한 액션
+
+	// play_card, discard
+	CardIndex *int  `json:"cardIndex,omitempty"`
+	Card      *Card `json:"card,omitempty"`      // 내려놓거나 버린 카드 (공개 정보)
+	Success   *bool `json:"success,omitempty"`   // play_card 성공 여부
+	Drew      bool  `json:"drew,omitempty"`      // 덱에서 카드를 뽑아 손패 끝에 추가했는지
+	DrawnCard *Card `json:"drawnCard,omitempty"` // 뽑은 카드 (뽑은 본인에게는 가려짐)
+
+	// give_hint
+	TargetID       string `json:"targetId,omitempty"`
+	HintType       string `json:"hintType,omitempty"`
+	HintValue      any    `json:"hintValue,omitempty"`
+	TouchedIndices []int  `json:"touchedIndices,omitempty"`
+
+	// 액션 이후 공용 상태
+	HintTokens int  `json:"hintTokens"`
+	MissTokens int  `json:"missTokens"`
+	DeckCount  int  `json:"deckCount"`
+	TurnIndex  int  `json:"turnIndex"`
+	GameOver   bool `json:"gameOver"`
+}
+
+func (r *ActionResult) StateVersion() int64 {
+	return r.Version
+}
+
+// ViewFor 특정 플레이어 시점의 결과를 반환 (자신이 뽑은 카드는 보이지 않아야함)
+func (r *ActionResult) ViewFor(playerID string) any {
+	view := *r
+	if r.DrawnCard != nil && r.PlayerID == playerID {
+		view.DrawnCard = nil
+	}
+	return &view
+}
+
+// SpectatorView 관전자 시점의 결과. omniscient가 아니면 뽑은 카드를 가린다.
+func (r *ActionResult) SpectatorView(omniscient bool) any {
+	view := *r
+	if !omniscient {
+		view.DrawnCard = nil
+	}
+	return &view
+}
@@ -0,0 +1,185 @@
+package hanabi
+
+import "testing"
+
+func TestHandleEvent_BroadcastsActionResultInsteadOfState(t *testing.T) {
+	players := []string{"p1", "p2"}
+	var broadcasted any
+	engine := NewEngine(players,
+		func(eventName string, playerIDs []string, state any) { broadcasted = state },
+		func(state *State) error { return nil },
+		func() *State { return nil },
+	)
+
+	state := newTestState()
+	state.Deck = []*Card{{Color: Yellow, Number: 4}, {Color: White, Number: 1}}
+	state.PlayerHands["p1"] = []*Card{{Color: Red, Number: 1}, {Color: Blue, Number: 3}}
+	state.PlayerHands["p2"] = []*Card{{Color: Green, Number: 2}}
+	engine.CurrentState = state
+
+	err := engine.HandleEvent(Event{Type: "play_card", Data: map[string]any{
+		"playerId":  "p1",
+		"cardIndex": float64(0),
+	}})
+	if err != nil {
+		t.Fatalf("play_card 실패: %v", err)
+	}
+
+	result, ok := broadcasted.(*ActionResult)
+	if !ok {
+		t.Fatalf("브로드캐스트 페이로드가 *ActionResult여야 하지만 %T", broadcasted)
+	}
+	if result.Version != 1 || state.Version != 1 {
+		t.Errorf("액션 후 버전이 1이어야 하지만 result=%d state=%d", result.Version, state.Version)
+	}
+	if result.Action != "play_card" || result.PlayerID != "p1" {
+		t.Errorf("액션 정보가 잘못됨: %s by %s", result.Action, result.PlayerID)
+	}
+	if result.Success == nil || !*result.Success {
+		t.Error("Red 1 플레이는 성공이어야 함")
+	}
+	if result.CardIndex == nil || *result.CardIndex != 0 {
+		t.Error("cardIndex가 0이어야 함")
+	}
+	if !result.Drew || result.DrawnCard == nil || result.DrawnCard.Color != Yellow {
+		t.Error("덱에서 Yellow 4를 뽑아야 함")
+	}
+	if result.DeckCount != 1 || result.TurnIndex != 1 {
+		t.Errorf("deckCount=1, turnIndex=1 이어야 하지만 %d, %d", result.DeckCount, result.TurnIndex)
+	}
+}
+
+func TestActionResult_ViewForHidesOwnDrawnCard(t *testing.T) {
+	result := &ActionResult{
+		PlayerID:  "p1",
+		Drew:      true,
+		DrawnCard: &Card{Color: Red, Number: 5},
+	}
+
+	own := result.ViewFor("p1").(*ActionResult)
+	if own.DrawnCard != nil {
+		t.Error("자신이 뽑은 카드는 보이지 않아야 함")
+	}
+	if !own.Drew {
+		t.Error("카드를 뽑았다는 사실은 알려야 함")
+	}
+
+	other := result.ViewFor("p2").(*ActionResult)
+	if other.DrawnCard == nil || other.DrawnCard.Number != 5 {
+		t.Error("다른 플레이어는 뽑은 카드를 볼 수 있어야 함")
+	}
+	if result.DrawnCard == nil {
+		t.Error("ViewFor가 원본을 변경하면 안됨")
+	}
+}
+
+func TestGiveHint_RecordsTouchedIndices(t *testing.T) {
+	players := []string{"p1", "p2"}
+	var broadcasted any
+	engine := NewEngine(players,
+		func(eventName string, playerIDs []string, state any) { broadcasted = state },
+		func(state *State) error { return nil },
+		func() *State { return nil },
+	)
+
+	state := newTestState()
+	state.PlayerHands["p1"] = []*Card{{Color: Red, Number: 1}}
+	state.PlayerHands["p2"] = []*Card{
+		{Color: Blue, Number: 1},
+		{Color: Green, Number: 2},
+		{Color: Blue, Number: 4},
+	}
+	engine.CurrentState = state
+
+	err := engine.HandleEvent(Event{Type: "give_hint", Data: map[string]any{
+		"playerId": "p1",
+		"toId":     "p2",
+		"hintType": "color",
+		"value":    "blue",
+	}})
+	if err != nil {
+		t.Fatalf("give_hint 실패: %v", err)
+	}
+
+	result, ok := broadcasted.(*ActionResult)
+	if !ok {
+		t.Fatalf("브로드캐스트 페이로드가 *ActionResult여야 하지만 %T", broadcasted)
+	}
+	if len(result.TouchedIndices) != 2 || result.TouchedIndices[0] != 0 || result.TouchedIndices[1] != 2 {
+		t.Errorf("touchedIndices가 [0 2]여야 하지만 %v", result.TouchedIndices)
+	}
+	if result.TargetID != "p2" || result.HintTokens != MaxHintTokens-1 {
+		t.Errorf("targetId=p2, hintTokens=%d 이어야 하지만 %s, %d", MaxHintTokens-1, result.TargetID, result.HintTokens)
+	}
+
+	// HandleEvent 이후 pending은 비워져야 함
+	if engine.pending != nil {
+		t.Error("commit 이후 pending 결과가 남아있으면 안됨")
+	}
+	if state.Version != 1 {
+		t.Errorf("버전이 1이어야 하지만 %d", state.Version)
+	}
+}
+
+func TestExecuteForceAction_ResultIsForced(t *testing.T) {
+	players := []string{"p1", "p2"}
+	var broadcasted any
+	engine := NewEngine(players,
+		func(eventName string, playerIDs []string, state any) { broadcasted = state },
+		func(state *State) error { return nil },
+		func() *State { return nil },
+	)
+
+	state := newTestState()
+	state.HintTokens = 5
+	state.Deck = []*Card{{Color: White, Number: 2}}
+	state.PlayerHands["p1"] = []*Card{{Color: Red, Number: 1}}
+	state.PlayerHands["p2"] = []*Card{{Color: Green, Number: 3}}
+	engine.CurrentState = state
+
+	if err := engine.ExecuteForceAction(); err != nil {
+		t.Fatalf("ExecuteForceAction 실패: %v", err)
+	}
+
+	result, ok := broadcasted.(*ActionResult)
+	if !ok {
+		t.Fatalf("브로드캐스트 페이로드가 *ActionResult여야 하지만 %T", broadcasted)
+	}
+	if !result.Forced || result.Action != "discard" {
+		t.Errorf("강제 discard 결과여야 하지만 forced=%v action=%s", result.Forced, result.Action)
+	}
+	if result.HintTokens != 6 {
+		t.Errorf("hintTokens가 6이어야 하지만 %d", result.HintTokens)
+	}
+}
+
+func TestSpectatorView_HidesHandsUnlessOmniscient(t *testing.T) {
+	state := newTestState()
+	state.PlayerHands["p1"] = []*Card{{Color: Red, Number: 1, ColorKnown: true}}
+	state.PlayerHands["p2"] = []*Card{{Color: Green, Number: 2}}
+
+	hidden := state.SpectatorView(false).(*State)
+	if card := hidden.PlayerHands["p1"][0]; card.Color != Red || card.Number != 0 {
+		t.Errorf("알려진 색만 보여야 하지만 %s %d", card.Color, card.Number)
+	}
+	if card := hidden.PlayerHands["p2"][0]; card.Color != "" || card.Number != 0 {
+		t.Errorf("모르는 카드는 가려져야 하지만 %s %d", card.Color, card.Number)
+	}
+	if state.PlayerHands["p2"][0].Color != Green {
+		t.Error("관전자 화면이 원래 상태를 바꾸면 안됨")
+	}
+
+	full := state.SpectatorView(true).(*State)
+	full.Fireworks[Red] = 5
+	if full.PlayerHands["p2"][0].Color != Green || state.Fireworks[Red] == 5 {
+		t.Error("omniscient 화면은 손패를 보여주고 원래 상태와 분리되어야 함")
+	}
+
+	result := &ActionResult{Drew: true, DrawnCard: &Card{Color: Yellow, Number: 4}}
+	if view := result.SpectatorView(false).(*ActionResult); view.DrawnCard != nil {
+		t.Error("실시간 관전 화면에서는 뽑은 카드를 가려야 함")
+	}
+	if result.DrawnCard == nil {
+		t.Error("원래 결과의 뽑은 카드는 유지되어야 함")
+	}
+}
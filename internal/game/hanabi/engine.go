@@ -27,6 +27,8 @@ type Engine struct {
 	SetGameState SetGameStateFunc
 	GetGameState GetGameStateFunc
 	CurrentState *State
+	TurnLimit    time.Duration // 방 설정(turnSeconds)의 턴 제한 시간. 0이면 TurnDuration
+	pending      *ActionResult // 처리 중인 액션의 결과 (HandleEvent/ExecuteForceAction 동안만 유효)
 }
 
 func NewEngine(players []string, broadcast BroadcastFunc, setGameState SetGameStateFunc, getGameState GetGameStateFunc) *Engine {
@@ -95,6 +97,7 @@ func (e *Engine) HandleEvent(event any) error {
 		return fmt.Errorf("invalid event")
 	}
 	log.Logger.Debugf("[Hanabi] HandleEvent - Type: %s", cast.Type)
+	e.pending = nil
 	var err error
 	advanceTurn := false
 	switch cast.Type {
@@ -121,6 +124,8 @@ func (e *Engine) HandleEvent(event any) error {
 		}
 	}
 
+	result := e.commitResult(cast.Type)
+
 	// 상태 저장
 	if e.CurrentState != nil {
 		if saveErr := e.SetGameState(e.CurrentState); saveErr != nil {
@@ -129,12 +134,37 @@ func (e *Engine) HandleEvent(event any) error {
 	}
 
 	// 게임 종료 시에는 sync를 보내지 않음 (서비스 레이어에서 EndGame 호출)
+	// 전체 상태 대신 변경분(ActionResult)만 전송하고, 클라이언트는 버전이 어긋나면 game.sync로 스냅샷을 요청한다.
 	if !e.CurrentState.IsGameOver() {
-		e.Broadcast("game.action.sync", e.Players, e.CurrentState)
+		e.Broadcast("game.action.sync", e.Players, result)
 	}
 	return nil
 }
 
+// actionResult 처리 중인 액션의 결과를 반환 (핸들러가 직접 호출된 경우에도 안전하도록 지연 생성)
+func (e *Engine) actionResult() *ActionResult {
+	if e.pending == nil {
+		e.pending = &ActionResult{}
+	}
+	return e.pending
+}
+
+// commitResult 상태 버전을 올리고 액션 이후의 공용 상태를 결과에 채워 반환한다.
+func (e *Engine) commitResult(action string) *ActionResult {
+	result := e.actionResult()
+	e.pending = nil
+
+	e.CurrentState.Version++
+	result.Version = e.CurrentState.Version
+	result.Action = action
+	result.HintTokens = e.CurrentState.HintTokens
+	result.MissTokens = e.CurrentState.MissTokens
+	result.DeckCount = len(e.CurrentState.Deck)
+	result.TurnIndex = e.CurrentState.TurnIndex
+	result.GameOver = e.CurrentState.IsGameOver()
+	return result
+}
+
 // drawCard 덱에서 카드를 뽑아 플레이어 손에 추가하고, 덱이 비면 LastPlayer를 설정한다.
 func (e *Engine) drawCard(playerID string) {
 	if len(e.CurrentState.Deck) > 0 {
@@ -144,6 +174,10 @@ func (e *Engine) drawCard(playerID string) {
 			Number: orig.Number,
 		})
 		e.CurrentState.Deck = e.CurrentState.Deck[1:]
+
+		result := e.actionResult()
+		result.Drew = true
+		result.DrawnCard = &Card{Color: orig.Color, Number: orig.Number}
 	} else {
 		if e.CurrentState.LastPlayer == -1 {
 			for i, pID := range e.Players {
@@ -198,7 +232,7 @@ func (e *Engine) handleGiveHint(data map[string]any) error {
 	}
 
 	// 매칭되는 카드 수를 세서 빈 힌트 방지
-	matchCount := 0
+	var touched []int
 	switch hintType {
 	case "color":
 		colorStr, ok := value.(string)
@@ -206,32 +240,45 @@ func (e *Engine) handleGiveHint(data map[string]any) error {
 			return fmt.Errorf("invalid color hint value")
 		}
 		color := Color(colorStr)
-		for _, card := range hand {
+		for i, card := range hand {
 			if card.Color == color {
-				card.ColorKnown = true
-				matchCount++
+				touched = append(touched, i)
 			}
 		}
+		if len(touched) == 0 {
+			return fmt.Errorf("hint must match at least one card")
+		}
+		for _, i := range touched {
+			hand[i].ColorKnown = true
+		}
 	case "number":
 		num, ok := value.(float64)
 		if !ok {
 			return fmt.Errorf("invalid number hint value")
 		}
-		for _, card := range hand {
+		for i, card := range hand {
 			if card.Number == int(num) {
-				card.NumberKnown = true
-				matchCount++
+				touched = append(touched, i)
 			}
 		}
+		if len(touched) == 0 {
+			return fmt.Errorf("hint must match at least one card")
+		}
+		for _, i := range touched {
+			hand[i].NumberKnown = true
+		}
 	default:
 		return fmt.Errorf("unknown hint type: %s", hintType)
 	}
 
-	if matchCount == 0 {
-		return fmt.Errorf("hint must match at least one card")
-	}
-
 	e.CurrentState.HintTokens--
+
+	result := e.actionResult()
+	result.PlayerID = playerID
+	result.TargetID = toID
+	result.HintType = hintType
+	result.HintValue = value
+	result.TouchedIndices = touched
 	return nil
 }
 
@@ -254,7 +301,14 @@ func (e *Engine) handlePlayCard(data map[string]any) error {
 	e.CurrentState.PlayerHands[playerID] = append(hand[:index], hand[index+1:]...)
 	e.drawCard(playerID)
 
-	if e.CurrentState.Fireworks[card.Color]+1 == card.Number {
+	success := e.CurrentState.Fireworks[card.Color]+1 == card.Number
+	result := e.actionResult()
+	result.PlayerID = playerID
+	result.CardIndex = &index
+	result.Card = &Card{Color: card.Color, Number: card.Number}
+	result.Success = &success
+
+	if success {
 		e.CurrentState.Fireworks[card.Color] = card.Number
 		if card.Number == MaxCardNumber && e.CurrentState.HintTokens < MaxHintTokens {
 			e.CurrentState.HintTokens++
@@ -298,6 +352,11 @@ func (e *Engine) handleDiscardCard(data map[string]any) error {
 	e.CurrentState.PlayerHands[playerID] = append(hand[:index], hand[index+1:]...)
 	e.drawCard(playerID)
 
+	result := e.actionResult()
+	result.PlayerID = playerID
+	result.CardIndex = &index
+	result.Card = &Card{Color: card.Color, Number: card.Number}
+
 	if e.CurrentState.HintTokens < MaxHintTokens {
 		e.CurrentState.HintTokens++
 	}
@@ -318,6 +377,9 @@ func (e *Engine) handleEndTurn() error {
 }
 
 func (e *Engine) GetTurnDuration() time.Duration {
+	if e.TurnLimit > 0 {
+		return e.TurnLimit
+	}
 	return TurnDuration
 }
 
@@ -343,15 +405,19 @@ func (e *Engine) ExecuteForceAction() error {
 		"cardIndex": float64(cardIndex),
 	}
 
+	e.pending = &ActionResult{Forced: true}
+	action := "discard"
 	var err error
 	if e.CurrentState.HintTokens >= MaxHintTokens {
 		log.Logger.Debugf("[Hanabi] ForceAction: play_card (hint tokens full) player=%s index=%d", playerID, cardIndex)
+		action = "play_card"
 		err = e.handlePlayCard(data)
 	} else {
 		log.Logger.Debugf("[Hanabi] ForceAction: discard player=%s index=%d", playerID, cardIndex)
 		err = e.handleDiscardCard(data)
 	}
 	if err != nil {
+		e.pending = nil
 		return fmt.Errorf("force action failed: %w", err)
 	}
 
@@ -359,6 +425,8 @@ func (e *Engine) ExecuteForceAction() error {
 		return fmt.Errorf("force end_turn failed: %w", err)
 	}
 
+	result := e.commitResult(action)
+
 	if e.CurrentState != nil {
 		if saveErr := e.SetGameState(e.CurrentState); saveErr != nil {
 			log.Logger.Errorf("[Hanabi] Error saving state after force action: %v", saveErr)
@@ -366,7 +434,7 @@ func (e *Engine) ExecuteForceAction() error {
 	}
 
 	if !e.CurrentState.IsGameOver() {
-		e.Broadcast("game.action.sync", e.Players, e.CurrentState)
+		e.Broadcast("game.action.sync", e.Players, result)
 	}
 
 	return nil
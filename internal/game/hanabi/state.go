@@ -16,6 +16,7 @@ const (
 )
 
 type State struct {
+	Version     int64              `json:"version"` // 액션이 적용될 때마다 1씩 증가
 	Fireworks   map[Color]int      `json:"fireworks"`
 	HintTokens  int                `json:"hintTokens"`
 	MissTokens  int                `json:"missTokens"`
@@ -135,6 +136,35 @@ func (s *State) GetPlayerView(playerID string) *State {
 	return &playerView
 }
 
+// SpectatorView 관전자 시점의 상태. omniscient가 false면 모든 손패를 각 주인이 보는 것처럼 가리고, true면 그대로 보여준다.
+// 지연 전송을 위해 바로 직렬화할 수 있도록 공유되는 맵/슬라이스도 복사한다.
+func (s *State) SpectatorView(omniscient bool) any {
+	view := *s
+	view.Fireworks = make(map[Color]int, len(s.Fireworks))
+	for color, n := range s.Fireworks {
+		view.Fireworks[color] = n
+	}
+	view.DiscardPile = append([]*Card(nil), s.DiscardPile...)
+	view.PlayerHands = make(map[string][]*Card, len(s.PlayerHands))
+	for pID, hand := range s.PlayerHands {
+		copiedHand := make([]*Card, len(hand))
+		for i, card := range hand {
+			copiedCard := *card
+			if !omniscient {
+				if !copiedCard.ColorKnown {
+					copiedCard.Color = ""
+				}
+				if !copiedCard.NumberKnown {
+					copiedCard.Number = 0
+				}
+			}
+			copiedHand[i] = &copiedCard
+		}
+		view.PlayerHands[pID] = copiedHand
+	}
+	return &view
+}
+
 // GetCardsRemainingInDeck 현재 덱에 남은 카드의 수 반환
 func (s *State) GetCardsRemainingInDeck() int {
 	return len(s.Deck)
@@ -0,0 +1,142 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+)
+
+// SettingType 방 설정 항목의 값 종류. 클라이언트는 이 값으로 입력 폼을 그린다.
+type SettingType string
+
+const (
+	SettingInt  SettingType = "int"  // Min~Max 범위의 정수
+	SettingEnum SettingType = "enum" // Options 중 하나
+)
+
+// SettingField 게임 모드가 받는 방 설정 항목 하나 (game.info로 내려간다)
+type SettingField struct {
+	Key     string      `json:"key"` // Settings의 json 키
+	Type    SettingType `json:"type"`
+	Label   string      `json:"label"`
+	Default any         `json:"default"`
+	Min     int         `json:"min,omitempty"`
+	Max     int         `json:"max,omitempty"`
+	Options []string    `json:"options,omitempty"`
+	Unit    string      `json:"unit,omitempty"`
+}
+
+// Settings 방에 저장되는 게임 설정. 모드 스키마에 없는 항목은 0/빈 값으로 둔다.
+type Settings struct {
+	TurnSeconds  int    `json:"turnSeconds,omitempty"`  // 턴 제한 시간(초)
+	Variant      string `json:"variant,omitempty"`      // 규칙 변형
+	BoardRows    int    `json:"boardRows,omitempty"`    // 보드 세로 칸 수
+	BoardColumns int    `json:"boardColumns,omitempty"` // 보드 가로 칸 수
+}
+
+// Rules 게임 모드별 인원 제한과 설정 스키마
+type Rules struct {
+	Mode       Mode           `json:"mode"`
+	MinPlayers int            `json:"minPlayers"`
+	MaxPlayers int            `json:"maxPlayers"`
+	Settings   []SettingField `json:"settings"`
+}
+
+const (
+	SettingTurnSeconds  = "turnSeconds"
+	SettingVariant      = "variant"
+	SettingBoardRows    = "boardRows"
+	SettingBoardColumns = "boardColumns"
+
+	VariantStandard = "standard"
+)
+
+var rules = map[Mode]Rules{
+	ModeHanabi: {
+		Mode:       ModeHanabi,
+		MinPlayers: 2,
+		MaxPlayers: 5,
+		Settings: []SettingField{
+			{Key: SettingTurnSeconds, Type: SettingInt, Label: "턴 제한 시간", Default: 60, Min: 15, Max: 300, Unit: "seconds"},
+			{Key: SettingVariant, Type: SettingEnum, Label: "규칙", Default: VariantStandard, Options: []string{VariantStandard}},
+		},
+	},
+	ModeTilePush: { // 목표 타일이 두 종류라 정확히 2인
+		Mode:       ModeTilePush,
+		MinPlayers: 2,
+		MaxPlayers: 2,
+		Settings: []SettingField{
+			{Key: SettingTurnSeconds, Type: SettingInt, Label: "턴 제한 시간", Default: 30, Min: 10, Max: 120, Unit: "seconds"},
+			{Key: SettingBoardRows, Type: SettingInt, Label: "보드 세로", Default: 5, Min: 3, Max: 8},
+			{Key: SettingBoardColumns, Type: SettingInt, Label: "보드 가로", Default: 5, Min: 3, Max: 8},
+		},
+	},
+}
+
+// LookupRules 모드의 규칙. 방을 만들 수 없는 모드면 false.
+func LookupRules(mode Mode) (Rules, bool) {
+	r, ok := rules[mode]
+	return r, ok
+}
+
+// AcceptsPlayers 인원 n이 모드의 최소~최대 인원 안에 있는지
+func (r Rules) AcceptsPlayers(n int) bool {
+	return n >= r.MinPlayers && n <= r.MaxPlayers
+}
+
+// DefaultSettings 스키마 기본값으로 채운 설정
+func (r Rules) DefaultSettings() Settings {
+	s, _ := r.NormalizeSettings(Settings{})
+	return s
+}
+
+// NormalizeSettings 비어 있는 항목은 기본값으로 채우고, 스키마에 없거나 범위를 벗어난 값이 있으면 에러.
+func (r Rules) NormalizeSettings(s Settings) (Settings, error) {
+	ints := map[string]*int{
+		SettingTurnSeconds:  &s.TurnSeconds,
+		SettingBoardRows:    &s.BoardRows,
+		SettingBoardColumns: &s.BoardColumns,
+	}
+	strs := map[string]*string{
+		SettingVariant: &s.Variant,
+	}
+	for key, v := range ints {
+		f, ok := r.field(key)
+		if !ok {
+			if *v != 0 {
+				return s, fmt.Errorf("%s: setting %q is not supported", r.Mode, key)
+			}
+			continue
+		}
+		if *v == 0 {
+			*v = f.Default.(int)
+		}
+		if *v < f.Min || *v > f.Max {
+			return s, fmt.Errorf("%s: setting %q must be between %d and %d", r.Mode, key, f.Min, f.Max)
+		}
+	}
+	for key, v := range strs {
+		f, ok := r.field(key)
+		if !ok {
+			if *v != "" {
+				return s, fmt.Errorf("%s: setting %q is not supported", r.Mode, key)
+			}
+			continue
+		}
+		if *v == "" {
+			*v = f.Default.(string)
+		}
+		if !slices.Contains(f.Options, *v) {
+			return s, fmt.Errorf("%s: setting %q must be one of %v", r.Mode, key, f.Options)
+		}
+	}
+	return s, nil
+}
+
+func (r Rules) field(key string) (SettingField, bool) {
+	for _, f := range r.Settings {
+		if f.Key == key {
+			return f, true
+		}
+	}
+	return SettingField{}, false
+}
@@ -0,0 +1,47 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupRules_PlayerLimits(t *testing.T) {
+	hanabi, ok := LookupRules(ModeHanabi)
+	assert.True(t, ok)
+	assert.True(t, hanabi.AcceptsPlayers(2))
+	assert.True(t, hanabi.AcceptsPlayers(5))
+	assert.False(t, hanabi.AcceptsPlayers(6))
+
+	tilePush, ok := LookupRules(ModeTilePush)
+	assert.True(t, ok)
+	assert.True(t, tilePush.AcceptsPlayers(2))
+	assert.False(t, tilePush.AcceptsPlayers(3), "목표 타일이 두 종류라 2인만 가능해야 함")
+
+	_, ok = LookupRules(Mode("chess"))
+	assert.False(t, ok)
+}
+
+func TestNormalizeSettings(t *testing.T) {
+	hanabi, _ := LookupRules(ModeHanabi)
+
+	s, err := hanabi.NormalizeSettings(Settings{})
+	assert.NoError(t, err)
+	assert.Equal(t, Settings{TurnSeconds: 60, Variant: VariantStandard}, s, "빈 항목은 기본값으로 채워야 함")
+
+	s, err = hanabi.NormalizeSettings(Settings{TurnSeconds: 90})
+	assert.NoError(t, err)
+	assert.Equal(t, 90, s.TurnSeconds)
+
+	_, err = hanabi.NormalizeSettings(Settings{TurnSeconds: 5})
+	assert.Error(t, err, "범위를 벗어난 값은 거부")
+	_, err = hanabi.NormalizeSettings(Settings{Variant: "rainbow"})
+	assert.Error(t, err, "선택지에 없는 값은 거부")
+	_, err = hanabi.NormalizeSettings(Settings{BoardRows: 5})
+	assert.Error(t, err, "모드 스키마에 없는 항목은 거부")
+
+	tilePush, _ := LookupRules(ModeTilePush)
+	s, err = tilePush.NormalizeSettings(Settings{BoardColumns: 7})
+	assert.NoError(t, err)
+	assert.Equal(t, Settings{TurnSeconds: 30, BoardRows: 5, BoardColumns: 7}, s)
+}
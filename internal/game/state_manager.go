@@ -5,6 +5,7 @@ import (
 	"fmt"
 	redisutil "github.com/Ryeom/board-game/infra/redis"
 	"github.com/Ryeom/board-game/log"
+	"strings"
 	"time"
 )
 
@@ -44,3 +45,26 @@ func DeleteGameState(ctx context.Context, gameMode Mode, roomID string) error {
 	log.Logger.Debugf("Deleted game state for room %s (mode %s)", roomID, gameMode)
 	return nil
 }
+
+// StateRef 저장된 게임 상태 키가 가리키는 방
+type StateRef struct {
+	Mode   Mode
+	RoomID string
+}
+
+// ScanGameStates 저장된 모든 게임 상태 키를 찾는다. (정리 작업용, 방 ID에 ':'가 있어도 첫 ":state:"로 나눈다)
+func ScanGameStates() []StateRef {
+	var refs []StateRef
+	for _, key := range redisutil.ScanKeyList(redisutil.RedisTargetGame, "game:*:state:*") {
+		rest, ok := strings.CutPrefix(key, "game:")
+		if !ok {
+			continue
+		}
+		mode, roomID, ok := strings.Cut(rest, ":state:")
+		if !ok || mode == "" || roomID == "" {
+			continue
+		}
+		refs = append(refs, StateRef{Mode: Mode(mode), RoomID: roomID})
+	}
+	return refs
+}
@@ -12,6 +12,12 @@ import (
 
 const TurnDuration = 30 * time.Second
 
+// 방 설정이 없을 때의 보드 크기
+const (
+	DefaultRows    = 5
+	DefaultColumns = 5
+)
+
 type Event struct {
 	Type string
 	Data map[string]any
@@ -28,6 +34,10 @@ type Engine struct {
 	SetGameState SetGameStateFunc
 	GetGameState GetGameStateFunc
 	CurrentState *State
+	TurnLimit    time.Duration     // 방 설정(turnSeconds)의 턴 제한 시간. 0이면 TurnDuration
+	TileSet      *tilepush.TileSet // 사용할 타일 세트. nil이면 불러온 세트 중 무작위
+	rows         int               // 방 설정(boardRows). SetBoardDimensions로 지정
+	columns      int               // 방 설정(boardColumns)
 }
 
 func NewEngine(players []string, broadcast BroadcastFunc, setGameState SetGameStateFunc, getGameState GetGameStateFunc) *Engine {
@@ -39,24 +49,27 @@ func NewEngine(players []string, broadcast BroadcastFunc, setGameState SetGameSt
 	}
 }
 
-func (e *Engine) StartGame(rows, columns int) {
+func (e *Engine) StartGame() {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 	log.Logger.Debugf("[TilePush] StartGame")
 
 	state := e.GetGameState()
 	if state == nil {
-		tileSet, err := tilepush.GetRandomTileSet()
-		if err != nil {
-			log.Logger.Errorf("[TilePush] Failed to get tile set: %v", err)
-			return
+		tileSet := e.TileSet
+		if tileSet == nil {
+			var err error
+			if tileSet, err = tilepush.GetRandomTileSet(); err != nil {
+				log.Logger.Errorf("[TilePush] Failed to get tile set: %v", err)
+				return
+			}
 		}
-
+		rows, columns := e.boardSize()
 		state = NewState(e.Players, tileSet, rows, columns)
-		e.CurrentState = state
 	} else {
 		log.Logger.Debugf("[TilePush] Resuming game with existing state.")
 	}
+	e.CurrentState = state
 
 	if err := e.SetGameState(e.CurrentState); err != nil { // CurrentState를 저장
 		log.Logger.Errorf("[TilePush] Error saving game state on start: %v", err)
@@ -96,10 +109,7 @@ func (e *Engine) HandleEvent(event any) error {
 	if err != nil {
 		return err
 	}
-
-	if e.IsGameOver() {
-		e.endGameLocked()
-	}
+	e.IsGameOver() // 종료 여부와 승자를 상태에 기록 (game.end는 호출한 쪽의 EndGame에서)
 
 	if saveErr := e.SetGameState(e.CurrentState); saveErr != nil {
 		log.Logger.Errorf("[TilePush] Error saving game state after event %s: %v", cast.Type, saveErr)
@@ -189,20 +199,36 @@ func (e *Engine) handleTilePush(data map[string]any) error {
 	return nil
 }
 
+// IsGameOver 덱이 비었거나, 목표 타일로 한 줄을 채웠거나, 보드가 가득 차면 종료
 func (e *Engine) IsGameOver() bool {
 	if e.CurrentState == nil {
 		return false
 	}
-	if len(e.CurrentState.Deck) == 0 {
-		e.CurrentState.GameOver = true // 덱이 비면 게임 종료로 설정
-	}
-	return e.CurrentState.GameOver
+	return e.CurrentState.IsGameOver()
 }
+
+// SetBoardDimensions 새 게임의 보드 크기 (StartGame 전에 호출). 0이면 기본 크기.
 func (e *Engine) SetBoardDimensions(rows, columns int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rows, e.columns = rows, columns
+}
 
+func (e *Engine) boardSize() (int, int) {
+	rows, columns := e.rows, e.columns
+	if rows <= 0 {
+		rows = DefaultRows
+	}
+	if columns <= 0 {
+		columns = DefaultColumns
+	}
+	return rows, columns
 }
 
 func (e *Engine) GetTurnDuration() time.Duration {
+	if e.TurnLimit > 0 {
+		return e.TurnLimit
+	}
 	return TurnDuration
 }
 
@@ -152,6 +152,12 @@ func (s *State) IsGameOver() bool {
 	return s.GameOver
 }
 
+// SpectatorView 관전자 화면. 숨겨진 손패가 없어 플레이어 화면과 같다.
+func (s *State) SpectatorView(omniscient bool) any {
+	view := *s
+	return &view
+}
+
 func (s *State) GetPlayerView(playerID string) *State {
 	playerView := *s
 	return &playerView
@@ -21,6 +21,18 @@ const (
 	ErrorCodeAuthTokenBlacklistCheckFailed = "ERROR_AUTH_TOKEN_BLACKLIST_CHECK_FAILED"
 	ErrorCodeAuthTokenBlacklisted          = "ERROR_AUTH_TOKEN_BLACKLISTED"
 	ErrorCodeAuthInvalidRequest            = "ERROR_AUTH_INVALID_REQUEST"
+	ErrorCodeAuthRefreshInvalid            = "ERROR_AUTH_REFRESH_INVALID"
+	ErrorCodeAuthRefreshReused             = "ERROR_AUTH_REFRESH_REUSED"
+	ErrorCodeAuthSessionRevoked            = "ERROR_AUTH_SESSION_REVOKED"
+	ErrorCodeAuthSessionNotFound           = "ERROR_AUTH_SESSION_NOT_FOUND"
+	ErrorCodeAuthSessionFailed             = "ERROR_AUTH_SESSION_FAILED"
+	ErrorCodeAuthActionTokenInvalid        = "ERROR_AUTH_ACTION_TOKEN_INVALID"
+	ErrorCodeAuthEmailAlreadyVerified      = "ERROR_AUTH_EMAIL_ALREADY_VERIFIED"
+	ErrorCodeAuthMailSendFailed            = "ERROR_AUTH_MAIL_SEND_FAILED"
+	ErrorCodeAuthAccountRecoveryFailed     = "ERROR_AUTH_ACCOUNT_RECOVERY_FAILED"
+	ErrorCodeAuthLoginThrottled            = "ERROR_AUTH_LOGIN_THROTTLED"
+	ErrorCodeAuthAccountLocked             = "ERROR_AUTH_ACCOUNT_LOCKED"
+	ErrorCodeAuthUnlockFailed              = "ERROR_AUTH_UNLOCK_FAILED"
 	ErrorCodeWSExpectedIdentify            = "ERROR_WS_EXPECTED_IDENTIFICATION"
 	ErrorCodeWSInitialSessionSaveFailed    = "ERROR_WS_INITIAL_SESSION_SAVE_FAILED"
 	ErrorCodeWSInvalidMessageFormat        = "ERROR_WS_INVALID_MESSAGE_FORMAT"
@@ -28,6 +40,15 @@ const (
 	ErrorCodeChatEmptyMessage              = "ERROR_CHAT_EMPTY_MESSAGE"
 	ErrorCodeChatSendFailed                = "ERROR_CHAT_SEND_FAILED"
 	ErrorCodeChatHistoryFetchFailed        = "ERROR_CHAT_HISTORY_FAILURE"
+	ErrorCodeChatHistoryInvalidQuery       = "ERROR_CHAT_HISTORY_INVALID_QUERY"
+	ErrorCodeChatMuted                     = "ERROR_CHAT_MUTED"
+	ErrorCodeChatRateLimited               = "ERROR_CHAT_RATE_LIMITED"
+	ErrorCodeChatMessageTooLong            = "ERROR_CHAT_MESSAGE_TOO_LONG"
+	ErrorCodeChatMessageBlocked            = "ERROR_CHAT_MESSAGE_BLOCKED"
+	ErrorCodeChatMuteForbidden             = "ERROR_CHAT_MUTE_FORBIDDEN"
+	ErrorCodeChatNotMuted                  = "ERROR_CHAT_NOT_MUTED"
+	ErrorCodeChatChannelInvalid            = "ERROR_CHAT_CHANNEL_INVALID"
+	ErrorCodeChatChannelForbidden          = "ERROR_CHAT_CHANNEL_FORBIDDEN"
 	ErrorCodeAuthUpdateLastLoginAt         = "ERROR_AUTH_UPDATE_LAST_LOGIN_at"
 
 	// User Error Codes
@@ -41,6 +62,11 @@ const (
 	ErrorCodeUserProfileInvalidRequest          = "ERROR_USER_PROFILE_INVALID_REQUEST"
 	ErrorCodeUserChangePasswordValidationFailed = "ERROR_USER_PASSWORD_CHANGE_VALIDATION_FAILED"
 	ErrorCodeUserChangePasswordNotFound         = "ERROR_USER_CHANGE_PASSWORD_NOT_FOUND"
+	ErrorCodeUserDataExportFailed               = "ERROR_USER_DATA_EXPORT_FAILED"
+	ErrorCodeUserDeleteValidationFailed         = "ERROR_USER_DELETE_VALIDATION_FAILED"
+	ErrorCodeUserDeleteFailed                   = "ERROR_USER_DELETE_FAILED"
+	ErrorCodeUserStillActive                    = "ERROR_USER_STILL_ACTIVE"
+	ErrorCodeUserAnonymizeFailed                = "ERROR_USER_ANONYMIZE_FAILED"
 
 	// Room Error Codes
 	ErrorCodeRoomInvalidRequest        = "ERROR_ROOM_INVALID_REQUEST"
@@ -59,11 +85,50 @@ const (
 	ErrorCodeRoomUpdateFailed          = "ERROR_ROOM_UPDATE_FAILED"
 	ErrorCodeRoomUserNotInRoom         = "ERROR_ROOM_USER_NOT_IN_ROOM"
 	ErrorCodeRoomKickFailed            = "ERROR_ROOM_KICK_FAILED"
+	ErrorCodeRoomListInvalidQuery      = "ERROR_ROOM_LIST_INVALID_QUERY"
+	ErrorCodeRoomUpdateConflict        = "ERROR_ROOM_UPDATE_CONFLICT"
+	ErrorCodeRoomSpectatorsFull        = "ERROR_ROOM_SPECTATORS_FULL"
+	ErrorCodeRoomNotSpectator          = "ERROR_ROOM_NOT_SPECTATOR"
+	ErrorCodeRoomBanned                = "ERROR_ROOM_BANNED"
+	ErrorCodeRoomLocked                = "ERROR_ROOM_LOCKED"
+	ErrorCodeRoomUserNotBanned         = "ERROR_ROOM_USER_NOT_BANNED"
+	ErrorCodeRoomInvalidMaxPlayers     = "ERROR_ROOM_INVALID_MAX_PLAYERS"
+	ErrorCodeRoomInvalidSettings       = "ERROR_ROOM_INVALID_SETTINGS"
 	ErrorCodeUserNoUpdates             = "ERROR_USER_NO_UPDATES"
 	ErrorCodeUserInvalidRequest        = "ERROR_USER_INVALID_REQUEST"
+	ErrorCodeUserNameNotEditable       = "ERROR_USER_NAME_NOT_EDITABLE"
+	ErrorCodeUserInvalidStatus         = "ERROR_USER_INVALID_STATUS"
 	ErrorCodeChatMuteFailed            = "ERROR_CHAT_MUTE_FAILED"
 
-	ErrorCodeWSUnknownEvent = "ERROR_WS_UNKNOWN_EVENT"
+	ErrorCodeInviteInvalidRequest = "ERROR_INVITE_INVALID_REQUEST"
+	ErrorCodeInviteNotFound       = "ERROR_INVITE_NOT_FOUND"
+	ErrorCodeInviteExhausted      = "ERROR_INVITE_EXHAUSTED"
+	ErrorCodeInviteCreateFailed   = "ERROR_INVITE_CREATE_FAILED"
+
+	ErrorCodeMatchInvalidRequest   = "ERROR_MATCH_INVALID_REQUEST"
+	ErrorCodeMatchAlreadyQueued    = "ERROR_MATCH_ALREADY_QUEUED"
+	ErrorCodeMatchNotQueued        = "ERROR_MATCH_NOT_QUEUED"
+	ErrorCodeMatchInRoom           = "ERROR_MATCH_IN_ROOM"
+	ErrorCodeMatchQueueFailed      = "ERROR_MATCH_QUEUE_FAILED"
+	ErrorCodeMatchEmailNotVerified = "ERROR_MATCH_EMAIL_NOT_VERIFIED"
+
+	ErrorCodeFriendInvalidTarget   = "ERROR_FRIEND_INVALID_TARGET"
+	ErrorCodeFriendBlocked         = "ERROR_FRIEND_BLOCKED"
+	ErrorCodeFriendAlreadyFriends  = "ERROR_FRIEND_ALREADY_FRIENDS"
+	ErrorCodeFriendRequestExists   = "ERROR_FRIEND_REQUEST_EXISTS"
+	ErrorCodeFriendRequestNotFound = "ERROR_FRIEND_REQUEST_NOT_FOUND"
+	ErrorCodeFriendNotFound        = "ERROR_FRIEND_NOT_FOUND"
+	ErrorCodeFriendNotBlocked      = "ERROR_FRIEND_NOT_BLOCKED"
+	ErrorCodeFriendGuestNotAllowed = "ERROR_FRIEND_GUEST_NOT_ALLOWED"
+	ErrorCodeFriendFailed          = "ERROR_FRIEND_FAILED"
+	ErrorCodeDMNotFriends          = "ERROR_DM_NOT_FRIENDS"
+	ErrorCodeDMInvalidQuery        = "ERROR_DM_INVALID_QUERY"
+	ErrorCodeDMSendFailed          = "ERROR_DM_SEND_FAILED"
+	ErrorCodeDMFetchFailed         = "ERROR_DM_FETCH_FAILED"
+
+	ErrorCodeWSUnknownEvent  = "ERROR_WS_UNKNOWN_EVENT"
+	ErrorCodeWSGuestDisabled = "ERROR_WS_GUEST_DISABLED"
+	ErrorCodeWSRateLimited   = "ERROR_WS_RATE_LIMITED"
 
 	ErrorCodeGameAlreadyStarted        = "ERROR_GAME_ALREADY_STARTED"
 	ErrorCodeGameNotEnoughPlayers      = "ERROR_GAME_NOT_ENOUGH_PLAYERS"
@@ -77,6 +142,8 @@ const (
 	ErrorCodeGameNotAllPlayersReady    = "ERROR_GAME_NOT_ALL_PLAYERS_READY"
 	ErrorCodeGameInfoNotSaved          = "ERROR_GAME_INFO_NOT_SAVED"
 	ErrorCodeGameStateNotDeleted       = "ERROR_GAME_STATE_NOT_DELETED"
+	ErrorCodeGameRematchNotOpen        = "ERROR_GAME_REMATCH_NOT_OPEN"
+	ErrorCodeGameTileSetUnavailable    = "ERROR_GAME_TILESET_UNAVAILABLE"
 
 	ErrorCodeSystemFeatureNotImplemented = "ERROR_SYSTEM_FEATURE_NOT_IMPLEMENTED"
 )
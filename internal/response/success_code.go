@@ -13,6 +13,19 @@ const (
 	SuccessCodeUserProfileUpdate  = "SUCCESS_USER_PROFILE_UPDATE"
 	SuccessCodeUserProfileGet     = "SUCCESS_USER_PROFILE_GET"
 	SuccessCodeUserReconnected    = "SUCCESS_USER_RECONNECTED"
+	SuccessCodeUserDataExport     = "SUCCESS_USER_DATA_EXPORT"
+	SuccessCodeUserDelete         = "SUCCESS_USER_DELETE"
+	SuccessCodeUserDeletePending  = "SUCCESS_USER_DELETE_CLEANUP_PENDING"
+	SuccessCodeUserAnonymize      = "SUCCESS_USER_ANONYMIZE"
+
+	SuccessCodeAuthRefresh               = "SUCCESS_AUTH_REFRESH"
+	SuccessCodeAuthSessionList           = "SUCCESS_AUTH_SESSION_LIST"
+	SuccessCodeAuthSessionRevoke         = "SUCCESS_AUTH_SESSION_REVOKE"
+	SuccessCodeAuthPasswordResetRequest  = "SUCCESS_AUTH_PASSWORD_RESET_REQUEST"
+	SuccessCodeAuthPasswordReset         = "SUCCESS_AUTH_PASSWORD_RESET"
+	SuccessCodeAuthEmailVerificationSent = "SUCCESS_AUTH_EMAIL_VERIFICATION_SENT"
+	SuccessCodeAuthEmailVerified         = "SUCCESS_AUTH_EMAIL_VERIFIED"
+	SuccessCodeAuthLoginUnlock           = "SUCCESS_AUTH_LOGIN_UNLOCK"
 
 	SuccessCodeRoomCreate    = "SUCCESS_ROOM_CREATE"
 	SuccessCodeRoomJoin      = "SUCCESS_ROOM_JOIN"
@@ -24,10 +37,41 @@ const (
 	SuccessCodeRoomNoChanges = "SUCCESS_ROOM_NO_CHANGES" // 변경 사항 없을 때
 	SuccessCodeRoomReady    = "SUCCESS_ROOM_READY"
 
+	SuccessCodeRoomSpectate     = "SUCCESS_ROOM_SPECTATE"
+	SuccessCodeRoomPromote      = "SUCCESS_ROOM_PROMOTE"
+	SuccessCodeRoomInviteCreate = "SUCCESS_ROOM_INVITE_CREATE"
+	SuccessCodeRoomBan          = "SUCCESS_ROOM_BAN"
+	SuccessCodeRoomUnban        = "SUCCESS_ROOM_UNBAN"
+	SuccessCodeRoomTransferHost = "SUCCESS_ROOM_TRANSFER_HOST"
+	SuccessCodeRoomClosed       = "SUCCESS_ROOM_CLOSED"
+	SuccessCodeInvitePreview    = "SUCCESS_INVITE_PREVIEW"
+
+	SuccessCodeMatchQueue  = "SUCCESS_MATCH_QUEUE"
+	SuccessCodeMatchCancel = "SUCCESS_MATCH_CANCEL"
+	SuccessCodeMatchFound  = "SUCCESS_MATCH_FOUND"
+
 	SuccessCodeChatSend         = "SUCCESS_CHAT_SEND"
 	SuccessCodeChatHistoryFetch = "SUCCESS_CHAT_HISTORY_FETCH"
+	SuccessCodeChatMute         = "SUCCESS_CHAT_MUTE"
+	SuccessCodeChatUnmute       = "SUCCESS_CHAT_UNMUTE"
+
+	SuccessCodeFriendList        = "SUCCESS_FRIEND_LIST"
+	SuccessCodeFriendRequestList = "SUCCESS_FRIEND_REQUEST_LIST"
+	SuccessCodeFriendBlockList   = "SUCCESS_FRIEND_BLOCK_LIST"
+	SuccessCodeFriendRequest     = "SUCCESS_FRIEND_REQUEST"
+	SuccessCodeFriendAccept      = "SUCCESS_FRIEND_ACCEPT"
+	SuccessCodeFriendDecline     = "SUCCESS_FRIEND_DECLINE"
+	SuccessCodeFriendRemove      = "SUCCESS_FRIEND_REMOVE"
+	SuccessCodeFriendBlock       = "SUCCESS_FRIEND_BLOCK"
+	SuccessCodeFriendUnblock     = "SUCCESS_FRIEND_UNBLOCK"
+
+	SuccessCodeDMSend    = "SUCCESS_DM_SEND"
+	SuccessCodeDMHistory = "SUCCESS_DM_HISTORY"
+	SuccessCodeDMRead    = "SUCCESS_DM_READ"
+	SuccessCodeDMUnread  = "SUCCESS_DM_UNREAD"
 
 	SuccessCodeSystemErrorReceived = "SUCCESS_SYSTEM_ERROR_RECEIVED"
+	SuccessCodeSystemSync          = "SUCCESS_SYSTEM_SYNC"
 
 	SuccessCodeGameStart        = "SUCCESS_GAME_START"
 	SuccessCodeGameEnd          = "SUCCESS_GAME_END"
@@ -37,4 +81,7 @@ const (
 	SuccessCodeGameTimerStarted = "SUCCESS_GAME_TIMER_STARTED"
 	SuccessCodeGameTimerReset   = "SUCCESS_GAME_TIMER_RESET"
 	SuccessCodeGameTimerExpired = "SUCCESS_GAME_TIMER_EXPIRED"
+	SuccessCodeGameRematch      = "SUCCESS_GAME_REMATCH"
+	SuccessCodeGameRematchVote  = "SUCCESS_GAME_REMATCH_VOTE"
+	SuccessCodeGameRematchClose = "SUCCESS_GAME_REMATCH_CLOSE"
 )
@@ -0,0 +1,21 @@
+package response
+
+// 채팅 타임라인(system/game 기록) 메시지 템플릿. message의 {이름} 자리는 기록의 params로 채운다.
+const (
+	TimelineCodeJoined      = "CHAT_SYSTEM_JOINED"
+	TimelineCodeSpectating  = "CHAT_SYSTEM_SPECTATING"
+	TimelineCodePromoted    = "CHAT_SYSTEM_PROMOTED"
+	TimelineCodeLeft        = "CHAT_SYSTEM_LEFT"
+	TimelineCodeKicked      = "CHAT_SYSTEM_KICKED"
+	TimelineCodeBanned      = "CHAT_SYSTEM_BANNED"
+	TimelineCodeHostChanged = "CHAT_SYSTEM_HOST_CHANGED"
+
+	TimelineCodeGameStarted     = "CHAT_GAME_STARTED"
+	TimelineCodeGameAborted     = "CHAT_GAME_ABORTED"
+	TimelineCodeHintColor       = "CHAT_GAME_HINT_COLOR"
+	TimelineCodeHintNumber      = "CHAT_GAME_HINT_NUMBER"
+	TimelineCodePlayed          = "CHAT_GAME_PLAYED"
+	TimelineCodeMisplayed       = "CHAT_GAME_MISPLAYED"
+	TimelineCodeDiscarded       = "CHAT_GAME_DISCARDED"
+	TimelineCodeGameEndedPrefix = "CHAT_GAME_ENDED_" // + 종료 사유 대문자 (PERFECT, MISS_DEPLETED, DECK_EXHAUSTED)
+)
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/Ryeom/board-game/infra/db"
+	"github.com/Ryeom/board-game/infra/mail"
+	"github.com/Ryeom/board-game/internal/auth"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/Ryeom/board-game/internal/util"
+	"github.com/Ryeom/board-game/log"
+)
+
+// AccountMailConfig 계정 메일(비밀번호 재설정, 이메일 인증) 설정
+type AccountMailConfig struct {
+	LinkBase  string        // 메일 링크 앞부분. 뒤에 password-reset?token=..., email-verify?token=...이 붙는다.
+	ResetTTL  time.Duration // 비밀번호 재설정 링크 유효 시간
+	VerifyTTL time.Duration // 이메일 인증 링크 유효 시간
+}
+
+// AccountMail 서버 시작 시 bg.mail 설정으로 바뀐다.
+var AccountMail = AccountMailConfig{
+	LinkBase:  "boardgame://auth/",
+	ResetTTL:  time.Hour,
+	VerifyTTL: 24 * time.Hour,
+}
+
+// 언어별 메일 제목과 본문 (링크, 유효 시간(분))
+var accountMailTemplates = map[string]map[auth.Purpose][2]string{
+	"ko": {
+		auth.PurposePasswordReset: {"[Board Game] 비밀번호 재설정", "비밀번호 재설정을 요청하셨습니다.\n아래 링크에서 새 비밀번호를 설정해주세요. (%d분 동안 한 번만 사용할 수 있습니다)\n\n%s\n\n요청하지 않으셨다면 이 메일을 무시해주세요."},
+		auth.PurposeEmailVerify:   {"[Board Game] 이메일 인증", "Board Game에 가입해주셔서 감사합니다.\n아래 링크로 이메일을 인증해주세요. (%d분 동안 한 번만 사용할 수 있습니다)\n\n%s"},
+	},
+	"en": {
+		auth.PurposePasswordReset: {"[Board Game] Reset your password", "We received a request to reset your password.\nSet a new password with the link below. (It can be used once within %d minutes.)\n\n%s\n\nIf you didn't request this, you can ignore this email."},
+		auth.PurposeEmailVerify:   {"[Board Game] Verify your email", "Thanks for signing up for Board Game.\nVerify your email with the link below. (It can be used once within %d minutes.)\n\n%s"},
+	},
+}
+
+// RequestPasswordReset 가입된 활성 계정이면 비밀번호 재설정 메일을 보낸다.
+// 가입 여부가 드러나지 않도록 없는 이메일도 에러 없이 끝난다.
+func RequestPasswordReset(ctx context.Context, email, lang string) error {
+	u, err := user.FindUserByEmail(email)
+	if err != nil {
+		return recoveryError("RequestPasswordReset", email, err)
+	}
+	if u == nil || !u.IsActive {
+		log.Logger.Infof("RequestPasswordReset - No active account for %s", email)
+		return nil
+	}
+	return sendAccountMail(ctx, auth.PurposePasswordReset, u, lang, AccountMail.ResetTTL)
+}
+
+// ConfirmPasswordReset 재설정 토큰으로 비밀번호를 바꾸고 모든 로그인 세션을 종료한다.
+// 메일을 받았으므로 이메일도 인증된 것으로 보고, 로그인 실패로 잠긴 계정도 풀어준다. 비밀번호를 바꾼 유저 ID를 반환한다.
+func ConfirmPasswordReset(ctx context.Context, token, newPassword string) (string, error) {
+	u, err := consumeAccountToken(ctx, auth.PurposePasswordReset, token)
+	if err != nil {
+		return "", err
+	}
+	userID := u.ID.String()
+	hashed, err := util.HashPassword(newPassword)
+	if err != nil {
+		return "", recoveryError("ConfirmPasswordReset", userID, err)
+	}
+	if err := user.UpdatePassword(db.DB.WithContext(ctx), userID, hashed); err != nil {
+		return "", recoveryError("ConfirmPasswordReset", userID, err)
+	}
+	if err := user.MarkEmailVerified(db.DB.WithContext(ctx), userID); err != nil {
+		log.Logger.Errorf("ConfirmPasswordReset - Failed to mark email verified for %s: %v", userID, err)
+	}
+	if _, err := auth.RevokeAllSessions(ctx, userID, auth.RevokeReasonPasswordReset); err != nil {
+		log.Logger.Errorf("ConfirmPasswordReset - Failed to revoke sessions of %s: %v", userID, err)
+	}
+	if _, err := auth.UnlockLogin(ctx, u.Email, userID, "", auth.UnlockReasonPasswordReset); err != nil {
+		log.Logger.Errorf("ConfirmPasswordReset - Failed to unlock login of %s: %v", userID, err)
+	}
+	return userID, nil
+}
+
+// SendEmailVerification 이메일 인증 메일을 보낸다. 이미 인증했으면 ERROR_AUTH_EMAIL_ALREADY_VERIFIED.
+func SendEmailVerification(ctx context.Context, u *user.User, lang string) error {
+	if u.EmailVerified() {
+		return errors.New(resp.ErrorCodeAuthEmailAlreadyVerified)
+	}
+	return sendAccountMail(ctx, auth.PurposeEmailVerify, u, lang, AccountMail.VerifyTTL)
+}
+
+// VerifyEmail 인증 토큰으로 이메일 인증을 마친다.
+func VerifyEmail(ctx context.Context, token string) error {
+	u, err := consumeAccountToken(ctx, auth.PurposeEmailVerify, token)
+	if err != nil {
+		return err
+	}
+	if err := user.MarkEmailVerified(db.DB.WithContext(ctx), u.ID.String()); err != nil {
+		return recoveryError("VerifyEmail", u.ID.String(), err)
+	}
+	return nil
+}
+
+func sendAccountMail(ctx context.Context, purpose auth.Purpose, u *user.User, lang string, ttl time.Duration) error {
+	userID := u.ID.String()
+	token, err := auth.IssueActionToken(ctx, purpose, userID, u.Email, ttl)
+	if err != nil {
+		log.Logger.Errorf("sendAccountMail - Failed to issue %s token for %s: %v", purpose, userID, err)
+		return errors.New(resp.ErrorCodeAuthMailSendFailed)
+	}
+	templates, ok := accountMailTemplates[util.NormalizeLanguage(lang)]
+	if !ok {
+		templates = accountMailTemplates["ko"]
+	}
+	template := templates[purpose]
+	link := AccountMail.LinkBase + string(purpose) + "?token=" + url.QueryEscape(token)
+	msg := mail.Message{
+		To:      u.Email,
+		Subject: template[0],
+		Body:    fmt.Sprintf(template[1], int(ttl.Minutes()), link),
+	}
+	if err := mail.Default.Send(ctx, msg); err != nil {
+		log.Logger.Errorf("sendAccountMail - Failed to send %s mail to %s: %v", purpose, userID, err)
+		return errors.New(resp.ErrorCodeAuthMailSendFailed)
+	}
+	return nil
+}
+
+// consumeAccountToken 토큰을 사용하고 대상 계정을 반환한다. 탈퇴했거나 발급 후 이메일이 바뀌었으면 ERROR_AUTH_ACTION_TOKEN_INVALID.
+func consumeAccountToken(ctx context.Context, purpose auth.Purpose, token string) (*user.User, error) {
+	record, err := auth.ConsumeActionToken(ctx, purpose, token)
+	if err != nil {
+		return nil, recoveryError("consumeAccountToken", string(purpose), err)
+	}
+	u, err := user.FindUserByID(record.UserID)
+	if err != nil {
+		return nil, recoveryError("consumeAccountToken", record.UserID, err)
+	}
+	if u == nil || !u.IsActive || u.Email != record.Email {
+		return nil, errors.New(resp.ErrorCodeAuthActionTokenInvalid)
+	}
+	return u, nil
+}
+
+// recoveryError 응답 코드가 정해진 에러는 그대로, 그 외에는 로그를 남기고 ERROR_AUTH_ACCOUNT_RECOVERY_FAILED로 바꾼다.
+func recoveryError(op, target string, err error) error {
+	if _, ok := resp.GetDefineCode(err.Error(), "ko"); ok {
+		return err
+	}
+	log.Logger.Errorf("%s - Account recovery error for %s: %v", op, target, err)
+	return errors.New(resp.ErrorCodeAuthAccountRecoveryFailed)
+}
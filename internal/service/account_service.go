@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	chat "github.com/Ryeom/board-game/internal/domain/chat"
+	"github.com/Ryeom/board-game/internal/domain/dm"
+	"github.com/Ryeom/board-game/internal/domain/match"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/Ryeom/board-game/log"
+)
+
+// UserExport 개인 정보 내보내기 (GET /api/user/export)
+type UserExport struct {
+	ExportedAt     time.Time          `json:"exportedAt"`
+	Profile        ExportProfile      `json:"profile"`
+	Matches        []*match.Result    `json:"matches"`        // 최신순
+	Messages       []*chat.ChatRecord `json:"messages"`       // 보낸 방 채팅, 오래된 순
+	DirectMessages []*dm.Message      `json:"directMessages"` // 보낸 1:1 메시지, 오래된 순
+}
+
+// ExportProfile 내보내는 프로필 (비밀번호 제외)
+type ExportProfile struct {
+	UserID          string     `json:"userId"`
+	Email           string     `json:"email"`
+	Nickname        string     `json:"nickname"`
+	ProfileImage    *string    `json:"profileImage,omitempty"`
+	Role            user.Role  `json:"role"`
+	IsActive        bool       `json:"isActive"`
+	EmailVerifiedAt *time.Time `json:"emailVerifiedAt,omitempty"`
+	LastLoginAt     *time.Time `json:"lastLoginAt,omitempty"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
+
+// ExportUserData 유저의 프로필, 전적, 보낸 메시지를 하나로 모은다.
+func ExportUserData(ctx context.Context, u *user.User) (*UserExport, error) {
+	userID := u.ID.String()
+	matches, err := match.ResultsByUser(ctx, userID)
+	if err != nil {
+		return nil, exportError(userID, err)
+	}
+	messages, err := chat.MessagesBySender(ctx, userID)
+	if err != nil {
+		return nil, exportError(userID, err)
+	}
+	directMessages, err := dm.SentBy(ctx, userID)
+	if err != nil {
+		return nil, exportError(userID, err)
+	}
+
+	return &UserExport{
+		ExportedAt: time.Now(),
+		Profile: ExportProfile{
+			UserID:          userID,
+			Email:           u.Email,
+			Nickname:        u.Nickname,
+			ProfileImage:    u.ProfileImage,
+			Role:            u.Role,
+			IsActive:        u.IsActive,
+			EmailVerifiedAt: u.EmailVerifiedAt,
+			LastLoginAt:     u.LastLoginAt,
+			CreatedAt:       u.CreatedAt,
+			UpdatedAt:       u.UpdatedAt,
+		},
+		Matches:        matches,
+		Messages:       messages,
+		DirectMessages: directMessages,
+	}, nil
+}
+
+// AnonymizeUserData 탈퇴한 유저의 방 채팅, 1:1 메시지, 전적을 익명으로 바꾼다. (user.OnSoftDelete로 등록)
+func AnonymizeUserData(ctx context.Context, userID string) error {
+	return errors.Join(
+		chat.AnonymizeSender(ctx, userID),
+		dm.AnonymizeUser(ctx, userID),
+		match.AnonymizeResults(ctx, userID),
+	)
+}
+
+func exportError(userID string, err error) error {
+	log.Logger.Errorf("ExportUserData - Failed to export data for user %s: %v", userID, err)
+	return errors.New(resp.ErrorCodeUserDataExportFailed)
+}
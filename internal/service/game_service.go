@@ -2,12 +2,19 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/Ryeom/board-game/internal/ai"
+	chat "github.com/Ryeom/board-game/internal/domain/chat"
+	"github.com/Ryeom/board-game/internal/domain/match"
 	"github.com/Ryeom/board-game/internal/domain/room"
+	tilesets "github.com/Ryeom/board-game/internal/domain/tilepush"
 	"github.com/Ryeom/board-game/internal/game"
 	"github.com/Ryeom/board-game/internal/game/hanabi"
+	"github.com/Ryeom/board-game/internal/game/tilepush"
 	resp "github.com/Ryeom/board-game/internal/response"
 	"github.com/Ryeom/board-game/log"
 )
@@ -20,37 +27,102 @@ type Broadcaster interface {
 type GameService struct {
 	Manager     *game.Manager
 	Broadcaster Broadcaster
+	// Rooms 재대결을 거절한 플레이어를 방에서 내보낼 때 사용
+	Rooms *RoomService
+	// RematchTimeout 게임 종료 후 재대결 투표 시간 (0이면 투표를 열지 않음)
+	RematchTimeout time.Duration
+	// Timeline 힌트/플레이/버림/결과를 채팅 타임라인에 남긴다 (nil이면 남기지 않음)
+	Timeline Timeline
+	// SaveResult 끝난 게임을 전적으로 저장한다 (nil이면 저장하지 않음)
+	SaveResult func(ctx context.Context, result *match.Result) error
+
+	spectatorSnapshots sync.Map // roomID → 관전자에게 마지막으로 보낸 지연 상태 (json.RawMessage)
+	rematchTimers      sync.Map // roomID → 재대결 투표 마감 *time.Timer
 }
 
 func NewGameService(manager *game.Manager, broadcaster Broadcaster) *GameService {
 	return &GameService{
-		Manager:     manager,
-		Broadcaster: broadcaster,
+		Manager:        manager,
+		Broadcaster:    broadcaster,
+		RematchTimeout: DefaultRematchTimeout,
 	}
 }
 
 func (s *GameService) StartGame(ctx context.Context, roomID string, userID string) error {
-	r, ok := room.GetRoom(ctx, roomID)
-	if !ok {
-		return fmt.Errorf(resp.ErrorCodeRoomNotFound)
+	// 시작 조건 확인과 시작 상태 기록을 한 트랜잭션으로 처리 (확인 후 누가 나가거나 레디를 풀어도 그대로 시작되지 않도록)
+	r, _, err := room.Update(ctx, roomID, func(r *room.Room) (room.Mutation, error) {
+		if r.Host != userID {
+			return room.MutationSkip, fmt.Errorf(resp.ErrorCodeRoomNotHost)
+		}
+		rules, err := r.Rules()
+		if err != nil {
+			return room.MutationSkip, err
+		}
+		if len(r.Players) < rules.MinPlayers {
+			return room.MutationSkip, fmt.Errorf(resp.ErrorCodeGameNotEnoughPlayers)
+		}
+		if len(r.Players) > rules.MaxPlayers {
+			return room.MutationSkip, fmt.Errorf(resp.ErrorCodeGameTooManyPlayers)
+		}
+		// 설정이 없던 이전 방이나 스키마가 바뀐 경우를 위해 시작 시점에 다시 검증
+		if err := r.SetSettings(r.Settings); err != nil {
+			return room.MutationSkip, err
+		}
+		if r.IsGameStarted {
+			return room.MutationSkip, fmt.Errorf(resp.ErrorCodeGameAlreadyStarted)
+		}
+		if !r.AllPlayersReady() {
+			return room.MutationSkip, fmt.Errorf(resp.ErrorCodeGameNotAllPlayersReady)
+		}
+		if err := checkLaunchable(r.GameMode); err != nil {
+			return room.MutationSkip, err
+		}
+		r.IsGameStarted = true
+		r.ResetReady()
+		r.Rematch = nil
+		return room.MutationSave, nil
+	})
+	if err != nil {
+		return updateError("StartGame", roomID, err, resp.ErrorCodeGameInfoNotSaved)
 	}
+	s.stopRematchTimer(r.ID)
 
-	if r.Host != userID {
-		return fmt.Errorf(resp.ErrorCodeRoomNotHost)
+	if err := s.launchGame(ctx, r); err != nil {
+		return err
 	}
 
-	if len(r.Players) < 2 {
-		return fmt.Errorf(resp.ErrorCodeGameNotEnoughPlayers)
+	// Payload construction for 'game.started'
+	payload := map[string]any{
+		"roomId":     r.ID,
+		"gameMode":   r.GameMode,
+		"timestamp":  time.Now(),
+		"gameStatus": game.StatusPlaying,
 	}
+	// Notify the host specifically that game started (handled by caller or here?)
+	// The original code passed 'start:2' to host specifically.
+	// We can use Broadcaster to send uniqueness.
+	s.Broadcaster.SendToPlayer(userID, "game.started", payload, resp.SuccessCodeGameStart)
 
-	if r.IsGameStarted {
-		return fmt.Errorf(resp.ErrorCodeGameAlreadyStarted)
-	}
+	return nil
+}
 
-	if !r.AllPlayersReady() {
-		return fmt.Errorf(resp.ErrorCodeGameNotAllPlayersReady)
+// checkLaunchable 이 서버에서 바로 시작할 수 있는 모드인지 확인한다. (방을 시작 상태로 바꾸기 전에 호출)
+func checkLaunchable(mode game.Mode) error {
+	switch mode {
+	case game.ModeHanabi:
+		return nil
+	case game.ModeTilePush:
+		if _, err := tilesets.GetRandomTileSet(); err != nil {
+			return fmt.Errorf(resp.ErrorCodeGameTileSetUnavailable)
+		}
+		return nil
+	default:
+		return fmt.Errorf(resp.ErrorCodeRoomUnsupportedGameMode)
 	}
+}
 
+// launchGame 시작 상태로 기록된 방의 엔진을 만들어 시작하고 턴 타이머를 건다. (r.Players 순서가 턴 순서)
+func (s *GameService) launchGame(ctx context.Context, r *room.Room) error {
 	setGameStateFunc := func(state *hanabi.State) error {
 		return game.SaveGameState(ctx, r.GameMode, r.ID, state)
 	}
@@ -69,32 +141,87 @@ func (s *GameService) StartGame(ctx context.Context, roomID string, userID strin
 
 	switch r.GameMode {
 	case game.ModeHanabi:
-		hanabiEngine := hanabi.NewEngine(
+		var hanabiEngine *hanabi.Engine
+		hanabiEngine = hanabi.NewEngine(
+			r.Players,
+			func(eventName string, playerIDs []string, state any) {
+				defer s.notifySpectators(r.ID, eventName, state, hanabiEngine.CurrentState)
+				s.postHanabiTimeline(r.ID, eventName, state)
+				if v, ok := state.(*hanabi.State); ok && eventName == "game.end" {
+					s.saveHanabiResult(r, v)
+				}
+				switch v := state.(type) {
+				case *hanabi.State:
+					for _, pID := range playerIDs {
+						playerView := v.GetPlayerView(pID)
+						payload := map[string]any{
+							"state": playerView,
+						}
+						s.Broadcaster.SendToPlayer(pID, eventName, payload, resp.SuccessCodeGameSync)
+					}
+				case game.ActionResult:
+					// 액션 델타: 전체 상태 대신 변경분과 상태 버전만 전송
+					for _, pID := range playerIDs {
+						payload := map[string]any{
+							"version": v.StateVersion(),
+							"result":  v.ViewFor(pID),
+						}
+						s.Broadcaster.SendToPlayer(pID, eventName, payload, resp.SuccessCodeGameSync)
+					}
+				default:
+					log.Logger.Errorf("BroadcastFunc: Invalid state type %T, expected *hanabi.State or game.ActionResult", state)
+				}
+			},
+			setGameStateFunc,
+			getGameStateFunc,
+		)
+		hanabiEngine.TurnLimit = time.Duration(r.Settings.TurnSeconds) * time.Second
+		engine = hanabiEngine
+	case game.ModeTilePush:
+		tileSet, err := tilesets.GetRandomTileSet()
+		if err != nil {
+			log.Logger.Errorf("launchGame - No tile set for room %s: %v", r.ID, err)
+			return fmt.Errorf(resp.ErrorCodeGameTileSetUnavailable)
+		}
+		tilePushEngine := tilepush.NewEngine(
 			r.Players,
 			func(eventName string, playerIDs []string, state any) {
-				fullHanabiState, ok := state.(*hanabi.State)
+				v, ok := state.(*tilepush.State)
 				if !ok {
-					log.Logger.Errorf("BroadcastFunc: Invalid state type, expected *hanabi.State")
+					log.Logger.Errorf("BroadcastFunc: Invalid state type %T, expected *tilepush.State", state)
 					return
 				}
+				defer s.notifySpectators(r.ID, eventName, v, v)
 				for _, pID := range playerIDs {
-					playerView := fullHanabiState.GetPlayerView(pID)
 					payload := map[string]any{
-						"state": playerView,
+						"state": v.GetPlayerView(pID),
 					}
 					s.Broadcaster.SendToPlayer(pID, eventName, payload, resp.SuccessCodeGameSync)
 				}
 			},
-			setGameStateFunc,
-			getGameStateFunc,
+			func(state *tilepush.State) error {
+				return game.SaveGameState(ctx, r.GameMode, r.ID, state)
+			},
+			func() *tilepush.State {
+				var loadedState tilepush.State
+				if err := game.GetGameState(ctx, r.GameMode, r.ID, &loadedState); err != nil {
+					log.Logger.Warningf("StartGame - Could not load existing game state for room %s: %v. Creating new.", r.ID, err)
+					return nil
+				}
+				return &loadedState
+			},
 		)
-		engine = hanabiEngine
+		tilePushEngine.TileSet = tileSet
+		tilePushEngine.SetBoardDimensions(r.Settings.BoardRows, r.Settings.BoardColumns)
+		tilePushEngine.TurnLimit = time.Duration(r.Settings.TurnSeconds) * time.Second
+		engine = tilePushEngine
 	default:
 		return fmt.Errorf(resp.ErrorCodeRoomUnsupportedGameMode)
 	}
 
 	engine.StartGame()
 	s.Manager.AddEngine(r.ID, engine)
+	postTimeline(s.Timeline, r.ID, chat.RecordGame, resp.TimelineCodeGameStarted, map[string]string{"mode": string(r.GameMode)})
 
 	// 턴 타이머 생성 및 시작
 	if turnDuration := engine.GetTurnDuration(); turnDuration > 0 {
@@ -107,25 +234,6 @@ func (s *GameService) StartGame(ctx context.Context, roomID string, userID strin
 			"durationSecs": int(turnDuration.Seconds()),
 		}, resp.SuccessCodeGameTimerStarted)
 	}
-
-	r.IsGameStarted = true
-	r.ResetReady()
-	if err := r.Save(); err != nil {
-		return fmt.Errorf(resp.ErrorCodeGameInfoNotSaved)
-	}
-
-	// Payload construction for 'game.started'
-	payload := map[string]any{
-		"roomId":     r.ID,
-		"gameMode":   r.GameMode,
-		"timestamp":  time.Now(),
-		"gameStatus": game.StatusPlaying,
-	}
-	// Notify the host specifically that game started (handled by caller or here?)
-	// The original code passed 'start:2' to host specifically.
-	// We can use Broadcaster to send uniqueness.
-	s.Broadcaster.SendToPlayer(userID, "game.started", payload, resp.SuccessCodeGameStart)
-
 	return nil
 }
 
@@ -147,9 +255,56 @@ func (s *GameService) EndGame(ctx context.Context, roomID string, userID string)
 		"gameStatus": game.StatusDefault,
 	}
 	s.Broadcaster.BroadcastToRoom(r.ID, "game.ended", payload, resp.SuccessCodeGameSync)
+	postTimeline(s.Timeline, r.ID, chat.RecordGame, resp.TimelineCodeGameAborted, map[string]string{"user": playerName(userID)}, userSubject(userID))
+	s.openRematch(r)
 	return nil
 }
 
+// saveHanabiResult 하나비 종료 상태를 참가자 전적으로 저장한다.
+func (s *GameService) saveHanabiResult(r *room.Room, state *hanabi.State) {
+	if s.SaveResult == nil {
+		return
+	}
+	players := make([]match.Participant, 0, len(r.Players))
+	for _, pID := range r.Players {
+		players = append(players, match.Participant{UserID: pID, Name: playerName(pID)})
+	}
+	result := &match.Result{
+		RoomID:    r.ID,
+		GameMode:  r.GameMode,
+		Players:   players,
+		Score:     state.FinalScore,
+		EndReason: state.EndReason,
+		EndedAt:   time.Now(),
+	}
+	if err := s.SaveResult(context.Background(), result); err != nil {
+		log.Logger.Errorf("saveHanabiResult - Failed to save match result for room %s: %v", r.ID, err)
+	}
+}
+
+// postHanabiTimeline 엔진 브로드캐스트 중 기록할 만한 것(액션 결과, 게임 결과)을 타임라인에 남긴다.
+func (s *GameService) postHanabiTimeline(roomID string, eventName string, state any) {
+	if s.Timeline == nil {
+		return
+	}
+	var key string
+	var params map[string]string
+	var subjects []chat.Subject
+	switch v := state.(type) {
+	case *hanabi.ActionResult:
+		key, params, subjects = hanabiTimelineEntry(v, playerName)
+	case *hanabi.State:
+		if eventName != "game.end" {
+			return
+		}
+		key, params = hanabiEndedEntry(v)
+	}
+	if key == "" {
+		return
+	}
+	s.Timeline.Post(roomID, chat.RecordGame, key, params, subjects...)
+}
+
 func (s *GameService) ProcessAction(ctx context.Context, roomID string, userID string, actionData map[string]any) error {
 	engine, ok := s.Manager.GetEngine(roomID)
 	if !ok {
@@ -161,7 +316,14 @@ func (s *GameService) ProcessAction(ctx context.Context, roomID string, userID s
 		return fmt.Errorf(resp.ErrorCodeRoomNotFound)
 	}
 
+	actionData["playerId"] = userID
+	actionType, ok := actionData["actionType"].(string)
+	if !ok || actionType == "" {
+		return fmt.Errorf(resp.ErrorCodeRoomInvalidRequest)
+	}
+
 	var specificEngine game.Engine
+	var gameEvent any
 	switch r.GameMode {
 	case game.ModeHanabi:
 		hanabiEng, typeOk := engine.(*hanabi.Engine)
@@ -169,20 +331,18 @@ func (s *GameService) ProcessAction(ctx context.Context, roomID string, userID s
 			return fmt.Errorf(resp.ErrorCodeGameActionFailed)
 		}
 		specificEngine = hanabiEng
+		gameEvent = hanabi.Event{Type: actionType, Data: actionData}
+	case game.ModeTilePush:
+		tilePushEng, typeOk := engine.(*tilepush.Engine)
+		if !typeOk {
+			return fmt.Errorf(resp.ErrorCodeGameActionFailed)
+		}
+		specificEngine = tilePushEng
+		gameEvent = tilepush.Event{Type: actionType, Data: actionData}
 	default:
 		return fmt.Errorf(resp.ErrorCodeRoomUnsupportedGameMode)
 	}
 
-	actionData["playerId"] = userID
-	actionType, ok := actionData["actionType"].(string)
-	if !ok || actionType == "" {
-		return fmt.Errorf(resp.ErrorCodeRoomInvalidRequest)
-	}
-	gameEvent := hanabi.Event{
-		Type: actionType,
-		Data: actionData,
-	}
-
 	if err := specificEngine.HandleEvent(gameEvent); err != nil {
 		log.Logger.Errorf("ProcessAction - Engine error: %v", err)
 		return fmt.Errorf(resp.ErrorCodeGameActionFailed)
@@ -192,6 +352,7 @@ func (s *GameService) ProcessAction(ctx context.Context, roomID string, userID s
 		log.Logger.Infof("Game in room %s ended automatically.", roomID)
 		specificEngine.EndGame()
 		s.cleanupGame(ctx, r)
+		s.openRematch(r)
 		return nil
 	}
 
@@ -230,8 +391,26 @@ func (s *GameService) GetGameState(ctx context.Context, roomID string, userID st
 		if !typeOk {
 			return nil, "", fmt.Errorf(resp.ErrorCodeGameSyncFailed)
 		}
+		if r.HasSpectator(userID) {
+			return s.spectatorState(r, hanabiEng.CurrentState), r.GameMode, nil
+		}
+		if !r.HasPlayer(userID) {
+			return nil, "", fmt.Errorf(resp.ErrorCodeGamePlayerNotInRoom)
+		}
 		state := hanabiEng.CurrentState.GetPlayerView(userID)
 		return state, r.GameMode, nil
+	case game.ModeTilePush:
+		tilePushEng, typeOk := engine.(*tilepush.Engine)
+		if !typeOk || tilePushEng.CurrentState == nil {
+			return nil, "", fmt.Errorf(resp.ErrorCodeGameSyncFailed)
+		}
+		if r.HasSpectator(userID) {
+			return s.spectatorState(r, tilePushEng.CurrentState), r.GameMode, nil
+		}
+		if !r.HasPlayer(userID) {
+			return nil, "", fmt.Errorf(resp.ErrorCodeGamePlayerNotInRoom)
+		}
+		return tilePushEng.CurrentState.GetPlayerView(userID), r.GameMode, nil
 	default:
 		return nil, "", fmt.Errorf(resp.ErrorCodeRoomUnsupportedGameMode)
 	}
@@ -242,10 +421,15 @@ func (s *GameService) GetGameInfo(gameModeStr string) (game.Mode, map[string]any
 		return "", nil, fmt.Errorf(resp.ErrorCodeRoomInvalidRequest)
 	}
 	gameMode := game.Mode(gameModeStr)
+	rules, ok := game.LookupRules(gameMode)
+	if !ok {
+		return "", nil, fmt.Errorf(resp.ErrorCodeRoomUnsupportedGameMode)
+	}
 
+	var info map[string]any
 	switch gameMode {
 	case game.ModeHanabi:
-		info := map[string]any{
+		info = map[string]any{
 			"name":        "Hanabi",
 			"description": "하나비는 협력 카드 게임입니다. 플레이어들은 불꽃놀이를 완성하기 위해 카드 정보를 공유하며 색깔별로 1부터 5까지 순서대로 카드를 내야 합니다. 하지만 자신의 패는 볼 수 없습니다!",
 			"rulesSummary": []string{
@@ -265,22 +449,129 @@ func (s *GameService) GetGameInfo(gameModeStr string) (game.Mode, map[string]any
 				"hint": 8, "miss": 3,
 			},
 		}
-		return gameMode, info, nil
-	default:
-		return "", nil, fmt.Errorf(resp.ErrorCodeRoomUnsupportedGameMode)
+	case game.ModeTilePush:
+		info = map[string]any{
+			"name":        "Tile Push",
+			"description": "타일 푸시는 2인 대전 게임입니다. 덱에서 뽑은 타일을 보드의 칸에 밀어 넣어 자신의 목표 타일로 한 줄을 먼저 채우면 승리합니다.",
+			"rulesSummary": []string{
+				"각 플레이어는 서로 다른 목표 타일을 하나씩 받습니다.",
+				"턴마다 덱에서 타일을 뽑아 원하는 열과 행에 밀어 넣습니다. 아래로 밀려난 타일은 버려집니다.",
+				"밀어 넣은 타일과 밀려난 타일의 모양이 같으면 한 번 더 진행합니다.",
+				"목표 타일로 가로 또는 세로 한 줄을 채우면 승리합니다.",
+				"덱이 비거나 보드가 가득 차면 게임이 종료됩니다.",
+			},
+		}
 	}
+	// 방 생성/설정 폼을 그릴 수 있도록 인원 제한과 설정 스키마를 함께 내려준다.
+	info["minPlayers"] = rules.MinPlayers
+	info["maxPlayers"] = rules.MaxPlayers
+	info["settings"] = rules.Settings
+	return gameMode, info, nil
 }
 
 func (s *GameService) cleanupGame(ctx context.Context, r *room.Room) {
 	s.Manager.RemoveEngine(r.ID)
+	s.spectatorSnapshots.Delete(r.ID)
 	if err := game.DeleteGameState(ctx, r.GameMode, r.ID); err != nil {
 		log.Logger.Errorf("cleanupGame - Failed to delete game state: %v", err)
 	}
+	latest, _, err := room.Update(ctx, r.ID, func(latest *room.Room) (room.Mutation, error) {
+		latest.IsGameStarted = false
+		latest.ResetReady()
+		latest.Rematch = nil
+		if s.RematchTimeout > 0 && len(latest.Players) >= 2 {
+			latest.OpenRematch(time.Now().Add(s.RematchTimeout))
+			for _, pid := range latest.Players {
+				if ai.IsAIPlayer(pid) { // 봇은 항상 수락
+					latest.Rematch.Accepted[pid] = true
+				}
+			}
+		}
+		return room.MutationSave, nil
+	})
+	if err != nil {
+		log.Logger.Errorf("cleanupGame - Failed to save room state: %v", err)
+		return
+	}
 	r.IsGameStarted = false
 	r.ResetReady()
-	if err := r.Save(); err != nil {
-		log.Logger.Errorf("cleanupGame - Failed to save room state: %v", err)
+	r.Players = latest.Players
+	r.Rematch = latest.Rematch
+}
+
+// discardGame 방이 닫힐 때 이 서버에서 돌던 엔진, 타이머, 저장된 상태를 정리한다. (방 상태는 건드리지 않음)
+func (s *GameService) discardGame(ctx context.Context, roomID string, mode game.Mode) {
+	s.Manager.RemoveEngine(roomID)
+	s.spectatorSnapshots.Delete(roomID)
+	s.stopRematchTimer(roomID)
+	if err := game.DeleteGameState(ctx, mode, roomID); err != nil {
+		log.Logger.Warningf("discardGame - Failed to delete game state for room %s: %v", roomID, err)
+	}
+}
+
+// notifySpectators 관전자에게 게임 변경을 전달한다.
+// hidden: 손패를 가린 화면을 플레이어와 같은 이벤트로 바로 전송
+// delayed: 그 시점의 전체 상태를 SpectatorDelay 뒤에 game.spectate.sync로 전송 (실시간 정보 유출 방지)
+func (s *GameService) notifySpectators(roomID string, eventName string, update any, current game.SpectatorViewer) {
+	r, ok := room.GetRoom(context.Background(), roomID)
+	if !ok {
+		return
+	}
+
+	if r.SpectatorView == room.SpectatorViewDelayed {
+		snapshot, err := json.Marshal(current.SpectatorView(true))
+		if err != nil {
+			log.Logger.Errorf("notifySpectators - Failed to snapshot state for room %s: %v", roomID, err)
+			return
+		}
+		delay := r.SpectatorDelay()
+		time.AfterFunc(delay, func() {
+			if _, running := s.Manager.GetEngine(roomID); running {
+				s.spectatorSnapshots.Store(roomID, json.RawMessage(snapshot))
+			}
+			latest, ok := room.GetRoom(context.Background(), roomID)
+			if !ok {
+				return
+			}
+			payload := map[string]any{
+				"state":     json.RawMessage(snapshot),
+				"delaySecs": int(delay.Seconds()),
+				"spectator": true,
+			}
+			for _, sid := range latest.Spectators {
+				s.Broadcaster.SendToPlayer(sid, "game.spectate.sync", payload, resp.SuccessCodeGameSync)
+			}
+		})
+		return
+	}
+
+	if len(r.Spectators) == 0 {
+		return
+	}
+	viewer, ok := update.(game.SpectatorViewer)
+	if !ok {
+		return
+	}
+	payload := map[string]any{"spectator": true}
+	if result, isResult := update.(game.ActionResult); isResult {
+		payload["version"] = result.StateVersion()
+		payload["result"] = viewer.SpectatorView(false)
+	} else {
+		payload["state"] = viewer.SpectatorView(false)
+	}
+	for _, sid := range r.Spectators {
+		s.Broadcaster.SendToPlayer(sid, eventName, payload, resp.SuccessCodeGameSync)
+	}
+}
+
+// spectatorState game.sync를 요청한 관전자에게 줄 상태. delayed 모드는 마지막으로 보낸 지연 상태(없으면 가린 화면)를 준다.
+func (s *GameService) spectatorState(r *room.Room, current game.SpectatorViewer) any {
+	if r.SpectatorView == room.SpectatorViewDelayed {
+		if snapshot, ok := s.spectatorSnapshots.Load(r.ID); ok {
+			return snapshot
+		}
 	}
+	return current.SpectatorView(false)
 }
 
 // handleTimerExpired 턴 타이머 만료 시 자동 액션을 수행한다.
@@ -307,6 +598,7 @@ func (s *GameService) handleTimerExpired(roomID string) {
 		r, ok := room.GetRoom(ctx, roomID)
 		if ok {
 			s.cleanupGame(ctx, r)
+			s.openRematch(r)
 		}
 		return
 	}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	redisutil "github.com/Ryeom/board-game/infra/redis"
+	"github.com/Ryeom/board-game/internal/ai"
+	"github.com/Ryeom/board-game/internal/domain/room"
+	"github.com/Ryeom/board-game/internal/game"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/Ryeom/board-game/log"
+)
+
+// room.closed 사유
+const (
+	RoomClosedIdle      = "idle"      // 오랫동안 변화가 없는 대기 방
+	RoomClosedAbandoned = "abandoned" // 게임 중 모든 플레이어의 연결이 끊긴 채 유예 시간이 지남
+)
+
+// JanitorConfig 유휴 방/오래된 세션 정리 설정
+type JanitorConfig struct {
+	Interval        time.Duration // 정리 주기
+	RoomIdle        time.Duration // 접속 중인 멤버가 없는 대기 방을 닫는 기준 (마지막 변경 이후)
+	WaitingMaxIdle  time.Duration // 접속 중인 멤버가 있어도 대기 방을 닫는 기준 (0이면 닫지 않음)
+	DisconnectGrace time.Duration // 게임 중 모든 플레이어의 연결이 끊긴 뒤 게임을 끝내고 방을 닫기까지의 유예 시간
+}
+
+// DefaultJanitorConfig 설정 값이 없을 때 사용하는 기본값
+var DefaultJanitorConfig = JanitorConfig{
+	Interval:        time.Minute,
+	RoomIdle:        30 * time.Minute,
+	WaitingMaxIdle:  6 * time.Hour,
+	DisconnectGrace: 10 * time.Minute,
+}
+
+// SweepResult 한 번의 정리 결과
+type SweepResult struct {
+	ClosedRooms        int
+	PurgedRoomSessions int
+	PurgedGameStates   int
+}
+
+type Janitor struct {
+	Games       *GameService
+	Broadcaster Broadcaster
+	Config      JanitorConfig
+	// OnClosed 방이 닫혀 멤버를 내보낸 뒤 호출 (연결된 세션의 방 정보 갱신용)
+	OnClosed func(userID string)
+}
+
+func NewJanitor(games *GameService, broadcaster Broadcaster, config JanitorConfig) *Janitor {
+	if config.Interval <= 0 {
+		config.Interval = DefaultJanitorConfig.Interval
+	}
+	if config.RoomIdle <= 0 {
+		config.RoomIdle = DefaultJanitorConfig.RoomIdle
+	}
+	if config.DisconnectGrace <= 0 {
+		config.DisconnectGrace = DefaultJanitorConfig.DisconnectGrace
+	}
+	return &Janitor{
+		Games:       games,
+		Broadcaster: broadcaster,
+		Config:      config,
+	}
+}
+
+// Run ctx가 끝날 때까지 주기적으로 정리
+func (j *Janitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.Config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			res := j.SweepOnce(ctx, now)
+			if res.ClosedRooms > 0 || res.PurgedRoomSessions > 0 || res.PurgedGameStates > 0 {
+				log.Logger.Infof("Janitor - closed %d rooms, purged %d room session sets, %d game states",
+					res.ClosedRooms, res.PurgedRoomSessions, res.PurgedGameStates)
+			}
+		}
+	}
+}
+
+// SweepOnce 유휴/버려진 방을 닫고, 방이 없어진 room_sessions set과 게임 상태 키를 지운다.
+func (j *Janitor) SweepOnce(ctx context.Context, now time.Time) SweepResult {
+	var res SweepResult
+	rooms, err := room.AllRooms(ctx)
+	if err != nil {
+		log.Logger.Errorf("SweepOnce - Failed to load rooms: %v", err)
+		return res
+	}
+	for _, r := range rooms {
+		reason, stale := j.closeReason(r, now)
+		if stale && j.closeRoom(ctx, r, reason) {
+			res.ClosedRooms++
+		}
+	}
+	res.PurgedRoomSessions = j.purgeRoomSessions(ctx)
+	res.PurgedGameStates = j.purgeGameStates(ctx)
+	return res
+}
+
+// closeReason 방을 닫아야 하는지 판단한다.
+func (j *Janitor) closeReason(r *room.Room, now time.Time) (string, bool) {
+	idle := now.Sub(r.LastActivity())
+
+	if r.IsGameStarted {
+		// 사람 플레이어가 한 명이라도 접속해 있으면 진행 중. 모두 끊겼으면 마지막으로 끊긴 시각부터 유예 시간을 센다.
+		var lastDisconnect time.Time
+		for _, pid := range r.Players {
+			if ai.IsAIPlayer(pid) {
+				continue
+			}
+			session, err := user.GetSession(pid)
+			if err != nil || session == nil || session.RoomID != r.ID {
+				continue
+			}
+			if session.Status != "disconnected" {
+				return "", false
+			}
+			if session.DisconnectedAt.After(lastDisconnect) {
+				lastDisconnect = session.DisconnectedAt
+			}
+		}
+		if lastDisconnect.IsZero() {
+			lastDisconnect = r.LastActivity()
+		}
+		return RoomClosedAbandoned, now.Sub(lastDisconnect) >= j.Config.DisconnectGrace
+	}
+
+	if j.Config.WaitingMaxIdle > 0 && idle >= j.Config.WaitingMaxIdle {
+		return RoomClosedIdle, true
+	}
+	if idle < j.Config.RoomIdle {
+		return "", false
+	}
+	for _, uid := range append(append([]string{}, r.Players...), r.Spectators...) {
+		if ai.IsAIPlayer(uid) {
+			continue
+		}
+		if session, err := user.GetSession(uid); err == nil && session != nil && session.RoomID == r.ID && session.Status != "disconnected" {
+			return "", false
+		}
+	}
+	return RoomClosedIdle, true
+}
+
+// closeRoom 방을 삭제하고 남아 있는 멤버에게 room.closed를 보낸 뒤 세션의 방 정보를 비운다.
+// 확인한 뒤 방이 바뀌었으면(누가 들어오거나 게임이 시작됨) 닫지 않는다.
+func (j *Janitor) closeRoom(ctx context.Context, r *room.Room, reason string) bool {
+	seen := r.LastActivity()
+	_, mutation, err := room.Update(ctx, r.ID, func(latest *room.Room) (room.Mutation, error) {
+		if !latest.LastActivity().Equal(seen) || latest.IsGameStarted != r.IsGameStarted {
+			return room.MutationSkip, nil
+		}
+		return room.MutationDelete, nil
+	})
+	if err != nil || mutation != room.MutationDelete {
+		if err != nil {
+			log.Logger.Warningf("closeRoom - Failed to close room %s: %v", r.ID, err)
+		}
+		return false
+	}
+
+	if j.Games != nil {
+		j.Games.discardGame(ctx, r.ID, r.GameMode)
+	}
+
+	members := make(map[string]bool)
+	for _, uid := range r.Players {
+		members[uid] = true
+	}
+	for _, uid := range r.Spectators {
+		members[uid] = true
+	}
+	if ids, err := redisutil.GetSetMembers(redisutil.RedisTargetUser, user.RoomIndexKey(r.ID)); err == nil {
+		for _, uid := range ids {
+			members[uid] = true
+		}
+	}
+
+	payload := map[string]string{
+		"roomId": r.ID,
+		"reason": reason,
+	}
+	for uid := range members {
+		if ai.IsAIPlayer(uid) {
+			continue
+		}
+		j.Broadcaster.SendToPlayer(uid, "room.closed", payload, resp.SuccessCodeRoomClosed)
+		if session, err := user.GetSession(uid); err == nil && session != nil && session.RoomID == r.ID {
+			if session.Status == "disconnected" {
+				// 재접속을 기다리던 방이 없어졌으므로 세션도 정리
+				_ = user.DeleteUserSession(uid)
+			} else {
+				session.RoomID = ""
+				session.IsHost = false
+				_ = user.SaveUserSession(session)
+			}
+		}
+		if j.OnClosed != nil {
+			j.OnClosed(uid)
+		}
+	}
+	if err := redisutil.Delete(redisutil.RedisTargetUser, user.RoomIndexKey(r.ID)); err != nil {
+		log.Logger.Warningf("closeRoom - Failed to delete room %s sessions set: %v", r.ID, err)
+	}
+	log.Logger.Infof("Room %s closed by janitor (%s)", r.ID, reason)
+	return true
+}
+
+// purgeRoomSessions 방이 없어진 room_sessions set 삭제
+func (j *Janitor) purgeRoomSessions(ctx context.Context) int {
+	purged := 0
+	for _, key := range redisutil.ScanKeyList(redisutil.RedisTargetUser, user.RoomIndexPattern) {
+		roomID, ok := user.RoomIDFromIndexKey(key)
+		if !ok {
+			continue
+		}
+		exists, err := room.Exists(ctx, roomID)
+		if err != nil || exists {
+			continue
+		}
+		if err := redisutil.Delete(redisutil.RedisTargetUser, key); err != nil {
+			log.Logger.Warningf("purgeRoomSessions - Failed to delete %s: %v", key, err)
+			continue
+		}
+		purged++
+	}
+	return purged
+}
+
+// purgeGameStates 방이 없거나 게임 중이 아닌 방의 게임 상태 삭제
+func (j *Janitor) purgeGameStates(ctx context.Context) int {
+	purged := 0
+	for _, ref := range game.ScanGameStates() {
+		if r, ok := room.GetRoom(ctx, ref.RoomID); ok && r.IsGameStarted {
+			continue
+		}
+		if _, running := j.Games.Manager.GetEngine(ref.RoomID); running {
+			continue
+		}
+		if err := game.DeleteGameState(ctx, ref.Mode, ref.RoomID); err != nil {
+			continue
+		}
+		purged++
+	}
+	return purged
+}
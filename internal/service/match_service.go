@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Ryeom/board-game/internal/ai"
+	"github.com/Ryeom/board-game/internal/domain/match"
+	"github.com/Ryeom/board-game/internal/domain/room"
+	"github.com/Ryeom/board-game/internal/game"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/log"
+)
+
+// MatchConfig 빠른 대전 매처 설정
+type MatchConfig struct {
+	Interval      time.Duration // 매칭 주기
+	BackfillAfter time.Duration // 이 시간 이상 기다린 티켓은 모인 인원 + 봇으로 시작 (0이면 봇 채우기 안 함)
+	TicketTTL     time.Duration // 대기 티켓 유지 시간
+}
+
+// DefaultMatchConfig 설정 값이 없을 때 사용하는 기본값 (봇 채우기는 기본으로 끔)
+var DefaultMatchConfig = MatchConfig{
+	Interval:  time.Second,
+	TicketTTL: 10 * time.Minute,
+}
+
+// MatchFound match.found 페이로드
+type MatchFound struct {
+	RoomID   string    `json:"roomId"`
+	GameMode game.Mode `json:"gameMode"`
+	Host     string    `json:"host"`
+	Players  []string  `json:"players"`
+	Bots     []string  `json:"bots,omitempty"`
+}
+
+type MatchService struct {
+	Rooms       *RoomService
+	Broadcaster Broadcaster
+	Config      MatchConfig
+	// OnJoined 매처가 유저를 방에 넣은 뒤 호출 (연결된 세션의 방 정보 갱신용)
+	OnJoined func(userID string, roomID string, isHost bool)
+}
+
+func NewMatchService(rooms *RoomService, broadcaster Broadcaster, config MatchConfig) *MatchService {
+	if config.Interval <= 0 {
+		config.Interval = DefaultMatchConfig.Interval
+	}
+	if config.TicketTTL <= 0 {
+		config.TicketTTL = DefaultMatchConfig.TicketTTL
+	}
+	return &MatchService{
+		Rooms:       rooms,
+		Broadcaster: broadcaster,
+		Config:      config,
+	}
+}
+
+// Enqueue 빠른 대전 대기열에 등록
+func (s *MatchService) Enqueue(ctx context.Context, t *match.Ticket) error {
+	rules, ok := game.LookupRules(t.GameMode)
+	if !ok || !slices.Contains(match.QueueModes, t.GameMode) || !rules.AcceptsPlayers(t.PlayerCount) || t.Rating < 0 || t.RatingBand < 0 {
+		return fmt.Errorf(resp.ErrorCodeMatchInvalidRequest)
+	}
+	t.EnqueuedAt = time.Now()
+	if err := match.Enqueue(ctx, t, s.Config.TicketTTL); err != nil {
+		if err.Error() == resp.ErrorCodeMatchAlreadyQueued {
+			return err
+		}
+		log.Logger.Errorf("Enqueue - Failed to enqueue user %s: %v", t.UserID, err)
+		return fmt.Errorf(resp.ErrorCodeMatchQueueFailed)
+	}
+	log.Logger.Debugf("Enqueue - User %s queued for %s (%d players)", t.UserID, t.GameMode, t.PlayerCount)
+	return nil
+}
+
+// Cancel 대기 취소. 대기 중이 아니면 ErrorCodeMatchNotQueued.
+func (s *MatchService) Cancel(ctx context.Context, userID string) error {
+	removed, err := match.Cancel(ctx, userID)
+	if err != nil {
+		log.Logger.Errorf("Cancel - Failed to cancel queue for user %s: %v", userID, err)
+		return fmt.Errorf(resp.ErrorCodeMatchQueueFailed)
+	}
+	if !removed {
+		return fmt.Errorf(resp.ErrorCodeMatchNotQueued)
+	}
+	return nil
+}
+
+// Run ctx가 끝날 때까지 주기적으로 매칭
+func (s *MatchService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.Config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.MatchOnce(ctx, now)
+		}
+	}
+}
+
+// MatchOnce 모드별 대기열에서 그룹을 만들어 방을 생성하고, 만든 방 수를 반환
+func (s *MatchService) MatchOnce(ctx context.Context, now time.Time) int {
+	matched := 0
+	for _, mode := range match.QueueModes {
+		tickets, err := match.Pending(ctx, mode)
+		if err != nil {
+			log.Logger.Errorf("MatchOnce - Failed to load %s queue: %v", mode, err)
+			continue
+		}
+		for _, g := range match.FormGroups(tickets, now, s.Config.BackfillAfter) {
+			claimed, err := match.Claim(ctx, &g)
+			if err != nil {
+				log.Logger.Errorf("MatchOnce - Failed to claim group %v: %v", g.UserIDs(), err)
+				continue
+			}
+			if !claimed { // 그 사이 취소했거나 다른 서버가 가져감. 다음 주기에 다시 묶는다.
+				continue
+			}
+			if s.startMatch(ctx, &g) {
+				matched++
+			}
+		}
+	}
+	return matched
+}
+
+// startMatch 첫 티켓의 유저를 방장으로 방을 만들고 나머지와 봇을 참여시킨 뒤 match.found 전송
+func (s *MatchService) startMatch(ctx context.Context, g *match.Group) bool {
+	host := g.Tickets[0]
+	roomName := fmt.Sprintf("Quick Match (%s)", g.GameMode)
+	r, err := s.Rooms.CreateRoom(ctx, host.UserID, host.UserName, roomName, "", g.PlayerCount, g.GameMode, game.Settings{})
+	if err != nil {
+		log.Logger.Errorf("startMatch - Failed to create room for %v: %v", g.UserIDs(), err)
+		s.requeue(ctx, g.Tickets)
+		return false
+	}
+
+	var failed []*match.Ticket
+	for _, t := range g.Tickets[1:] {
+		if _, err := s.Rooms.JoinRoom(ctx, t.UserID, t.UserName, r.ID, ""); err != nil {
+			// 이미 대기열에서 빠진 티켓이므로 원래 대기 순서로 되돌린다. (그 사이 다른 유저가 자리를 차지한 경우 등)
+			log.Logger.Warningf("startMatch - User %s could not join matched room %s, requeueing: %v", t.UserID, r.ID, err)
+			failed = append(failed, t)
+		}
+	}
+
+	// 봇으로 채우지 않는 경우 방에 남은 그룹 인원이 최소 인원보다 적으면 시작할 수 없다.
+	minPlayers := 0
+	if rules, ok := game.LookupRules(g.GameMode); ok && s.Config.BackfillAfter <= 0 {
+		minPlayers = rules.MinPlayers
+	}
+
+	// 게임 모드 지정, 빈 자리는 봇으로 채움. 봇은 준비 완료 상태로 참여한다. (봇의 턴은 턴 타이머의 자동 액션으로 진행)
+	var (
+		joined []string
+		bots   []string
+	)
+	r, _, err = room.Update(ctx, r.ID, func(r *room.Room) (room.Mutation, error) {
+		joined = joined[:0]
+		for _, t := range g.Tickets {
+			if r.HasPlayer(t.UserID) { // 참여 직후 나간 유저는 제외
+				joined = append(joined, t.UserID)
+			}
+		}
+		if len(joined) < minPlayers {
+			return room.MutationSkip, nil
+		}
+		if r.ReadyPlayers == nil {
+			r.ReadyPlayers = make(map[string]bool)
+		}
+		bots = bots[:0]
+		for i := 1; len(bots) < g.Bots && len(r.Players) < r.MaxPlayers; i++ {
+			botID := ai.GenerateAIPlayerID(i)
+			if r.HasPlayer(botID) {
+				continue
+			}
+			r.Players = append(r.Players, botID)
+			r.ReadyPlayers[botID] = true
+			bots = append(bots, botID)
+		}
+		return room.MutationSave, nil
+	})
+	if err != nil {
+		log.Logger.Errorf("startMatch - Failed to finalize matched room: %v", err)
+		s.requeue(ctx, failed)
+		return false
+	}
+
+	if len(joined) < minPlayers {
+		// 그룹 전체를 방에서 내보내고 방장까지 다시 대기시킨다. 방은 마지막 인원이 나갈 때 삭제된다.
+		// (그 사이 들어온 다른 유저가 있으면 그 유저의 방으로 남음)
+		log.Logger.Warningf("startMatch - Only %d of %d players stayed in room %s, requeueing group %v", len(joined), minPlayers, r.ID, g.UserIDs())
+		for _, uid := range joined {
+			if _, _, err := s.Rooms.LeaveRoom(ctx, uid, r.ID); err != nil {
+				log.Logger.Errorf("startMatch - Failed to remove user %s from abandoned room %s: %v", uid, r.ID, err)
+			}
+		}
+		s.requeue(ctx, g.Tickets)
+		return false
+	}
+	s.requeue(ctx, failed)
+
+	found := MatchFound{RoomID: r.ID, GameMode: r.GameMode, Host: r.Host, Players: r.Players, Bots: bots}
+	for _, uid := range joined {
+		if s.OnJoined != nil {
+			s.OnJoined(uid, r.ID, uid == r.Host)
+		}
+		s.Broadcaster.SendToPlayer(uid, "match.found", found, resp.SuccessCodeMatchFound)
+	}
+	log.Logger.Infof("Match found: room %s (%s) players=%v bots=%d", r.ID, r.GameMode, joined, len(bots))
+	return true
+}
+
+// requeue 매칭된 방에 들어가지 못한 티켓을 원래 대기 순서대로 다시 등록.
+// 다시 등록하지 못하면 match.failed로 알려 클라이언트가 대기 상태에 머물지 않게 한다.
+func (s *MatchService) requeue(ctx context.Context, tickets []*match.Ticket) {
+	for _, t := range tickets {
+		err := match.Enqueue(ctx, t, s.Config.TicketTTL)
+		if err == nil || err.Error() == resp.ErrorCodeMatchAlreadyQueued { // 그 사이 직접 다시 대기한 경우
+			continue
+		}
+		log.Logger.Errorf("requeue - Failed to requeue user %s: %v", t.UserID, err)
+		s.Broadcaster.SendToPlayer(t.UserID, "match.failed", map[string]any{"gameMode": t.GameMode}, resp.ErrorCodeMatchQueueFailed)
+	}
+}
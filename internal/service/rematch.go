@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ryeom/board-game/internal/domain/room"
+	"github.com/Ryeom/board-game/internal/game"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/log"
+)
+
+// DefaultRematchTimeout 설정 값이 없을 때의 재대결 투표 시간
+const DefaultRematchTimeout = 30 * time.Second
+
+// RematchVoteResult game.rematch.vote 브로드캐스트 페이로드
+type RematchVoteResult struct {
+	RoomID   string          `json:"roomId"`
+	UserID   string          `json:"userId"`
+	Accept   bool            `json:"accept"`
+	Accepted map[string]bool `json:"accepted"`
+	Players  []string        `json:"players"`
+}
+
+// openRematch cleanupGame이 연 재대결 투표를 알리고 마감 타이머를 건다. 투표가 열리지 않았으면 아무것도 하지 않는다.
+func (s *GameService) openRematch(r *room.Room) {
+	if r.Rematch == nil {
+		return
+	}
+	deadline := r.Rematch.Deadline
+	timer := time.AfterFunc(time.Until(deadline), func() {
+		s.resolveRematch(context.Background(), r.ID, deadline)
+	})
+	if prev, loaded := s.rematchTimers.Swap(r.ID, timer); loaded {
+		prev.(*time.Timer).Stop()
+	}
+
+	s.Broadcaster.BroadcastToRoom(r.ID, "game.rematch", map[string]any{
+		"roomId":      r.ID,
+		"players":     r.Players,
+		"accepted":    r.Rematch.Accepted,
+		"deadline":    deadline,
+		"timeoutSecs": int(time.Until(deadline).Round(time.Second).Seconds()),
+		"seats":       r.RematchSeats,
+	}, resp.SuccessCodeGameRematch)
+
+	// 봇만 남아 있는 경우 등 이미 전원 수락한 상태일 수 있음
+	s.resolveRematch(context.Background(), r.ID, time.Time{})
+}
+
+// VoteRematch 재대결 수락/거절. 거절한 플레이어는 방에서 나가 로비로 돌아가고, 남은 플레이어가 모두 수락하면 바로 새 게임을 시작한다.
+func (s *GameService) VoteRematch(ctx context.Context, roomID string, userID string, accept bool) error {
+	r, _, err := room.Update(ctx, roomID, func(r *room.Room) (room.Mutation, error) {
+		if !accept {
+			// 거절은 투표가 열려 있는지만 확인하고 퇴장은 LeaveRoom에 맡긴다
+			if r.Rematch == nil || r.IsGameStarted {
+				return room.MutationSkip, errors.New(resp.ErrorCodeGameRematchNotOpen)
+			}
+			if !r.HasPlayer(userID) {
+				return room.MutationSkip, errors.New(resp.ErrorCodeGamePlayerNotInRoom)
+			}
+			return room.MutationSkip, nil
+		}
+		if err := r.AcceptRematch(userID); err != nil {
+			return room.MutationSkip, err
+		}
+		return room.MutationSave, nil
+	})
+	if err != nil {
+		return updateError("VoteRematch", roomID, err, resp.ErrorCodeGameActionFailed)
+	}
+
+	if !accept {
+		if s.Rooms == nil {
+			return fmt.Errorf(resp.ErrorCodeRoomLeaveFailed)
+		}
+		_, deleted, err := s.Rooms.LeaveRoom(ctx, userID, roomID)
+		if err != nil {
+			return err
+		}
+		if deleted {
+			s.stopRematchTimer(roomID)
+			return nil
+		}
+		if latest, ok := room.GetRoom(ctx, roomID); ok {
+			r = latest
+		}
+	}
+
+	result := RematchVoteResult{RoomID: roomID, UserID: userID, Accept: accept, Players: r.Players}
+	if r.Rematch != nil {
+		result.Accepted = r.Rematch.Accepted
+	}
+	s.Broadcaster.BroadcastToRoom(roomID, "game.rematch.vote", result, resp.SuccessCodeGameRematchVote)
+
+	s.resolveRematch(ctx, roomID, time.Time{})
+	return nil
+}
+
+// resolveRematch 전원이 수락했으면 좌석 순서를 적용해 새 게임을 시작하고, 마감되었거나 인원이 부족하면 투표를 닫는다.
+// deadline이 zero가 아니면 마감 타이머에서 호출된 것으로, 그 사이 새로 열린 투표는 건드리지 않는다.
+func (s *GameService) resolveRematch(ctx context.Context, roomID string, deadline time.Time) {
+	timedOut := !deadline.IsZero()
+	var started, closed bool
+	r, _, err := room.Update(ctx, roomID, func(r *room.Room) (room.Mutation, error) {
+		started, closed = false, false
+		if r.Rematch == nil || r.IsGameStarted {
+			return room.MutationSkip, nil
+		}
+		if timedOut && !r.Rematch.Deadline.Equal(deadline) {
+			return room.MutationSkip, nil
+		}
+		switch {
+		case r.RematchAccepted() && r.GameMode == game.ModeHanabi:
+			r.ApplyRematchSeats()
+			r.Rematch = nil
+			r.IsGameStarted = true
+			r.ResetReady()
+			started = true
+		case timedOut || len(r.Players) < 2:
+			r.CloseRematch()
+			closed = true
+		default:
+			return room.MutationSkip, nil
+		}
+		return room.MutationSave, nil
+	})
+	if err != nil {
+		log.Logger.Errorf("resolveRematch - Failed to resolve rematch for room %s: %v", roomID, err)
+		return
+	}
+	if !started && !closed {
+		return
+	}
+	s.stopRematchTimer(roomID)
+
+	if closed {
+		s.Broadcaster.BroadcastToRoom(roomID, "game.rematch.closed", map[string]any{
+			"roomId":       roomID,
+			"players":      r.Players,
+			"readyPlayers": r.ReadyPlayers,
+		}, resp.SuccessCodeGameRematchClose)
+		return
+	}
+
+	if err := s.launchGame(ctx, r); err != nil {
+		log.Logger.Errorf("resolveRematch - Failed to start rematch in room %s: %v", roomID, err)
+		return
+	}
+	log.Logger.Infof("Rematch started in room %s with players %v", roomID, r.Players)
+	s.Broadcaster.BroadcastToRoom(roomID, "game.started", map[string]any{
+		"roomId":     r.ID,
+		"gameMode":   r.GameMode,
+		"timestamp":  time.Now(),
+		"gameStatus": game.StatusPlaying,
+		"players":    r.Players,
+		"rematch":    true,
+	}, resp.SuccessCodeGameStart)
+}
+
+func (s *GameService) stopRematchTimer(roomID string) {
+	if timer, loaded := s.rematchTimers.LoadAndDelete(roomID); loaded {
+		timer.(*time.Timer).Stop()
+	}
+}
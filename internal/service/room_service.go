@@ -1,11 +1,16 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	redisutil "github.com/Ryeom/board-game/infra/redis"
+	"github.com/Ryeom/board-game/internal/ai"
+	chat "github.com/Ryeom/board-game/internal/domain/chat"
 	"github.com/Ryeom/board-game/internal/domain/room"
 	"github.com/Ryeom/board-game/internal/game"
 	resp "github.com/Ryeom/board-game/internal/response"
@@ -16,6 +21,8 @@ import (
 
 type RoomService struct {
 	Broadcaster Broadcaster
+	// Timeline 입장/퇴장/강퇴/방장 변경을 채팅 타임라인에 남긴다 (nil이면 남기지 않음)
+	Timeline Timeline
 }
 
 func NewRoomService(broadcaster Broadcaster) *RoomService {
@@ -24,12 +31,11 @@ func NewRoomService(broadcaster Broadcaster) *RoomService {
 	}
 }
 
-func (s *RoomService) CreateRoom(ctx context.Context, userID string, userName string, roomName string, password string, maxPlayers int) (*room.Room, error) {
+func (s *RoomService) CreateRoom(ctx context.Context, userID string, userName string, roomName string, password string, maxPlayers int, mode game.Mode, settings game.Settings) (*room.Room, error) {
 	roomID := "room:" + userID + ":" + fmt.Sprint(time.Now().UnixNano())
-	r, err := room.CreateRoom(ctx, roomID, userID, roomName, password, maxPlayers)
+	r, err := room.CreateRoom(ctx, roomID, userID, roomName, password, maxPlayers, mode, settings)
 	if err != nil {
-		log.Logger.Errorf("CreateRoom - Failed to create room: %v", err)
-		return nil, fmt.Errorf(resp.ErrorCodeRoomCreationFailed)
+		return nil, updateError("CreateRoom", roomID, err, resp.ErrorCodeRoomCreationFailed)
 	}
 
 	// 방 생성 시 방장은 자동으로 방에 참여하므로, Redis Set에 추가
@@ -57,22 +63,101 @@ func (s *RoomService) CreateRoom(ctx context.Context, userID string, userName st
 }
 
 func (s *RoomService) JoinRoom(ctx context.Context, userID string, userName string, roomID string, password string) (*room.Room, error) {
-	r, ok := room.GetRoom(ctx, roomID)
-	if !ok {
-		return nil, fmt.Errorf(resp.ErrorCodeRoomNotFound)
-	}
+	return s.joinRoom(ctx, userID, userName, roomID, func(r *room.Room) (bool, error) {
+		return r.Join(userID, password)
+	})
+}
 
-	// 방 참여 로직 (비밀번호 검증 및 인원 제한 포함)
-	joined, err := r.Join(ctx, userID, password)
+// JoinRoomByCode 초대 코드로 방에 참여. 초대받은 유저는 비밀번호 없이 입장하며, 입장하지 못하면 사용 횟수를 되돌린다.
+func (s *RoomService) JoinRoomByCode(ctx context.Context, userID string, userName string, code string) (*room.Room, error) {
+	inv, err := room.UseInvite(ctx, room.NormalizeInviteCode(code))
 	if err != nil {
-		log.Logger.Errorf("JoinRoom - User %s failed to join room %s: %v", userName,roomID, err)
+		return nil, updateError("JoinRoomByCode", code, err, resp.ErrorCodeRoomJoinFailed)
+	}
+	r, err := s.joinRoom(ctx, userID, userName, inv.RoomID, func(r *room.Room) (bool, error) {
+		return r.JoinInvited(userID)
+	})
+	if err != nil {
+		room.ReleaseInvite(ctx, inv.Code)
 		return nil, err
 	}
+	return r, nil
+}
+
+func (s *RoomService) joinRoom(ctx context.Context, userID string, userName string, roomID string, join func(r *room.Room) (bool, error)) (*room.Room, error) {
+	// 방 참여 로직 (비밀번호 검증 및 인원 제한 포함). 동시에 참여해도 인원 제한을 넘지 않도록 room.Update로 처리
+	joined := false
+	r, _, err := room.Update(ctx, roomID, func(r *room.Room) (room.Mutation, error) {
+		var err error
+		joined, err = join(r)
+		if err != nil || !joined {
+			return room.MutationSkip, err
+		}
+		return room.MutationSave, nil
+	})
+	if err != nil {
+		log.Logger.Errorf("JoinRoom - User %s failed to join room %s: %v", userName, roomID, err)
+		return nil, updateError("JoinRoom", roomID, err, resp.ErrorCodeRoomJoinFailed)
+	}
 	if !joined { // 이미 참여
 		log.Logger.Debugf("JoinRoom - User %s already joined room %s", userName, r.ID)
 		return nil, fmt.Errorf(resp.ErrorCodeRoomAlreadyJoined)
 	}
+	if err := s.enterRoom(userID, userName, r, "room.join", resp.SuccessCodeRoomJoin); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// SpectateRoom 관전자로 입장. 게임 진행 중에도 가능하며 방 브로드캐스트(채팅 제외)를 함께 받는다.
+func (s *RoomService) SpectateRoom(ctx context.Context, userID string, userName string, roomID string, password string) (*room.Room, error) {
+	added := false
+	r, _, err := room.Update(ctx, roomID, func(r *room.Room) (room.Mutation, error) {
+		var err error
+		added, err = r.Spectate(userID, password)
+		if err != nil || !added {
+			return room.MutationSkip, err
+		}
+		return room.MutationSave, nil
+	})
+	if err != nil {
+		log.Logger.Errorf("SpectateRoom - User %s failed to spectate room %s: %v", userName, roomID, err)
+		return nil, updateError("SpectateRoom", roomID, err, resp.ErrorCodeRoomJoinFailed)
+	}
+	if !added {
+		return nil, fmt.Errorf(resp.ErrorCodeRoomAlreadyJoined)
+	}
+	if err := s.enterRoom(userID, userName, r, "room.spectate", resp.SuccessCodeRoomSpectate); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
 
+// PromoteSpectator 관전자를 플레이어로 옮긴다. 방장은 누구든, 관전자는 자기 자신만 옮길 수 있다.
+func (s *RoomService) PromoteSpectator(ctx context.Context, requesterID string, roomID string, targetID string) (*room.Room, error) {
+	r, _, err := room.Update(ctx, roomID, func(r *room.Room) (room.Mutation, error) {
+		if r.Host != requesterID && requesterID != targetID {
+			return room.MutationSkip, fmt.Errorf(resp.ErrorCodeRoomNotHost)
+		}
+		if err := r.Promote(targetID); err != nil {
+			return room.MutationSkip, err
+		}
+		return room.MutationSave, nil
+	})
+	if err != nil {
+		return nil, updateError("PromoteSpectator", roomID, err, resp.ErrorCodeRoomUpdateFailed)
+	}
+
+	s.Broadcaster.BroadcastToRoom(r.ID, "room.promote", map[string]any{
+		"userId":  targetID,
+		"players": r.Players,
+	}, resp.SuccessCodeRoomPromote)
+	postTimeline(s.Timeline, r.ID, chat.RecordSystem, resp.TimelineCodePromoted, map[string]string{"user": playerName(targetID)}, userSubject(targetID))
+	return r, nil
+}
+
+// enterRoom 방에 들어온 유저(플레이어/관전자)의 세션과 방 세션 set을 갱신하고 입장을 알린다.
+func (s *RoomService) enterRoom(userID string, userName string, r *room.Room, eventName string, msgCode string) error {
 	// 세션의 RoomID 업데이트 및 저장
 	// We need to handle 'OldRoomID' logic (removing from old room set)
 	session, err := user.GetSession(userID)
@@ -81,7 +166,7 @@ func (s *RoomService) JoinRoom(ctx context.Context, userID string, userName stri
 		session.RoomID = r.ID
 		if saveErr := user.SaveUserSession(session); saveErr != nil {
 			log.Logger.Errorf("JoinRoom - Failed to save user session %s with new room ID %s: %v", userID, r.ID, saveErr)
-			return nil, fmt.Errorf(resp.ErrorCodeRoomJoinFailed)
+			return fmt.Errorf(resp.ErrorCodeRoomJoinFailed)
 		}
 		
 		// 이전 방이 있었다면 해당 방의 Redis Set에서 사용자 ID 제거
@@ -95,63 +180,54 @@ func (s *RoomService) JoinRoom(ctx context.Context, userID string, userName stri
 	// 새 방의 Redis Set에 사용자 ID 추가
 	if err := redisutil.AddSet(redisutil.RedisTargetUser, user.RoomIndexKey(r.ID), userID); err != nil {
 		log.Logger.Errorf("JoinRoom - Failed to add user %s to room %s sessions set: %v", userName, r.ID, err)
-		return nil, fmt.Errorf(resp.ErrorCodeRoomJoinFailed)
+		return fmt.Errorf(resp.ErrorCodeRoomJoinFailed)
 	}
 
 	// Broadcast Join Event
-	s.Broadcaster.BroadcastToRoom(r.ID, "room.join", map[string]string{
+	s.Broadcaster.BroadcastToRoom(r.ID, eventName, map[string]string{
 		"userId":   userID,
 		"userName": userName,
-	}, resp.SuccessCodeRoomJoin)
+	}, msgCode)
 
-	return r, nil
+	timelineCode := resp.TimelineCodeJoined
+	if eventName == "room.spectate" {
+		timelineCode = resp.TimelineCodeSpectating
+	}
+	postTimeline(s.Timeline, r.ID, chat.RecordSystem, timelineCode, map[string]string{"user": userName}, userSubject(userID))
+
+	return nil
 }
 
 func (s *RoomService) LeaveRoom(ctx context.Context, userID string, roomID string) (string, bool, error) {
-	r, ok := room.GetRoom(ctx, roomID)
-	if !ok {
-		return "", false, fmt.Errorf(resp.ErrorCodeRoomNotFound)
+	wasHost := false
+	r, mutation, err := room.Update(ctx, roomID, func(r *room.Room) (room.Mutation, error) {
+		wasHost = r.Host == userID
+		if r.RemoveSpectator(userID) {
+			return room.MutationSave, nil
+		}
+		if !r.Leave(userID) {
+			return room.MutationSkip, nil
+		}
+		if len(r.Players) == 0 {
+			return room.MutationDelete, nil
+		}
+		return room.MutationSave, nil
+	})
+	if err != nil {
+		return "", false, updateError("LeaveRoom", roomID, err, resp.ErrorCodeRoomLeaveFailed)
 	}
 
 	if err := redisutil.RemoveSetMembers(redisutil.RedisTargetUser, user.RoomIndexKey(r.ID), userID); err != nil {
 		log.Logger.Errorf("LeaveRoom - Failed to remove user %s from room %s sessions set: %v", userID, r.ID, err)
 	}
 
-	originalPlayers := r.Players
-	newPlayers := make([]string, 0, len(originalPlayers))
-	isHostLeaving := false
-	if r.Host == userID {
-		isHostLeaving = true
-	}
-
-	for _, pid := range originalPlayers {
-		if pid != userID {
-			newPlayers = append(newPlayers, pid)
-		}
-	}
-	r.Players = newPlayers
-	r.ResetReady()
-
-	roomDeleted := false
+	roomDeleted := mutation == room.MutationDelete
 	newHostID := r.Host
-
-	if len(r.Players) == 0 {
-		_ = room.DeleteRoom(ctx, r.ID)
+	if roomDeleted {
 		if err := redisutil.Delete(redisutil.RedisTargetUser, user.RoomIndexKey(r.ID)); err != nil {
 			log.Logger.Errorf("LeaveRoom - Failed to delete room %s sessions set after deletion: %v", r.ID, err)
 		}
 		log.Logger.Infof("Room %s deleted as no players left.", r.ID)
-		roomDeleted = true
-	} else {
-		if isHostLeaving {
-			r.Host = newPlayers[0]
-			newHostID = r.Host
-			log.Logger.Infof("Host of room %s changed from %s to %s", r.ID, userID, r.Host)
-		}
-		if err := r.Save(); err != nil {
-			log.Logger.Errorf("LeaveRoom - Failed to save room %s after player leaving: %v", r.ID, err)
-			return "", false, fmt.Errorf(resp.ErrorCodeRoomLeaveFailed)
-		}
 	}
 
 	// 유저의 RoomID 초기화
@@ -170,176 +246,353 @@ func (s *RoomService) LeaveRoom(ctx context.Context, userID string, roomID strin
 			"userName": userName,
 			"newHost":  newHostID,
 		}, resp.SuccessCodeRoomLeave)
+		if userName == "" {
+			userName = playerName(userID)
+		}
+		postTimeline(s.Timeline, r.ID, chat.RecordSystem, resp.TimelineCodeLeft, map[string]string{"user": userName}, userSubject(userID))
+		if wasHost && newHostID != userID {
+			postTimeline(s.Timeline, r.ID, chat.RecordSystem, resp.TimelineCodeHostChanged, map[string]string{"user": playerName(newHostID)}, userSubject(newHostID))
+		}
 	}
 
 	return newHostID, roomDeleted, nil
 }
 
 func (s *RoomService) UpdateRoom(ctx context.Context, userID string, roomID string, updates map[string]any) (*room.Room, bool, error) {
-	r, ok := room.GetRoom(ctx, roomID)
-	if !ok {
-		return nil, false, fmt.Errorf(resp.ErrorCodeRoomNotFound)
+	// 해시는 솔트가 매번 달라 재시도 때마다 계산할 필요가 없으므로 트랜잭션 밖에서 한 번만 계산
+	var (
+		newPassword    string
+		passwordUpdate bool
+	)
+	if passRaw, exists := updates["password"]; exists {
+		if password, ok := passRaw.(string); ok {
+			passwordUpdate = true
+			if password != "" {
+				hashedPassword, err := util.HashPassword(password)
+				if err != nil {
+					return nil, false, fmt.Errorf(resp.ErrorCodeRoomPasswordHashingFailed)
+				}
+				newPassword = hashedPassword
+			}
+		}
 	}
 
-	if r.Host != userID {
-		return nil, false, fmt.Errorf(resp.ErrorCodeRoomNotHost)
+	var settingsUpdate []byte
+	if raw, exists := updates["settings"]; exists {
+		fields, ok := raw.(map[string]any)
+		if !ok {
+			return nil, false, fmt.Errorf(resp.ErrorCodeRoomInvalidSettings)
+		}
+		settingsUpdate, _ = json.Marshal(fields)
 	}
 
 	updated := false
+	r, _, err := room.Update(ctx, roomID, func(r *room.Room) (room.Mutation, error) {
+		updated = false
+		if r.Host != userID {
+			return room.MutationSkip, fmt.Errorf(resp.ErrorCodeRoomNotHost)
+		}
 
-	if nameRaw, exists := updates["roomName"]; exists {
-		if roomName, ok := nameRaw.(string); ok && r.RoomName != roomName {
-			r.RoomName = roomName
-			updated = true
+		if nameRaw, exists := updates["roomName"]; exists {
+			if roomName, ok := nameRaw.(string); ok && r.RoomName != roomName {
+				r.RoomName = roomName
+				updated = true
+			}
 		}
-	}
-	if gmRaw, exists := updates["gameMode"]; exists {
-		if gmStr, ok := gmRaw.(string); ok && game.Mode(gmStr) != r.GameMode {
-			r.GameMode = game.Mode(gmStr)
-			updated = true
+		if gmRaw, exists := updates["gameMode"]; exists {
+			if gmStr, ok := gmRaw.(string); ok && game.Mode(gmStr) != r.GameMode {
+				if r.IsGameStarted {
+					return room.MutationSkip, fmt.Errorf(resp.ErrorCodeGameAlreadyStarted)
+				}
+				if err := r.SetGameMode(game.Mode(gmStr)); err != nil {
+					return room.MutationSkip, err
+				}
+				updated = true
+			}
 		}
-	}
-	if passRaw, exists := updates["password"]; exists {
-		if password, ok := passRaw.(string); ok {
-			if password == "" {
-				if r.Password != "" {
+		if passwordUpdate {
+			if newPassword == "" {
+				if r.Password != "" || r.HasPassword {
 					r.Password = ""
+					r.HasPassword = false
 					updated = true
 				}
 			} else {
-				hashedPassword, err := util.HashPassword(password)
-				if err != nil {
-					return nil, false, fmt.Errorf(resp.ErrorCodeRoomPasswordHashingFailed)
-				}
-				if r.Password != hashedPassword {
-					r.Password = hashedPassword
-					updated = true
+				r.Password = newPassword
+				r.HasPassword = true
+				updated = true
+			}
+		}
+		if raw, exists := updates["maxPlayers"]; exists {
+			maxPlayers, ok := intValue(raw)
+			if !ok {
+				return room.MutationSkip, fmt.Errorf(resp.ErrorCodeRoomInvalidRequest)
+			}
+			if maxPlayers != r.MaxPlayers {
+				if err := r.SetMaxPlayers(maxPlayers); err != nil {
+					return room.MutationSkip, err
 				}
+				updated = true
 			}
 		}
-	}
-	if maxPlayersRaw, exists := updates["maxPlayers"]; exists {
-		// Handle float64/int logic inside service or assume it's passed as correct type?
-		// JSON unmarshal usually gives float64 for numbers.
-		var maxPlayers int
-		valid := false
-		if mpFloat, ok := maxPlayersRaw.(float64); ok {
-			maxPlayers = int(mpFloat)
-			valid = true
-		} else if mpInt, ok := maxPlayersRaw.(int); ok {
-			maxPlayers = mpInt
-			valid = true
+		if settingsUpdate != nil {
+			// 보낸 항목만 덮어쓰고 나머지는 현재 설정(모드를 바꿨으면 새 모드 기본값)을 유지
+			settings := r.Settings
+			dec := json.NewDecoder(bytes.NewReader(settingsUpdate))
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(&settings); err != nil {
+				return room.MutationSkip, fmt.Errorf(resp.ErrorCodeRoomInvalidSettings)
+			}
+			if settings != r.Settings {
+				if r.IsGameStarted {
+					return room.MutationSkip, fmt.Errorf(resp.ErrorCodeGameAlreadyStarted)
+				}
+				if err := r.SetSettings(settings); err != nil {
+					return room.MutationSkip, err
+				}
+				updated = true
+			}
 		}
 
-		if valid {
-			if maxPlayers < 2 || maxPlayers > 6 || maxPlayers < len(r.Players) {
-				return nil, false, fmt.Errorf(resp.ErrorCodeRoomInvalidRequest)
+		if raw, exists := updates["maxSpectators"]; exists {
+			maxSpectators, ok := intValue(raw)
+			if !ok || maxSpectators < 0 || maxSpectators > room.MaxSpectatorsLimit || maxSpectators < len(r.Spectators) {
+				return room.MutationSkip, fmt.Errorf(resp.ErrorCodeRoomInvalidRequest)
 			}
-			if maxPlayers != r.MaxPlayers {
-				r.MaxPlayers = maxPlayers
+			if maxSpectators != r.MaxSpectators {
+				r.MaxSpectators = maxSpectators
+				updated = true
+			}
+		}
+		if raw, exists := updates["spectatorView"]; exists {
+			view, ok := raw.(string)
+			if !ok || !room.ValidSpectatorView(view) {
+				return room.MutationSkip, fmt.Errorf(resp.ErrorCodeRoomInvalidRequest)
+			}
+			if view != r.SpectatorView {
+				// 게임 중에 바꾸면 이미 본 화면과 섞이므로 다음 게임부터 적용되도록 시작 전에만 허용
+				if r.IsGameStarted {
+					return room.MutationSkip, fmt.Errorf(resp.ErrorCodeGameAlreadyStarted)
+				}
+				r.SpectatorView = view
+				updated = true
+			}
+		}
+		if raw, exists := updates["spectatorDelaySecs"]; exists {
+			delay, ok := intValue(raw)
+			if !ok || delay < 1 || delay > room.MaxSpectatorDelaySecs {
+				return room.MutationSkip, fmt.Errorf(resp.ErrorCodeRoomInvalidRequest)
+			}
+			if delay != r.SpectatorDelaySecs {
+				r.SpectatorDelaySecs = delay
+				updated = true
+			}
+		}
+		if raw, exists := updates["locked"]; exists {
+			locked, ok := raw.(bool)
+			if !ok {
+				return room.MutationSkip, fmt.Errorf(resp.ErrorCodeRoomInvalidRequest)
+			}
+			if locked != r.Locked {
+				r.Locked = locked
+				updated = true
+			}
+		}
+		if raw, exists := updates["rematchSeats"]; exists {
+			seats, ok := raw.(string)
+			if !ok || !room.ValidRematchSeats(seats) {
+				return room.MutationSkip, fmt.Errorf(resp.ErrorCodeRoomInvalidRequest)
+			}
+			if seats != r.RematchSeats {
+				r.RematchSeats = seats
 				updated = true
 			}
 		}
-	}
 
+		if !updated {
+			return room.MutationSkip, nil
+		}
+		return room.MutationSave, nil
+	})
+	if err != nil {
+		return nil, false, updateError("UpdateRoom", roomID, err, resp.ErrorCodeRoomUpdateFailed)
+	}
 	if !updated {
 		return nil, false, nil // No error, not updated
 	}
 
-	if err := r.Save(); err != nil {
-		log.Logger.Errorf("UpdateRoom - Failed to update room %s: %v", r.ID, err)
-		return nil, false, fmt.Errorf(resp.ErrorCodeRoomUpdateFailed)
-	}
-
 	s.Broadcaster.BroadcastToRoom(r.ID, "room.update", r, resp.SuccessCodeRoomUpdate)
 
 	return r, true, nil
 }
 
 func (s *RoomService) KickUser(ctx context.Context, hostID string, roomID string, targetID string) (string, bool, error) {
-	r, ok := room.GetRoom(ctx, roomID)
-	if !ok {
-		return "", false, fmt.Errorf(resp.ErrorCodeRoomNotFound)
-	}
-
-	if r.Host != hostID {
-		return "", false, fmt.Errorf(resp.ErrorCodeRoomNotHost)
-	}
-
 	targetSession, err := user.GetSession(targetID)
 	if err != nil || targetSession == nil {
 		return "", false, fmt.Errorf(resp.ErrorCodeUserNotFound)
 	}
-	if targetSession.RoomID != r.ID {
+	if targetSession.RoomID != roomID {
 		return "", false, fmt.Errorf(resp.ErrorCodeRoomUserNotInRoom)
 	}
 
-	kicked := false
-	newPlayers := make([]string, 0, len(r.Players))
-	for _, pid := range r.Players {
-		if pid == targetID {
-			kicked = true
-			continue
+	r, mutation, err := room.Update(ctx, roomID, func(r *room.Room) (room.Mutation, error) {
+		if r.Host != hostID {
+			return room.MutationSkip, fmt.Errorf(resp.ErrorCodeRoomNotHost)
 		}
-		newPlayers = append(newPlayers, pid)
-	}
-	if !kicked {
-		return "", false, fmt.Errorf(resp.ErrorCodeRoomUserNotInRoom)
+		if r.RemoveSpectator(targetID) {
+			return room.MutationSave, nil
+		}
+		if !r.Leave(targetID) {
+			return room.MutationSkip, fmt.Errorf(resp.ErrorCodeRoomUserNotInRoom)
+		}
+		if len(r.Players) == 0 {
+			return room.MutationDelete, nil
+		}
+		return room.MutationSave, nil
+	})
+	if err != nil {
+		return "", false, updateError("KickUser", roomID, err, resp.ErrorCodeRoomKickFailed)
 	}
 
-	r.Players = newPlayers
-	r.ResetReady()
-	roomDeleted := false
+	roomDeleted := mutation == room.MutationDelete
 	newHostID := r.Host
-
-	if len(r.Players) == 0 {
-		_ = room.DeleteRoom(ctx, r.ID)
+	if roomDeleted {
 		_ = redisutil.Delete(redisutil.RedisTargetUser, user.RoomIndexKey(r.ID))
-		roomDeleted = true
-	} else {
-		if r.Host == targetID {
-			r.Host = newPlayers[0]
-			newHostID = r.Host
-		}
-		if err := r.Save(); err != nil {
-			return "", false, fmt.Errorf(resp.ErrorCodeRoomKickFailed)
-		}
 	}
 
-	targetSession.RoomID = ""
-	_ = user.SaveUserSession(targetSession)
+	s.removedFromRoom(r, targetID, targetSession, false)
+	return newHostID, roomDeleted, nil
+}
+
+// removedFromRoom 강퇴/차단된 유저의 세션을 정리하고, 방에는 user.kicked를 알리며 본인에게도 직접 보내 로비로 돌아가게 한다.
+func (s *RoomService) removedFromRoom(r *room.Room, targetID string, targetSession *user.Session, banned bool) {
+	userName := ""
+	if targetSession != nil {
+		userName = targetSession.Name
+		targetSession.RoomID = ""
+		targetSession.IsHost = false
+		_ = user.SaveUserSession(targetSession)
+	}
 	_ = redisutil.RemoveSetMembers(redisutil.RedisTargetUser, user.RoomIndexKey(r.ID), targetID)
 
-	s.Broadcaster.BroadcastToRoom(r.ID, "user.kicked", map[string]string{
+	payload := map[string]any{
+		"roomId":   r.ID,
 		"userId":   targetID,
-		"userName": targetSession.Name,
-		"newHost":  newHostID,
-	}, resp.SuccessCodeRoomKick)
+		"userName": userName,
+		"newHost":  r.Host,
+		"banned":   banned,
+	}
+	s.Broadcaster.BroadcastToRoom(r.ID, "user.kicked", payload, resp.SuccessCodeRoomKick)
+	s.Broadcaster.SendToPlayer(targetID, "user.kicked", payload, resp.SuccessCodeRoomKick)
 
-	return newHostID, roomDeleted, nil
+	if userName == "" {
+		userName = playerName(targetID)
+	}
+	timelineCode := resp.TimelineCodeKicked
+	if banned {
+		timelineCode = resp.TimelineCodeBanned
+	}
+	postTimeline(s.Timeline, r.ID, chat.RecordSystem, timelineCode, map[string]string{"user": userName}, userSubject(targetID))
 }
 
-func (s *RoomService) SetPlayerReady(ctx context.Context, userID string, roomID string) (bool, map[string]bool, error) {
-	r, ok := room.GetRoom(ctx, roomID)
-	if !ok {
-		return false, nil, fmt.Errorf(resp.ErrorCodeRoomNotFound)
+// BanUser 방장이 유저를 차단한다. 방에 있으면 강퇴와 같이 내보내고, 없으면 목록에만 올려 이후 참여/관전을 막는다.
+func (s *RoomService) BanUser(ctx context.Context, hostID string, roomID string, targetID string) (*room.Room, error) {
+	removed := false
+	r, _, err := room.Update(ctx, roomID, func(r *room.Room) (room.Mutation, error) {
+		if r.Host != hostID {
+			return room.MutationSkip, fmt.Errorf(resp.ErrorCodeRoomNotHost)
+		}
+		var err error
+		removed, err = r.Ban(targetID)
+		if err != nil {
+			return room.MutationSkip, err
+		}
+		return room.MutationSave, nil
+	})
+	if err != nil {
+		return nil, updateError("BanUser", roomID, err, resp.ErrorCodeRoomKickFailed)
 	}
 
-	// 방에 속한 플레이어인지 확인
-	inRoom := false
-	for _, pid := range r.Players {
-		if pid == userID {
-			inRoom = true
-			break
+	if removed {
+		targetSession, err := user.GetSession(targetID)
+		if err != nil {
+			targetSession = nil
 		}
+		s.removedFromRoom(r, targetID, targetSession, true)
 	}
-	if !inRoom {
-		return false, nil, fmt.Errorf(resp.ErrorCodeRoomUserNotInRoom)
+	s.Broadcaster.BroadcastToRoom(r.ID, "room.ban", map[string]any{
+		"userId": targetID,
+		"banned": r.Banned,
+	}, resp.SuccessCodeRoomBan)
+	return r, nil
+}
+
+// UnbanUser 차단 해제
+func (s *RoomService) UnbanUser(ctx context.Context, hostID string, roomID string, targetID string) (*room.Room, error) {
+	r, _, err := room.Update(ctx, roomID, func(r *room.Room) (room.Mutation, error) {
+		if r.Host != hostID {
+			return room.MutationSkip, fmt.Errorf(resp.ErrorCodeRoomNotHost)
+		}
+		if err := r.Unban(targetID); err != nil {
+			return room.MutationSkip, err
+		}
+		return room.MutationSave, nil
+	})
+	if err != nil {
+		return nil, updateError("UnbanUser", roomID, err, resp.ErrorCodeRoomUpdateFailed)
 	}
 
-	isReady := r.ToggleReady(userID)
-	if err := r.Save(); err != nil {
-		return false, nil, fmt.Errorf(resp.ErrorCodeRoomUpdateFailed)
+	s.Broadcaster.BroadcastToRoom(r.ID, "room.unban", map[string]any{
+		"userId": targetID,
+		"banned": r.Banned,
+	}, resp.SuccessCodeRoomUnban)
+	return r, nil
+}
+
+// TransferHost 방장 권한을 다른 플레이어에게 넘긴다.
+func (s *RoomService) TransferHost(ctx context.Context, hostID string, roomID string, targetID string) (*room.Room, error) {
+	r, _, err := room.Update(ctx, roomID, func(r *room.Room) (room.Mutation, error) {
+		if r.Host != hostID {
+			return room.MutationSkip, fmt.Errorf(resp.ErrorCodeRoomNotHost)
+		}
+		if ai.IsAIPlayer(targetID) {
+			return room.MutationSkip, fmt.Errorf(resp.ErrorCodeRoomInvalidRequest)
+		}
+		if err := r.TransferHost(targetID); err != nil {
+			return room.MutationSkip, err
+		}
+		return room.MutationSave, nil
+	})
+	if err != nil {
+		return nil, updateError("TransferHost", roomID, err, resp.ErrorCodeRoomUpdateFailed)
+	}
+
+	for _, uid := range []string{hostID, targetID} {
+		if session, err := user.GetSession(uid); err == nil && session != nil {
+			session.IsHost = uid == r.Host
+			_ = user.SaveUserSession(session)
+		}
+	}
+	s.Broadcaster.BroadcastToRoom(r.ID, "room.transferHost", map[string]string{
+		"oldHost": hostID,
+		"newHost": r.Host,
+	}, resp.SuccessCodeRoomTransferHost)
+	postTimeline(s.Timeline, r.ID, chat.RecordSystem, resp.TimelineCodeHostChanged, map[string]string{"user": playerName(r.Host)}, userSubject(r.Host))
+	return r, nil
+}
+
+func (s *RoomService) SetPlayerReady(ctx context.Context, userID string, roomID string) (bool, map[string]bool, error) {
+	isReady := false
+	r, _, err := room.Update(ctx, roomID, func(r *room.Room) (room.Mutation, error) {
+		// 방에 속한 플레이어인지 확인 (동시에 나간 플레이어가 레디로 다시 기록되지 않도록 최신 상태에서 확인)
+		if !r.HasPlayer(userID) {
+			return room.MutationSkip, fmt.Errorf(resp.ErrorCodeRoomUserNotInRoom)
+		}
+		isReady = r.ToggleReady(userID)
+		return room.MutationSave, nil
+	})
+	if err != nil {
+		return false, nil, updateError("SetPlayerReady", roomID, err, resp.ErrorCodeRoomUpdateFailed)
 	}
 
 	s.Broadcaster.BroadcastToRoom(r.ID, "room.ready", map[string]any{
@@ -352,7 +605,89 @@ func (s *RoomService) SetPlayerReady(ctx context.Context, userID string, roomID
 	return isReady, r.ReadyPlayers, nil
 }
 
-func (s *RoomService) GetRoomList(ctx context.Context) ([]*room.Room, error) {
-	// Simple wrapper, but allows for caching/processing later
-	return room.ListRooms(ctx), nil
+// CreateInvite 방장이 초대 코드를 발급. maxUses가 0이면 만료 전까지 여러 번 사용 가능.
+func (s *RoomService) CreateInvite(ctx context.Context, hostID string, roomID string, ttl time.Duration, maxUses int) (*room.Invite, error) {
+	r, ok := room.GetRoom(ctx, roomID)
+	if !ok {
+		return nil, fmt.Errorf(resp.ErrorCodeRoomNotFound)
+	}
+	if r.Host != hostID {
+		return nil, fmt.Errorf(resp.ErrorCodeRoomNotHost)
+	}
+	inv, err := room.CreateInvite(ctx, r.ID, hostID, ttl, maxUses)
+	if err != nil {
+		log.Logger.Errorf("CreateInvite - Failed to create invite for room %s: %v", r.ID, err)
+		return nil, fmt.Errorf(resp.ErrorCodeInviteCreateFailed)
+	}
+	return inv, nil
+}
+
+// InvitePreview 초대 코드로 입장하기 전에 보여줄 방 정보
+type InvitePreview struct {
+	Code          string    `json:"code"`
+	RoomID        string    `json:"roomId"`
+	RoomName      string    `json:"roomName"`
+	Host          string    `json:"host"`
+	GameMode      game.Mode `json:"gameMode"`
+	PlayerCount   int       `json:"playerCount"`
+	MaxPlayers    int       `json:"maxPlayers"`
+	IsGameStarted bool      `json:"isGameStarted"`
+	MaxUses       int       `json:"maxUses"` // 0이면 제한 없음
+	Uses          int       `json:"uses"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+}
+
+// PreviewInvite 초대 코드의 방 정보를 조회 (사용 횟수는 차감하지 않음)
+func (s *RoomService) PreviewInvite(ctx context.Context, code string) (*InvitePreview, error) {
+	inv, err := room.GetInvite(ctx, room.NormalizeInviteCode(code))
+	if err != nil {
+		return nil, updateError("PreviewInvite", code, err, resp.ErrorCodeInviteNotFound)
+	}
+	r, ok := room.GetRoom(ctx, inv.RoomID)
+	if !ok {
+		return nil, fmt.Errorf(resp.ErrorCodeInviteNotFound)
+	}
+	return &InvitePreview{
+		Code:          inv.Code,
+		RoomID:        r.ID,
+		RoomName:      r.RoomName,
+		Host:          r.Host,
+		GameMode:      r.GameMode,
+		PlayerCount:   len(r.Players),
+		MaxPlayers:    r.MaxPlayers,
+		IsGameStarted: r.IsGameStarted,
+		MaxUses:       inv.MaxUses,
+		Uses:          inv.Uses,
+		ExpiresAt:     inv.ExpiresAt,
+	}, nil
+}
+
+// QueryRoomList 필터/정렬/커서 조건으로 방 목록 한 페이지 조회 (room.list, GET /board-game/api/rooms 공용)
+func (s *RoomService) QueryRoomList(ctx context.Context, q room.ListQuery) ([]*room.Room, string, error) {
+	rooms, nextCursor, err := room.QueryRooms(ctx, q)
+	if err != nil {
+		log.Logger.Warningf("QueryRoomList - Invalid query %+v: %v", q, err)
+		return nil, "", err
+	}
+	return rooms, nextCursor, nil
+}
+
+// intValue JSON 숫자(float64) 또는 int를 정수로 변환
+func intValue(raw any) (int, bool) {
+	switch v := raw.(type) {
+	case float64:
+		return int(v), v == float64(int(v))
+	case int:
+		return v, true
+	}
+	return 0, false
+}
+
+// updateError room.Update 실패를 응답 코드로 변환. 정의된 코드(방 없음, 권한, 동시 변경 충돌 등)는 그대로 두고 Redis 오류 등은 fallback으로 바꾼다.
+func updateError(op string, roomID string, err error, fallback string) error {
+	if _, ok := resp.GetDefineCode(err.Error(), util.DefaultLanguage); ok {
+		return err
+	}
+	log.Logger.Errorf("%s - Failed to update room %s: %v", op, roomID, err)
+	return errors.New(fallback)
 }
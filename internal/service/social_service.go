@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Ryeom/board-game/internal/domain/dm"
+	"github.com/Ryeom/board-game/internal/domain/friend"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/Ryeom/board-game/internal/util"
+	"github.com/Ryeom/board-game/log"
+)
+
+// FriendEntry 친구/요청/차단 목록 항목. 접속 상태와 안 읽은 메시지 수는 친구 목록에만 채운다.
+type FriendEntry struct {
+	UserID       string        `json:"userId"`
+	Nickname     string        `json:"nickname"`
+	ProfileImage *string       `json:"profileImage,omitempty"`
+	Presence     user.Presence `json:"presence,omitempty"`
+	InRoom       bool          `json:"inRoom,omitempty"`
+	Unread       int64         `json:"unread,omitempty"`
+	Since        time.Time     `json:"since"` // 친구가 된 시각 (요청/차단 목록은 요청/차단한 시각)
+}
+
+// FriendRequests 받은/보낸 친구 요청
+type FriendRequests struct {
+	Incoming []FriendEntry `json:"incoming"`
+	Outgoing []FriendEntry `json:"outgoing"`
+}
+
+// FriendNotice friend.request / friend.accepted / friend.removed 알림
+type FriendNotice struct {
+	UserID   string `json:"userId"`
+	Nickname string `json:"nickname"`
+}
+
+// SocialService 친구 관계(PostgreSQL)와 1:1 메시지(MongoDB)
+type SocialService struct {
+	Broadcaster Broadcaster
+}
+
+func NewSocialService(broadcaster Broadcaster) *SocialService {
+	return &SocialService{Broadcaster: broadcaster}
+}
+
+// Friends 친구 목록. 접속 중인 친구가 먼저, 그다음 닉네임 순.
+func (s *SocialService) Friends(ctx context.Context, me string) ([]FriendEntry, error) {
+	rows, err := friend.Outgoing(ctx, me, friend.StatusAccepted)
+	if err != nil {
+		return nil, socialError("Friends", me, err, resp.ErrorCodeFriendFailed)
+	}
+	entries, err := friendEntries(rows, func(f friend.Friendship) string { return f.FriendID })
+	if err != nil {
+		return nil, socialError("Friends", me, err, resp.ErrorCodeFriendFailed)
+	}
+
+	unread, err := dm.UnreadCounts(ctx, me)
+	if err != nil {
+		// 안 읽은 수를 못 읽어도 친구 목록은 보여준다
+		log.Logger.Errorf("SocialService.Friends - Failed to count unread messages for %s: %v", me, err)
+	}
+	for i := range entries {
+		entries[i].Presence, entries[i].InRoom = user.PresenceOf(entries[i].UserID)
+		entries[i].Unread = unread[entries[i].UserID]
+	}
+	sortFriends(entries)
+	return entries, nil
+}
+
+// Requests 받은/보낸 친구 요청
+func (s *SocialService) Requests(ctx context.Context, me string) (*FriendRequests, error) {
+	incoming, err := friend.Incoming(ctx, me)
+	if err != nil {
+		return nil, socialError("Requests", me, err, resp.ErrorCodeFriendFailed)
+	}
+	outgoing, err := friend.Outgoing(ctx, me, friend.StatusPending)
+	if err != nil {
+		return nil, socialError("Requests", me, err, resp.ErrorCodeFriendFailed)
+	}
+	result := &FriendRequests{}
+	if result.Incoming, err = friendEntries(incoming, func(f friend.Friendship) string { return f.UserID }); err != nil {
+		return nil, socialError("Requests", me, err, resp.ErrorCodeFriendFailed)
+	}
+	if result.Outgoing, err = friendEntries(outgoing, func(f friend.Friendship) string { return f.FriendID }); err != nil {
+		return nil, socialError("Requests", me, err, resp.ErrorCodeFriendFailed)
+	}
+	return result, nil
+}
+
+// Blocked 내가 차단한 사용자 목록
+func (s *SocialService) Blocked(ctx context.Context, me string) ([]FriendEntry, error) {
+	rows, err := friend.Outgoing(ctx, me, friend.StatusBlocked)
+	if err != nil {
+		return nil, socialError("Blocked", me, err, resp.ErrorCodeFriendFailed)
+	}
+	entries, err := friendEntries(rows, func(f friend.Friendship) string { return f.FriendID })
+	if err != nil {
+		return nil, socialError("Blocked", me, err, resp.ErrorCodeFriendFailed)
+	}
+	return entries, nil
+}
+
+// RequestFriend 친구 요청. 상대가 먼저 요청해 둔 상태면 바로 친구가 되고 friend.StatusAccepted를 반환한다.
+func (s *SocialService) RequestFriend(ctx context.Context, me, target string) (friend.Status, error) {
+	status, err := friend.Request(ctx, me, target)
+	if err != nil {
+		return "", socialError("RequestFriend", me, err, resp.ErrorCodeFriendFailed)
+	}
+	if status == friend.StatusAccepted {
+		s.notify(me, target, "friend.accepted", resp.SuccessCodeFriendAccept)
+	} else {
+		s.notify(me, target, "friend.request", resp.SuccessCodeFriendRequest)
+	}
+	return status, nil
+}
+
+// AcceptFriend 받은 친구 요청 수락. 요청한 사람에게 friend.accepted를 보낸다.
+func (s *SocialService) AcceptFriend(ctx context.Context, me, requester string) error {
+	if err := friend.Accept(ctx, me, requester); err != nil {
+		return socialError("AcceptFriend", me, err, resp.ErrorCodeFriendFailed)
+	}
+	s.notify(me, requester, "friend.accepted", resp.SuccessCodeFriendAccept)
+	return nil
+}
+
+// DeclineFriend 받은 요청 거절 또는 보낸 요청 취소. 상대에게는 알리지 않는다.
+func (s *SocialService) DeclineFriend(ctx context.Context, me, other string) error {
+	if err := friend.Decline(ctx, me, other); err != nil {
+		return socialError("DeclineFriend", me, err, resp.ErrorCodeFriendFailed)
+	}
+	return nil
+}
+
+// RemoveFriend 친구 삭제. 상대에게 friend.removed를 보낸다.
+func (s *SocialService) RemoveFriend(ctx context.Context, me, other string) error {
+	if err := friend.Remove(ctx, me, other); err != nil {
+		return socialError("RemoveFriend", me, err, resp.ErrorCodeFriendFailed)
+	}
+	s.notify(me, other, "friend.removed", resp.SuccessCodeFriendRemove)
+	return nil
+}
+
+// Block 사용자 차단. 친구였다면 관계가 끊기지만 상대에게 알리지 않는다.
+func (s *SocialService) Block(ctx context.Context, me, target string) error {
+	if err := friend.Block(ctx, me, target); err != nil {
+		return socialError("Block", me, err, resp.ErrorCodeFriendFailed)
+	}
+	return nil
+}
+
+// Unblock 차단 해제
+func (s *SocialService) Unblock(ctx context.Context, me, target string) error {
+	if err := friend.Unblock(ctx, me, target); err != nil {
+		return socialError("Unblock", me, err, resp.ErrorCodeFriendFailed)
+	}
+	return nil
+}
+
+// SendDirectMessage 친구에게 메시지를 저장하고, 받는 사람이 접속 중이면 dm.message로 바로 전달한다.
+// 접속하지 않은 친구는 다음 접속 때 안 읽은 수와 대화 내역으로 확인한다.
+func (s *SocialService) SendDirectMessage(ctx context.Context, me, myName, to, text string) (*dm.Message, error) {
+	ok, err := friend.AreFriends(ctx, me, to)
+	if err != nil {
+		return nil, socialError("SendDirectMessage", me, err, resp.ErrorCodeDMSendFailed)
+	}
+	if !ok {
+		return nil, errors.New(resp.ErrorCodeDMNotFriends)
+	}
+
+	m := &dm.Message{
+		SenderID:    me,
+		SenderName:  myName,
+		RecipientID: to,
+		Message:     text,
+		Timestamp:   time.Now(),
+	}
+	if err := dm.Save(ctx, m); err != nil {
+		return nil, socialError("SendDirectMessage", me, err, resp.ErrorCodeDMSendFailed)
+	}
+	if presence, _ := user.PresenceOf(to); presence == user.PresenceOnline {
+		s.Broadcaster.SendToPlayer(to, "dm.message", m, resp.SuccessCodeDMSend)
+	}
+	return m, nil
+}
+
+// DirectMessages 상대와의 대화 내역 (친구가 끊긴 뒤에도 자기 대화는 볼 수 있다)
+func (s *SocialService) DirectMessages(ctx context.Context, me, other string, q dm.HistoryQuery) ([]*dm.Message, string, error) {
+	messages, next, err := dm.History(ctx, me, other, q)
+	if err != nil {
+		return nil, "", socialError("DirectMessages", me, err, resp.ErrorCodeDMFetchFailed)
+	}
+	return messages, next, nil
+}
+
+// MarkRead 상대에게서 받은 메시지를 모두 읽음 처리한다.
+func (s *SocialService) MarkRead(ctx context.Context, me, other string) (int64, error) {
+	n, err := dm.MarkRead(ctx, me, other)
+	if err != nil {
+		return 0, socialError("MarkRead", me, err, resp.ErrorCodeDMFetchFailed)
+	}
+	return n, nil
+}
+
+// UnreadCounts 보낸 사람별 안 읽은 메시지 수
+func (s *SocialService) UnreadCounts(ctx context.Context, me string) (map[string]int64, error) {
+	counts, err := dm.UnreadCounts(ctx, me)
+	if err != nil {
+		return nil, socialError("UnreadCounts", me, err, resp.ErrorCodeDMFetchFailed)
+	}
+	return counts, nil
+}
+
+// notify from의 닉네임을 담아 to에게 친구 알림을 보낸다. to가 접속 중이 아니면 보내지 않는다.
+func (s *SocialService) notify(from, to, eventName, msgCode string) {
+	if presence, _ := user.PresenceOf(to); presence != user.PresenceOnline {
+		return
+	}
+	notice := FriendNotice{UserID: from}
+	if u, err := user.FindUserByID(from); err == nil && u != nil {
+		notice.Nickname = u.Nickname
+	}
+	s.Broadcaster.SendToPlayer(to, eventName, notice, msgCode)
+}
+
+// friendEntries 관계 행을 상대 유저 정보가 담긴 목록으로 바꾼다. 탈퇴한 유저는 뺀다.
+func friendEntries(rows []friend.Friendship, other func(friend.Friendship) string) ([]FriendEntry, error) {
+	ids := make([]string, 0, len(rows))
+	for _, f := range rows {
+		ids = append(ids, other(f))
+	}
+	users, err := user.FindUsersByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]FriendEntry, 0, len(rows))
+	for _, f := range rows {
+		u, ok := users[other(f)]
+		if !ok || !u.IsActive {
+			continue
+		}
+		entries = append(entries, FriendEntry{
+			UserID:       u.ID.String(),
+			Nickname:     u.Nickname,
+			ProfileImage: u.ProfileImage,
+			Since:        f.UpdatedAt,
+		})
+	}
+	return entries, nil
+}
+
+var presenceOrder = map[user.Presence]int{
+	user.PresenceOnline:  0,
+	user.PresenceAway:    1,
+	user.PresenceOffline: 2,
+}
+
+// sortFriends 접속 중 → 재접속 대기 → 오프라인, 같은 상태면 닉네임 순
+func sortFriends(entries []FriendEntry) {
+	slices.SortStableFunc(entries, func(a, b FriendEntry) int {
+		if d := presenceOrder[a.Presence] - presenceOrder[b.Presence]; d != 0 {
+			return d
+		}
+		return strings.Compare(a.Nickname, b.Nickname)
+	})
+}
+
+// socialError 정의된 에러 코드는 그대로, 그 외(DB 오류 등)는 로그를 남기고 fallback 코드로 바꾼다.
+func socialError(op string, userID string, err error, fallback string) error {
+	if _, ok := resp.GetDefineCode(err.Error(), util.DefaultLanguage); ok {
+		return err
+	}
+	log.Logger.Errorf("SocialService.%s - Failed for user %s: %v", op, userID, err)
+	return errors.New(fallback)
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Ryeom/board-game/internal/ai"
+	chat "github.com/Ryeom/board-game/internal/domain/chat"
+	"github.com/Ryeom/board-game/internal/game/hanabi"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/user"
+)
+
+// Timeline 방 채팅 타임라인에 system/game 기록을 남긴다. (구현체가 저장 후 chat.message로 방에 전달)
+type Timeline interface {
+	Post(roomID string, recordType chat.RecordType, key string, params map[string]string, subjects ...chat.Subject)
+}
+
+// postTimeline Timeline이 설정되지 않았으면(테스트 등) 아무것도 하지 않는다.
+func postTimeline(t Timeline, roomID string, recordType chat.RecordType, key string, params map[string]string, subjects ...chat.Subject) {
+	if t == nil {
+		return
+	}
+	t.Post(roomID, recordType, key, params, subjects...)
+}
+
+// userSubject 기록의 {user} 자리에 이름이 들어간 사용자
+func userSubject(userID string) chat.Subject {
+	return chat.Subject{Param: "user", UserID: userID}
+}
+
+// playerName 타임라인에 표시할 플레이어 이름. 세션이 없으면 ID.
+func playerName(playerID string) string {
+	if ai.IsAIPlayer(playerID) {
+		return ai.PlayerName(playerID)
+	}
+	if session, err := user.GetSession(playerID); err == nil && session.Name != "" {
+		return session.Name
+	}
+	return playerID
+}
+
+// hanabiTimelineEntry 하나비 액션 결과를 타임라인 기록 템플릿과 값, 이름이 들어간 플레이어로 바꾼다. 남길 내용이 없으면 key가 빈 문자열.
+func hanabiTimelineEntry(result *hanabi.ActionResult, name func(string) string) (string, map[string]string, []chat.Subject) {
+	switch result.Action {
+	case "give_hint":
+		params := map[string]string{
+			"from":  name(result.PlayerID),
+			"to":    name(result.TargetID),
+			"count": strconv.Itoa(len(result.TouchedIndices)),
+		}
+		subjects := []chat.Subject{{Param: "from", UserID: result.PlayerID}, {Param: "to", UserID: result.TargetID}}
+		switch v := result.HintValue.(type) {
+		case string:
+			params["color"] = v
+			return resp.TimelineCodeHintColor, params, subjects
+		case float64:
+			params["number"] = strconv.Itoa(int(v))
+			return resp.TimelineCodeHintNumber, params, subjects
+		case int:
+			params["number"] = strconv.Itoa(v)
+			return resp.TimelineCodeHintNumber, params, subjects
+		}
+	case "play_card", "discard":
+		if result.Card == nil {
+			return "", nil, nil
+		}
+		params := map[string]string{
+			"player": name(result.PlayerID),
+			"color":  string(result.Card.Color),
+			"number": strconv.Itoa(result.Card.Number),
+		}
+		subjects := []chat.Subject{{Param: "player", UserID: result.PlayerID}}
+		if result.Action == "discard" {
+			return resp.TimelineCodeDiscarded, params, subjects
+		}
+		if result.Success != nil && !*result.Success {
+			return resp.TimelineCodeMisplayed, params, subjects
+		}
+		return resp.TimelineCodePlayed, params, subjects
+	}
+	return "", nil, nil
+}
+
+// hanabiEndedEntry 하나비 종료 상태를 결과 기록으로 바꾼다.
+func hanabiEndedEntry(state *hanabi.State) (string, map[string]string) {
+	reason := state.EndReason
+	if reason == "" {
+		reason = "deck_exhausted"
+	}
+	return resp.TimelineCodeGameEndedPrefix + strings.ToUpper(reason), map[string]string{
+		"score": strconv.Itoa(state.FinalScore),
+	}
+}
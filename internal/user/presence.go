@@ -0,0 +1,26 @@
+package user
+
+// Presence 친구 목록에 보이는 접속 상태
+type Presence string
+
+const (
+	PresenceOnline  Presence = "online"  // 연결 중
+	PresenceAway    Presence = "away"    // 게임 중 연결이 끊겨 재접속을 기다리는 중
+	PresenceOffline Presence = "offline" // 세션 없음
+)
+
+// PresenceOf user:session:<userID>의 상태로 접속 상태를 구한다. (user.identify 이후 세션 ID는 유저 ID와 같다)
+// 방에 들어가 있으면 inRoom=true.
+func PresenceOf(userID string) (presence Presence, inRoom bool) {
+	session, err := GetSession(userID)
+	if err != nil {
+		return PresenceOffline, false
+	}
+	switch session.Status {
+	case "connected":
+		return PresenceOnline, session.RoomID != ""
+	case "disconnected":
+		return PresenceAway, session.RoomID != ""
+	}
+	return PresenceOffline, false
+}
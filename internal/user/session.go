@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/Ryeom/board-game/log"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,19 +13,29 @@ import (
 )
 
 const sessionTTL = 3 * time.Hour // 세션 TTL
+
+// Activities user.update의 status로 고를 수 있는 표시 상태
+var Activities = map[string]bool{"online": true, "away": true, "busy": true}
+
 type Session struct {
-	ID           string          `json:"id"`
-	ActualUserID string          `json:"actualUserId"`
-	Name         string          `json:"name"`
-	RoomID       string          `json:"roomId"`
-	IsHost       bool            `json:"isHost"`
-	ConnectedAt  time.Time       `json:"connectedAt"`
-	LastPingAt   time.Time       `json:"lastPingAt"`
-	IP           string          `json:"ip"`
-	UserAgent    string          `json:"userAgent"`
-	Status       string          `json:"status"`
-	Conn         *websocket.Conn `json:"-"`
-	WriteMutex   *sync.Mutex     `json:"-"`
+	ID             string          `json:"id"`
+	ActualUserID   string          `json:"actualUserId"` // 토큰으로 검증된 사용자 ID
+	IsGuest        bool            `json:"isGuest"`
+	AuthSessionID  string          `json:"authSessionId,omitempty"` // 로그인 세션 (세션 종료 시 연결을 끊는 기준)
+	IsAdmin        bool            `json:"isAdmin"`                 // 관리자 계정 (전체 채팅 음소거 등)
+	Name           string          `json:"name"`
+	RoomID         string          `json:"roomId"`
+	IsHost         bool            `json:"isHost"`
+	ConnectedAt    time.Time       `json:"connectedAt"`
+	LastPingAt     time.Time       `json:"lastPingAt"`
+	IP             string          `json:"ip"`
+	UserAgent      string          `json:"userAgent"`
+	Lang           string          `json:"lang"`                     // 응답 메시지 언어 (ko, en)
+	Status         string          `json:"status"`                   // 연결 상태 (connected, disconnected). 서버만 변경한다.
+	Activity       string          `json:"activity,omitempty"`       // 유저가 고른 표시 상태 (Activities)
+	DisconnectedAt time.Time       `json:"disconnectedAt,omitempty"` // 게임 중 연결이 끊겨 재접속을 기다리기 시작한 시각
+	Conn           *websocket.Conn `json:"-"`
+	WriteMutex     *sync.Mutex     `json:"-"`
 }
 
 func NewUserSession(socketID, name, roomID, ip, userAgent string, isHost bool, conn *websocket.Conn) *Session {
@@ -92,3 +103,11 @@ func sessionKey(socketID string) string {
 func RoomIndexKey(roomID string) string {
 	return fmt.Sprintf("room_sessions:%s", roomID)
 }
+
+// RoomIndexPattern 모든 방 세션 set을 SCAN할 때 쓰는 패턴
+const RoomIndexPattern = "room_sessions:*"
+
+// RoomIDFromIndexKey room_sessions:<roomID> 키에서 방 ID를 꺼낸다.
+func RoomIDFromIndexKey(key string) (string, bool) {
+	return strings.CutPrefix(key, "room_sessions:")
+}
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"context"
 	"errors"
 	"github.com/Ryeom/board-game/infra/db"
 	"github.com/google/uuid"
@@ -17,17 +18,46 @@ const (
 	RoleAdmin Role = "admin"
 )
 
+// 탈퇴한 유저의 채팅/전적에 대신 남는 익명 ID와 이름
+const (
+	DeletedUserID   = "deleted"
+	DeletedUserName = "탈퇴한 사용자"
+)
+
+// DeletionHook 탈퇴 처리 뒤 다른 저장소에 남은 개인 정보를 정리한다. (채팅/전적 익명화 등)
+type DeletionHook func(ctx context.Context, userID string) error
+
+var deletionHooks []DeletionHook
+
+// OnSoftDelete 계정을 비활성화한 뒤 RunDeletionHooks로 실행할 정리 작업을 등록한다. (서버 시작 시)
+// 실패하면 다시 실행되므로 여러 번 실행해도 결과가 같아야 한다.
+func OnSoftDelete(hook DeletionHook) {
+	deletionHooks = append(deletionHooks, hook)
+}
+
 type User struct {
-	ID           uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
-	Email        string     `gorm:"uniqueIndex;not null"`
-	Password     string     `gorm:"not null"`
-	Nickname     string     `gorm:"not null"`
-	ProfileImage *string    `gorm:"type:text"`                       // 프로필 사진 URL
-	Role         Role       `gorm:"type:varchar(20);default:'user'"` // 권한
-	IsActive     bool       `gorm:"default:true"`                    // 탈퇴 여부
-	LastLoginAt  *time.Time // 마지막 로그인 시간
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID              uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	Email           string     `gorm:"uniqueIndex;not null"`
+	Password        string     `gorm:"not null"`
+	Nickname        string     `gorm:"not null"`
+	ProfileImage    *string    `gorm:"type:text"`                       // 프로필 사진 URL
+	Role            Role       `gorm:"type:varchar(20);default:'user'"` // 권한
+	IsActive        bool       `gorm:"default:true"`                    // 탈퇴 여부
+	EmailVerifiedAt *time.Time // 이메일 인증 시각 (인증 전이면 없음)
+	Rating          int        `gorm:"not null;default:1000"` // 레이팅 매칭 점수
+	LastLoginAt     *time.Time // 마지막 로그인 시간
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Migrate users 테이블에 새 컬럼 추가 (이메일 인증 등)
+func Migrate(conn *gorm.DB) error {
+	return conn.AutoMigrate(&User{})
+}
+
+// EmailVerified 이메일 인증을 마쳤는지 확인
+func (u *User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
 }
 
 func FindUserByID(id string) (*User, error) {
@@ -41,6 +71,22 @@ func FindUserByID(id string) (*User, error) {
 	return &user, nil
 }
 
+// FindUsersByIDs 여러 사용자를 한 번에 조회. 없는 ID는 결과에서 빠진다.
+func FindUsersByIDs(ids []string) (map[string]*User, error) {
+	users := make(map[string]*User, len(ids))
+	if len(ids) == 0 {
+		return users, nil
+	}
+	var list []*User
+	if err := db.DB.Where("id IN ?", ids).Find(&list).Error; err != nil {
+		return nil, err
+	}
+	for _, u := range list {
+		users[u.ID.String()] = u
+	}
+	return users, nil
+}
+
 func FindUserByEmail(email string) (*User, error) {
 	var user User
 	if err := db.DB.Where("email = ?", email).First(&user).Error; err != nil {
@@ -56,14 +102,36 @@ func CheckPassword(input, hashed string) bool {
 	return util.CheckPasswordHash(input, hashed)
 }
 
-func SoftDeleteUser(db *gorm.DB, id string) error {
+// DeactivateUser 계정을 비활성화한다. 비활성화된 계정은 로그인할 수 없다.
+func DeactivateUser(db *gorm.DB, id string) error {
 	return db.Model(&User{}).Where("id = ?", id).Update("is_active", false).Error
 }
 
+// RunDeletionHooks 비활성화된 계정에 등록된 정리 작업(OnSoftDelete)을 모두 실행한다. 정리 실패는 모아서 반환한다.
+func RunDeletionHooks(ctx context.Context, id string) error {
+	var errs []error
+	for _, hook := range deletionHooks {
+		if err := hook(ctx, id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func UpdateLastLoginAt(db *gorm.DB, id string) error {
 	return db.Model(&User{}).Where("id = ?", id).Update("last_login_at", time.Now()).Error
 }
 
+// UpdatePassword 해시된 비밀번호로 바꾼다.
+func UpdatePassword(db *gorm.DB, id, hashedPassword string) error {
+	return db.Model(&User{}).Where("id = ?", id).Update("password", hashedPassword).Error
+}
+
+// MarkEmailVerified 이메일 인증 시각을 남긴다. 이미 인증했으면 그대로 둔다.
+func MarkEmailVerified(db *gorm.DB, id string) error {
+	return db.Model(&User{}).Where("id = ? AND email_verified_at IS NULL", id).Update("email_verified_at", time.Now()).Error
+}
+
 func IsAdmin(user *User) bool {
 	return user.Role == RoleAdmin
 }
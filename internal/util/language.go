@@ -58,6 +58,16 @@ func GetUserLanguage(c echo.Context) string {
 	return DefaultLanguage
 }
 
+// NormalizeLanguage 언어 코드를 code.json이 가진 기본 언어(ko, en)로 변환. 지원하지 않으면 기본 언어를 반환.
+func NormalizeLanguage(lang string) string {
+	base := strings.ToLower(strings.Split(strings.TrimSpace(lang), "-")[0])
+	switch base {
+	case "ko", "en":
+		return base
+	}
+	return DefaultLanguage
+}
+
 func parseAcceptLanguage(header string) []LanguageCandidate {
 	var candidates []LanguageCandidate
 	parts := strings.Split(header, ",")
@@ -122,4 +122,44 @@ func isDesktopPlatform() bool {
 
 func applyDefaultSettings() {
 	viper.SetDefault("bg.local-ip", util.GetLocalIP())
+	viper.SetDefault("bg.ws.guest-enabled", false)
+	viper.SetDefault("bg.invite.default-ttl", "24h")
+	viper.SetDefault("bg.invite.max-ttl", "168h")
+	viper.SetDefault("bg.invite.link-base", "boardgame://invite/")
+	viper.SetDefault("bg.match.enabled", true)
+	viper.SetDefault("bg.match.interval", "1s")
+	viper.SetDefault("bg.match.bot-backfill-after", "0s")
+	viper.SetDefault("bg.match.ticket-ttl", "10m")
+	viper.SetDefault("bg.rematch.vote-timeout", "30s")
+	viper.SetDefault("bg.janitor.enabled", true)
+	viper.SetDefault("bg.janitor.interval", "1m")
+	viper.SetDefault("bg.janitor.room-idle", "30m")
+	viper.SetDefault("bg.janitor.waiting-max-idle", "6h")
+	viper.SetDefault("bg.janitor.disconnect-grace", "10m")
+	viper.SetDefault("bg.chat.max-length", 300)
+	viper.SetDefault("bg.chat.retention", "0s")
+	viper.SetDefault("bg.chat.max-mute", "24h")
+	viper.SetDefault("bg.chat.flood-limit", 5)
+	viper.SetDefault("bg.chat.flood-window", "5s")
+	viper.SetDefault("bg.chat.flood-mute", "30s")
+	viper.SetDefault("bg.chat.filter.mode", "mask")
+	viper.SetDefault("bg.chat.filter.files", []string{"chatfilter/ko.txt", "chatfilter/en.txt"})
+	viper.SetDefault("bg.auth.access-ttl", "15m")
+	viper.SetDefault("bg.auth.refresh-ttl", "720h")
+	viper.SetDefault("bg.auth.key-id", "default")
+	viper.SetDefault("bg.auth.issuer", "board-game")
+	viper.SetDefault("bg.auth.audience", "board-game-client")
+	viper.SetDefault("bg.auth.password-reset-ttl", "1h")
+	viper.SetDefault("bg.auth.email-verify-ttl", "24h")
+	viper.SetDefault("bg.auth.login.window", "15m")
+	viper.SetDefault("bg.auth.login.free-attempts", 3)
+	viper.SetDefault("bg.auth.login.ip-free-attempts", 20)
+	viper.SetDefault("bg.auth.login.base-delay", "1s")
+	viper.SetDefault("bg.auth.login.max-delay", "1m")
+	viper.SetDefault("bg.auth.login.lock-after", 10)
+	viper.SetDefault("bg.auth.login.lock-duration", "30m")
+	viper.SetDefault("bg.mail.driver", "log")
+	viper.SetDefault("bg.mail.from", "Board Game <no-reply@localhost>")
+	viper.SetDefault("bg.mail.link-base", "boardgame://auth/")
+	viper.SetDefault("bg.mail.smtp.port", 587)
 }
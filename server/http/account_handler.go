@@ -0,0 +1,163 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/Ryeom/board-game/internal/auth"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/service"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/Ryeom/board-game/internal/util"
+	"github.com/Ryeom/board-game/log"
+	"github.com/Ryeom/board-game/server/ws"
+	"github.com/labstack/echo/v4"
+)
+
+type PasswordResetRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type PasswordResetConfirmRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"newPassword" validate:"required,min=8"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// RequestPasswordReset - 비밀번호 재설정 메일 요청
+// @Summary 비밀번호 재설정 메일 요청
+// @Description 가입된 이메일이면 비밀번호 재설정 링크를 메일로 보냅니다. 가입 여부나 요청 제한(이메일/IP별)과 관계없이 같은 응답을 반환합니다.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body PasswordResetRequest true "비밀번호 재설정 요청"
+// @Success 200 {object} HttpResult "요청 접수"
+// @Failure 400 {object} HttpResult "잘못된 요청 형식"
+// @Router /board-game/auth/password-reset/request [post]
+func RequestPasswordReset(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	var req PasswordResetRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resp.Fail(resp.ErrorCodeAuthBind, lang,
+			resp.ErrorDetail{},
+		))
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resp.Fail(resp.ErrorCodeAuthValidation, lang,
+			resp.ErrorDetail{},
+		))
+	}
+
+	// 요청 제한이나 발송 실패도 같은 응답 (가입 여부가 드러나지 않도록). 실패는 서비스에서 로그로 남긴다.
+	ctx := c.Request().Context()
+	if !auth.AllowMailRequest(ctx, auth.MailPurposePasswordReset, req.Email, c.RealIP()) {
+		log.Logger.Warningf("RequestPasswordReset - Throttled request for %s from %s", req.Email, c.RealIP())
+	} else if err := service.RequestPasswordReset(ctx, req.Email, lang); err != nil {
+		log.Logger.Warningf("RequestPasswordReset - %v", err)
+	}
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeAuthPasswordResetRequest, nil, lang))
+}
+
+// ConfirmPasswordReset - 비밀번호 재설정
+// @Summary 비밀번호 재설정
+// @Description 메일의 토큰으로 새 비밀번호를 설정합니다. 모든 로그인 세션이 종료되고 WebSocket 연결이 끊기며, 로그인 실패로 잠긴 계정도 풀립니다.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body PasswordResetConfirmRequest true "비밀번호 재설정"
+// @Success 200 {object} HttpResult "비밀번호 재설정 성공"
+// @Failure 400 {object} HttpResult "잘못된 요청 형식 또는 유효하지 않은 토큰"
+// @Failure 500 {object} HttpResult "서버 오류"
+// @Router /board-game/auth/password-reset/confirm [post]
+func ConfirmPasswordReset(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	var req PasswordResetConfirmRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resp.Fail(resp.ErrorCodeAuthBind, lang,
+			resp.ErrorDetail{},
+		))
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resp.Fail(resp.ErrorCodeAuthValidation, lang,
+			resp.ErrorDetail{},
+		))
+	}
+
+	userID, err := service.ConfirmPasswordReset(c.Request().Context(), req.Token, req.NewPassword)
+	if err != nil {
+		return sessionFail(c, lang, err)
+	}
+	ws.DisconnectAuthSessions(userID, nil)
+	log.Logger.Infof("ConfirmPasswordReset - Password reset for user %s", userID)
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeAuthPasswordReset, nil, lang))
+}
+
+// VerifyEmail - 이메일 인증
+// @Summary 이메일 인증
+// @Description 메일의 토큰으로 이메일 인증을 마칩니다. 레이팅 매칭은 이메일 인증을 마친 계정만 이용할 수 있습니다.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body VerifyEmailRequest true "이메일 인증"
+// @Success 200 {object} HttpResult "이메일 인증 성공"
+// @Failure 400 {object} HttpResult "잘못된 요청 형식 또는 유효하지 않은 토큰"
+// @Router /board-game/auth/verify-email [post]
+func VerifyEmail(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	var req VerifyEmailRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resp.Fail(resp.ErrorCodeAuthBind, lang,
+			resp.ErrorDetail{},
+		))
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resp.Fail(resp.ErrorCodeAuthValidation, lang,
+			resp.ErrorDetail{},
+		))
+	}
+
+	if err := service.VerifyEmail(c.Request().Context(), req.Token); err != nil {
+		return sessionFail(c, lang, err)
+	}
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeAuthEmailVerified, nil, lang))
+}
+
+// ResendEmailVerification - 인증 메일 재발송
+// @Summary 인증 메일 재발송
+// @Description 이메일 인증 메일을 다시 보냅니다. 이전에 받은 인증 링크는 사용할 수 없게 됩니다. 요청 제한(이메일/IP별)에 걸리면 메일은 보내지 않고 같은 응답을 반환합니다.
+// @Tags Auth
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} HttpResult "인증 메일 발송"
+// @Failure 409 {object} HttpResult "이미 인증된 이메일"
+// @Failure 500 {object} HttpResult "메일 발송 실패"
+// @Router /board-game/api/auth/verify-email/resend [post]
+func ResendEmailVerification(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	userID := c.Get("userID").(string)
+
+	u, err := user.FindUserByID(userID)
+	if err != nil {
+		log.Logger.Errorf("ResendEmailVerification - FindUserByID Error for ID %s: %v", userID, err)
+		return c.JSON(http.StatusInternalServerError, resp.Fail(resp.ErrorCodeAuthUserLookupFailed, lang,
+			resp.ErrorDetail{},
+		))
+	}
+	if u == nil {
+		return c.JSON(http.StatusNotFound, resp.Fail(resp.ErrorCodeUserNotFound, lang,
+			resp.ErrorDetail{},
+		))
+	}
+	// 요청 제한에 걸려도 같은 응답 (메일만 보내지 않음)
+	ctx := c.Request().Context()
+	if !auth.AllowMailRequest(ctx, auth.MailPurposeVerifyEmail, u.Email, c.RealIP()) {
+		log.Logger.Warningf("ResendEmailVerification - Throttled request for user %s from %s", userID, c.RealIP())
+		return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeAuthEmailVerificationSent, nil, lang))
+	}
+	if err := service.SendEmailVerification(ctx, u, lang); err != nil {
+		return sessionFail(c, lang, err)
+	}
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeAuthEmailVerificationSent, nil, lang))
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/Ryeom/board-game/internal/auth"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/Ryeom/board-game/internal/util"
+	"github.com/Ryeom/board-game/log"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// UnlockUserLogin - 로그인 잠금 해제 (관리자)
+// @Summary 로그인 잠금 해제
+// @Description 로그인 실패 누적으로 잠긴 계정의 잠금과 실패 횟수를 초기화합니다. 잠겨 있었으면 감사 로그(auth_audit_logs)에 남습니다. 관리자만 호출할 수 있습니다.
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Param userId path string true "잠금을 해제할 유저 ID"
+// @Success 200 {object} HttpResult{data=object{userId=string,wasLocked=bool}} "잠금 해제 성공"
+// @Failure 403 {object} HttpResult "관리자가 아님"
+// @Failure 404 {object} HttpResult "유저 없음"
+// @Failure 500 {object} HttpResult "서버 오류"
+// @Router /board-game/api/admin/users/{userId}/login-lock [delete]
+func UnlockUserLogin(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	adminID := c.Get("userID").(string)
+	targetID := c.Param("userId")
+	if _, err := uuid.Parse(targetID); err != nil {
+		return c.JSON(http.StatusNotFound, resp.Fail(resp.ErrorCodeUserNotFound, lang,
+			resp.ErrorDetail{},
+		))
+	}
+
+	target, err := user.FindUserByID(targetID)
+	if err != nil {
+		log.Logger.Errorf("UnlockUserLogin - FindUserByID Error for ID %s: %v", targetID, err)
+		return c.JSON(http.StatusInternalServerError, resp.Fail(resp.ErrorCodeAuthUserLookupFailed, lang,
+			resp.ErrorDetail{},
+		))
+	}
+	if target == nil {
+		return c.JSON(http.StatusNotFound, resp.Fail(resp.ErrorCodeUserNotFound, lang,
+			resp.ErrorDetail{},
+		))
+	}
+
+	wasLocked, err := auth.UnlockLogin(c.Request().Context(), target.Email, targetID, adminID, auth.UnlockReasonAdmin)
+	if err != nil {
+		log.Logger.Errorf("UnlockUserLogin - Failed to unlock %s: %v", targetID, err)
+		return c.JSON(http.StatusInternalServerError, resp.Fail(resp.ErrorCodeAuthUnlockFailed, lang,
+			resp.ErrorDetail{},
+		))
+	}
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeAuthLoginUnlock, map[string]any{
+		"userId":    targetID,
+		"wasLocked": wasLocked,
+	}, lang))
+}
+
+// AnonymizeDeletedUser - 탈퇴 계정 익명화 재실행 (관리자)
+// @Summary 탈퇴 계정 익명화 재실행
+// @Description 탈퇴 시 일부 익명화가 실패한 계정(202 SUCCESS_USER_DELETE_CLEANUP_PENDING)의 정리 작업을 다시 실행합니다. 탈퇴한 계정만 가능하며 관리자만 호출할 수 있습니다.
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Param userId path string true "탈퇴한 유저 ID"
+// @Success 200 {object} HttpResult{data=object{userId=string}} "익명화 성공"
+// @Failure 403 {object} HttpResult "관리자가 아님"
+// @Failure 404 {object} HttpResult "유저 없음"
+// @Failure 409 {object} HttpResult "탈퇴하지 않은 계정"
+// @Failure 500 {object} HttpResult "서버 오류"
+// @Router /board-game/api/admin/users/{userId}/anonymize [post]
+func AnonymizeDeletedUser(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	targetID := c.Param("userId")
+	if _, err := uuid.Parse(targetID); err != nil {
+		return c.JSON(http.StatusNotFound, resp.Fail(resp.ErrorCodeUserNotFound, lang,
+			resp.ErrorDetail{},
+		))
+	}
+
+	target, err := user.FindUserByID(targetID)
+	if err != nil {
+		log.Logger.Errorf("AnonymizeDeletedUser - FindUserByID Error for ID %s: %v", targetID, err)
+		return c.JSON(http.StatusInternalServerError, resp.Fail(resp.ErrorCodeAuthUserLookupFailed, lang,
+			resp.ErrorDetail{},
+		))
+	}
+	if target == nil {
+		return c.JSON(http.StatusNotFound, resp.Fail(resp.ErrorCodeUserNotFound, lang,
+			resp.ErrorDetail{},
+		))
+	}
+	if target.IsActive {
+		return c.JSON(http.StatusConflict, resp.Fail(resp.ErrorCodeUserStillActive, lang,
+			resp.ErrorDetail{},
+		))
+	}
+
+	if err := user.RunDeletionHooks(c.Request().Context(), targetID); err != nil {
+		log.Logger.Errorf("AnonymizeDeletedUser - Anonymization failed for %s: %v", targetID, err)
+		return c.JSON(http.StatusInternalServerError, resp.Fail(resp.ErrorCodeUserAnonymizeFailed, lang,
+			resp.ErrorDetail{},
+		))
+	}
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeUserAnonymize, map[string]any{
+		"userId": targetID,
+	}, lang))
+}
@@ -1,14 +1,19 @@
 package http
 
 import (
+	"errors"
 	"github.com/Ryeom/board-game/infra/db"
 	"github.com/Ryeom/board-game/internal/auth"
 	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/service"
 	"github.com/Ryeom/board-game/internal/user"
 	"github.com/Ryeom/board-game/internal/util"
 	"github.com/Ryeom/board-game/log"
+	"github.com/Ryeom/board-game/server/ws"
 	"github.com/labstack/echo/v4"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -25,7 +30,7 @@ type LoginRequest struct {
 
 // SignUp은 새로운 사용자를 등록합니다.
 // @Summary 회원가입
-// @Description 새로운 사용자 계정을 생성합니다.
+// @Description 새로운 사용자 계정을 생성하고 이메일 인증 메일을 보냅니다.
 // @Tags Auth
 // @Accept json
 // @Produce json
@@ -88,19 +93,26 @@ func SignUp(c echo.Context) error {
 		))
 	}
 
+	// 인증 메일 발송 실패는 가입을 막지 않는다 (다시 보내기 가능)
+	if err := service.SendEmailVerification(c.Request().Context(), &data, lang); err != nil {
+		log.Logger.Errorf("SignUp - Failed to send verification mail to %s: %v", data.ID, err)
+	}
+
 	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeUserSignUp, data, lang))
 }
 
 // Login은 사용자를 인증하고 JWT를 반환합니다.
 // @Summary 로그인
-// @Description 사용자를 인증하고 JWT(JSON Web Token)를 발급합니다.
+// @Description 사용자를 인증하고 로그인 세션을 만들어 짧은 access token(JWT)과 refresh token을 발급합니다.
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param request body LoginRequest true "로그인 요청"
-// @Success 200 {object} HttpResult{data=object{token=string,user_id=string,nickname=string}} "로그인 성공 및 JWT 토큰 반환"
+// @Success 200 {object} HttpResult{data=object{token=string,expiresIn=int,refreshToken=string,refreshExpiresAt=string,sessionId=string,user_id=string,nickname=string}} "로그인 성공 및 토큰 반환"
 // @Failure 400 {object} HttpResult "잘못된 요청 형식 또는 유효성 검사 실패"
 // @Failure 401 {object} HttpResult "인증 실패 (잘못된 이메일 또는 비밀번호)"
+// @Failure 423 {object} HttpResult "로그인 실패 누적으로 계정 잠김 (Retry-After 헤더)"
+// @Failure 429 {object} HttpResult "로그인 시도가 너무 많음 (Retry-After 헤더)"
 // @Failure 500 {object} HttpResult "서버 오류"
 // @Router /board-game/auth/login [post]
 func Login(c echo.Context) error {
@@ -119,6 +131,11 @@ func Login(c echo.Context) error {
 		))
 	}
 
+	ctx := c.Request().Context()
+	if err := auth.CheckLogin(ctx, payload.Email, c.RealIP()); err != nil {
+		return loginBlocked(c, lang, err)
+	}
+
 	u, err := user.FindUserByEmail(payload.Email)
 	if err != nil {
 		log.Logger.Errorf("Login - FindUserByEmail Error: %v", err)
@@ -126,11 +143,24 @@ func Login(c echo.Context) error {
 			resp.ErrorDetail{},
 		))
 	}
-	if u == nil || !util.CheckPasswordHash(payload.Password, u.Password) {
+	// 탈퇴한 계정은 가입되지 않은 이메일과 같이 취급
+	if u == nil || !u.IsActive || !util.CheckPasswordHash(payload.Password, u.Password) {
+		userID := ""
+		if u != nil {
+			userID = u.ID.String()
+		}
+		if err := auth.RecordLoginFailure(ctx, payload.Email, c.RealIP(), userID); err != nil {
+			var blocked *auth.LoginBlockedError
+			if errors.As(err, &blocked) {
+				return loginBlocked(c, lang, err)
+			}
+			log.Logger.Errorf("Login - Failed to record login failure for %s: %v", payload.Email, err)
+		}
 		return c.JSON(http.StatusBadRequest, resp.Fail(resp.ErrorCodeAuthInvalidCredentials, lang,
 			resp.ErrorDetail{},
 		))
 	}
+	auth.RecordLoginSuccess(ctx, payload.Email)
 
 	if err := user.UpdateLastLoginAt(db.DB, u.ID.String()); err != nil {
 		log.Logger.Errorf("Login - UpdateLastLoginAt Error: %v", err)
@@ -139,23 +169,35 @@ func Login(c echo.Context) error {
 		))
 	}
 
-	token, err := auth.GenerateJWT(u.ID.String())
+	pair, err := auth.StartSession(ctx, u.ID.String(), u.Role, c.Request().UserAgent(), c.RealIP())
 	if err != nil {
-		log.Logger.Errorf("Login - JWT Generation Error: %v", err)
-		return c.JSON(http.StatusInternalServerError, resp.Fail(resp.ErrorCodeAuthJwtGenerationFailed, lang,
-			resp.ErrorDetail{},
-		))
+		log.Logger.Errorf("Login - StartSession Error: %v", err)
+		return sessionFail(c, lang, err)
 	}
 
 	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeUserLogin, map[string]any{
-		"token":    token,
-		"user_id":  u.ID.String(),
-		"nickname": u.Nickname,
+		"token":            pair.Token,
+		"expiresIn":        pair.ExpiresIn,
+		"refreshToken":     pair.RefreshToken,
+		"refreshExpiresAt": pair.RefreshExpiresAt,
+		"sessionId":        pair.SessionID,
+		"user_id":          u.ID.String(),
+		"nickname":         u.Nickname,
 	}, lang))
 }
 
+// loginBlocked 대기 중이거나 잠긴 계정의 로그인 응답. 남은 시간을 Retry-After 헤더(초)로 알려준다.
+func loginBlocked(c echo.Context, lang string, err error) error {
+	var blocked *auth.LoginBlockedError
+	if errors.As(err, &blocked) {
+		seconds := int(math.Ceil(blocked.RetryAfter.Seconds()))
+		c.Response().Header().Set("Retry-After", strconv.Itoa(seconds))
+	}
+	return sessionFail(c, lang, err)
+}
+
 // @Summary 로그아웃
-// @Description 현재 사용 중인 JWT 토큰을 무효화하여 즉시 로그아웃 처리합니다.
+// @Description 현재 사용 중인 JWT 토큰과 로그인 세션(refresh token)을 무효화하고, 이 세션으로 연결된 WebSocket을 끊습니다.
 // @Tags Auth
 // @Security ApiKeyAuth
 // @Accept json
@@ -185,5 +227,18 @@ func Logout(c echo.Context) error {
 		))
 	}
 
+	// 로그인 세션 종료 (refresh token도 더 이상 사용할 수 없음)
+	if sessionID, _ := c.Get("sessionID").(string); sessionID != "" {
+		userID := c.Get("userID").(string)
+		err := auth.RevokeSession(c.Request().Context(), userID, sessionID, auth.RevokeReasonLogout)
+		if err != nil && err.Error() != resp.ErrorCodeAuthSessionNotFound {
+			log.Logger.Errorf("Logout - Failed to revoke session %s: %v", sessionID, err)
+			return c.JSON(http.StatusInternalServerError, resp.Fail(resp.ErrorCodeAuthLogoutFailed, lang,
+				resp.ErrorDetail{},
+			))
+		}
+		ws.DisconnectAuthSessions(userID, []string{sessionID})
+	}
+
 	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeUserLogout, nil, lang))
 }
@@ -0,0 +1,318 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Ryeom/board-game/internal/domain/dm"
+	"github.com/Ryeom/board-game/internal/domain/friend"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/service"
+	"github.com/Ryeom/board-game/internal/util"
+	"github.com/Ryeom/board-game/server/ws"
+	"github.com/labstack/echo/v4"
+)
+
+type FriendTargetRequest struct {
+	UserID string `json:"userId"` // 대상 사용자 ID
+}
+
+type FriendListResult struct {
+	Friends []service.FriendEntry `json:"friends"`
+}
+
+type FriendRequestResult struct {
+	UserID string        `json:"userId"`
+	Status friend.Status `json:"status"` // pending: 요청 보냄, accepted: 상대 요청이 있어 바로 친구가 됨
+}
+
+type DirectMessagesResult struct {
+	UserID     string        `json:"userId"`
+	History    []*dm.Message `json:"history"`
+	NextCursor string        `json:"nextCursor,omitempty"`
+}
+
+// socialFail 에러 코드에 정의된 HTTP 상태로 실패 응답
+func socialFail(c echo.Context, lang string, err error) error {
+	status := http.StatusInternalServerError
+	if def, ok := resp.GetDefineCode(err.Error(), lang); ok && def.HttpStatus != 0 {
+		status = def.HttpStatus
+	}
+	return c.JSON(status, resp.Fail(err.Error(), lang,
+		resp.ErrorDetail{},
+	))
+}
+
+// GetFriends - 친구 목록 조회
+// @Summary 친구 목록 조회
+// @Description 친구 목록과 접속 상태(online/away/offline), 방 참여 여부, 안 읽은 메시지 수를 조회합니다. 접속 중인 친구가 먼저 나옵니다.
+// @Tags Friend
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} HttpResult{data=FriendListResult} "친구 목록 조회 성공"
+// @Router /board-game/api/friends [get]
+func GetFriends(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	userID := c.Get("userID").(string)
+
+	friends, err := ws.GlobalSocialService.Friends(c.Request().Context(), userID)
+	if err != nil {
+		return socialFail(c, lang, err)
+	}
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeFriendList, FriendListResult{Friends: friends}, lang))
+}
+
+// GetFriendRequests - 친구 요청 목록 조회
+// @Summary 친구 요청 목록 조회
+// @Description 받은 요청(incoming)과 보낸 요청(outgoing)을 최근 순으로 조회합니다.
+// @Tags Friend
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} HttpResult{data=service.FriendRequests} "친구 요청 목록 조회 성공"
+// @Router /board-game/api/friends/requests [get]
+func GetFriendRequests(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	userID := c.Get("userID").(string)
+
+	requests, err := ws.GlobalSocialService.Requests(c.Request().Context(), userID)
+	if err != nil {
+		return socialFail(c, lang, err)
+	}
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeFriendRequestList, requests, lang))
+}
+
+// SendFriendRequest - 친구 요청
+// @Summary 친구 요청
+// @Description 상대에게 친구 요청을 보냅니다. 상대가 먼저 요청해 둔 상태면 바로 친구가 됩니다. 접속 중인 상대에게는 friend.request(또는 friend.accepted) 이벤트가 전달됩니다.
+// @Tags Friend
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body FriendTargetRequest true "요청할 사용자"
+// @Success 200 {object} HttpResult{data=FriendRequestResult} "친구 요청 성공"
+// @Failure 400 {object} HttpResult "잘못된 대상"
+// @Failure 403 {object} HttpResult "차단된 상대"
+// @Failure 409 {object} HttpResult "이미 친구이거나 요청을 보낸 상태"
+// @Router /board-game/api/friends/requests [post]
+func SendFriendRequest(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	userID := c.Get("userID").(string)
+
+	var req FriendTargetRequest
+	if err := c.Bind(&req); err != nil || req.UserID == "" {
+		return c.JSON(http.StatusBadRequest, resp.Fail(resp.ErrorCodeFriendInvalidTarget, lang,
+			resp.ErrorDetail{},
+		))
+	}
+
+	status, err := ws.GlobalSocialService.RequestFriend(c.Request().Context(), userID, req.UserID)
+	if err != nil {
+		return socialFail(c, lang, err)
+	}
+	code := resp.SuccessCodeFriendRequest
+	if status == friend.StatusAccepted {
+		code = resp.SuccessCodeFriendAccept
+	}
+	return c.JSON(http.StatusOK, resp.Success(code, FriendRequestResult{UserID: req.UserID, Status: status}, lang))
+}
+
+// AcceptFriendRequest - 친구 요청 수락
+// @Summary 친구 요청 수락
+// @Tags Friend
+// @Security ApiKeyAuth
+// @Produce json
+// @Param userId path string true "요청을 보낸 사용자 ID"
+// @Success 200 {object} HttpResult "수락 성공"
+// @Failure 404 {object} HttpResult "받은 요청 없음"
+// @Router /board-game/api/friends/requests/{userId}/accept [post]
+func AcceptFriendRequest(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	userID := c.Get("userID").(string)
+
+	if err := ws.GlobalSocialService.AcceptFriend(c.Request().Context(), userID, c.Param("userId")); err != nil {
+		return socialFail(c, lang, err)
+	}
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeFriendAccept, nil, lang))
+}
+
+// DeclineFriendRequest - 친구 요청 거절/취소
+// @Summary 친구 요청 거절/취소
+// @Description 받은 요청은 거절하고, 보낸 요청은 취소합니다. 상대에게 알리지 않습니다.
+// @Tags Friend
+// @Security ApiKeyAuth
+// @Produce json
+// @Param userId path string true "상대 사용자 ID"
+// @Success 200 {object} HttpResult "거절/취소 성공"
+// @Failure 404 {object} HttpResult "요청 없음"
+// @Router /board-game/api/friends/requests/{userId} [delete]
+func DeclineFriendRequest(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	userID := c.Get("userID").(string)
+
+	if err := ws.GlobalSocialService.DeclineFriend(c.Request().Context(), userID, c.Param("userId")); err != nil {
+		return socialFail(c, lang, err)
+	}
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeFriendDecline, nil, lang))
+}
+
+// RemoveFriend - 친구 삭제
+// @Summary 친구 삭제
+// @Description 친구 관계를 끊습니다. 접속 중인 상대에게 friend.removed 이벤트가 전달됩니다.
+// @Tags Friend
+// @Security ApiKeyAuth
+// @Produce json
+// @Param userId path string true "친구 사용자 ID"
+// @Success 200 {object} HttpResult "삭제 성공"
+// @Failure 404 {object} HttpResult "친구가 아님"
+// @Router /board-game/api/friends/{userId} [delete]
+func RemoveFriend(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	userID := c.Get("userID").(string)
+
+	if err := ws.GlobalSocialService.RemoveFriend(c.Request().Context(), userID, c.Param("userId")); err != nil {
+		return socialFail(c, lang, err)
+	}
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeFriendRemove, nil, lang))
+}
+
+// GetBlockedUsers - 차단 목록 조회
+// @Summary 차단 목록 조회
+// @Tags Friend
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} HttpResult{data=FriendListResult} "차단 목록 조회 성공"
+// @Router /board-game/api/blocks [get]
+func GetBlockedUsers(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	userID := c.Get("userID").(string)
+
+	blocked, err := ws.GlobalSocialService.Blocked(c.Request().Context(), userID)
+	if err != nil {
+		return socialFail(c, lang, err)
+	}
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeFriendBlockList, FriendListResult{Friends: blocked}, lang))
+}
+
+// BlockUser - 사용자 차단
+// @Summary 사용자 차단
+// @Description 친구 관계와 주고받은 요청을 지우고, 차단한 동안 서로 친구 요청을 보낼 수 없습니다. 상대에게 알리지 않습니다.
+// @Tags Friend
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body FriendTargetRequest true "차단할 사용자"
+// @Success 200 {object} HttpResult "차단 성공"
+// @Router /board-game/api/blocks [post]
+func BlockUser(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	userID := c.Get("userID").(string)
+
+	var req FriendTargetRequest
+	if err := c.Bind(&req); err != nil || req.UserID == "" {
+		return c.JSON(http.StatusBadRequest, resp.Fail(resp.ErrorCodeFriendInvalidTarget, lang,
+			resp.ErrorDetail{},
+		))
+	}
+	if err := ws.GlobalSocialService.Block(c.Request().Context(), userID, req.UserID); err != nil {
+		return socialFail(c, lang, err)
+	}
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeFriendBlock, nil, lang))
+}
+
+// UnblockUser - 차단 해제
+// @Summary 차단 해제
+// @Tags Friend
+// @Security ApiKeyAuth
+// @Produce json
+// @Param userId path string true "차단한 사용자 ID"
+// @Success 200 {object} HttpResult "차단 해제 성공"
+// @Failure 404 {object} HttpResult "차단하지 않은 사용자"
+// @Router /board-game/api/blocks/{userId} [delete]
+func UnblockUser(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	userID := c.Get("userID").(string)
+
+	if err := ws.GlobalSocialService.Unblock(c.Request().Context(), userID, c.Param("userId")); err != nil {
+		return socialFail(c, lang, err)
+	}
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeFriendUnblock, nil, lang))
+}
+
+// GetUnreadCounts - 안 읽은 메시지 수
+// @Summary 안 읽은 메시지 수
+// @Description 보낸 사람 ID별 안 읽은 메시지 수. 안 읽은 메시지가 없는 상대는 빠집니다.
+// @Tags DirectMessage
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} HttpResult{data=object{unread=map[string]int}} "조회 성공"
+// @Router /board-game/api/dm/unread [get]
+func GetUnreadCounts(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	userID := c.Get("userID").(string)
+
+	counts, err := ws.GlobalSocialService.UnreadCounts(c.Request().Context(), userID)
+	if err != nil {
+		return socialFail(c, lang, err)
+	}
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeDMUnread, map[string]any{"unread": counts}, lang))
+}
+
+// GetDirectMessages - 대화 내역 조회
+// @Summary 대화 내역 조회
+// @Description 상대와 주고받은 메시지를 오래된 순으로 한 페이지 조회합니다. dm.history 이벤트와 같은 커서 조건을 사용합니다.
+// @Tags DirectMessage
+// @Security ApiKeyAuth
+// @Produce json
+// @Param userId path string true "상대 사용자 ID"
+// @Param before query string false "이전 응답의 nextCursor 또는 RFC3339 시각"
+// @Param limit query int false "페이지 크기 (기본 50, 최대 100)"
+// @Success 200 {object} HttpResult{data=DirectMessagesResult} "조회 성공"
+// @Failure 400 {object} HttpResult "잘못된 조회 조건"
+// @Router /board-game/api/dm/{userId} [get]
+func GetDirectMessages(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	userID := c.Get("userID").(string)
+	other := c.Param("userId")
+
+	query := dm.HistoryQuery{Before: c.QueryParam("before")}
+	if v := c.QueryParam("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, resp.Fail(resp.ErrorCodeDMInvalidQuery, lang,
+				resp.ErrorDetail{},
+			))
+		}
+		query.Limit = limit
+	}
+
+	messages, nextCursor, err := ws.GlobalSocialService.DirectMessages(c.Request().Context(), userID, other, query)
+	if err != nil {
+		return socialFail(c, lang, err)
+	}
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeDMHistory, DirectMessagesResult{
+		UserID:     other,
+		History:    messages,
+		NextCursor: nextCursor,
+	}, lang))
+}
+
+// MarkDirectMessagesRead - 읽음 처리
+// @Summary 읽음 처리
+// @Description 상대에게서 받은 안 읽은 메시지를 모두 읽음 처리합니다.
+// @Tags DirectMessage
+// @Security ApiKeyAuth
+// @Produce json
+// @Param userId path string true "상대 사용자 ID"
+// @Success 200 {object} HttpResult{data=object{userId=string,read=int}} "읽음 처리 성공"
+// @Router /board-game/api/dm/{userId}/read [post]
+func MarkDirectMessagesRead(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	userID := c.Get("userID").(string)
+	other := c.Param("userId")
+
+	n, err := ws.GlobalSocialService.MarkRead(c.Request().Context(), userID, other)
+	if err != nil {
+		return socialFail(c, lang, err)
+	}
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeDMRead, map[string]any{"userId": other, "read": n}, lang))
+}
@@ -1 +1,32 @@
 package http
+
+import (
+	"net/http"
+
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/Ryeom/board-game/internal/util"
+	"github.com/Ryeom/board-game/log"
+	"github.com/labstack/echo/v4"
+)
+
+// adminOnly 관리자 API. 토큰의 role 클레임이 아니라 DB 계정의 권한으로 판단한다. (JWTMiddleware 뒤에 사용)
+func adminOnly(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		lang := util.GetUserLanguage(c)
+		userID, _ := c.Get("userID").(string)
+		u, err := user.FindUserByID(userID)
+		if err != nil {
+			log.Logger.Errorf("adminOnly - FindUserByID Error for ID %s: %v", userID, err)
+			return c.JSON(http.StatusInternalServerError, resp.Fail(resp.ErrorCodeAuthUserLookupFailed, lang,
+				resp.ErrorDetail{},
+			))
+		}
+		if u == nil || !u.IsActive || !user.IsAdmin(u) {
+			return c.JSON(http.StatusForbidden, resp.Fail(resp.ErrorCodeDefaultForbidden, lang,
+				resp.ErrorDetail{},
+			))
+		}
+		return next(c)
+	}
+}
@@ -0,0 +1,128 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Ryeom/board-game/internal/domain/room"
+	"github.com/Ryeom/board-game/internal/game"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/service"
+	"github.com/Ryeom/board-game/internal/util"
+	"github.com/Ryeom/board-game/server/ws"
+	"github.com/labstack/echo/v4"
+)
+
+type RoomListResult struct {
+	Rooms      []room.Summary `json:"rooms"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+}
+
+// GetRoomList - 방 목록 조회
+// @Summary 방 목록 조회
+// @Description WebSocket 연결 전 로비 화면용 방 목록. room.list 이벤트와 같은 필터/정렬/커서 조건을 사용합니다.
+// @Tags Room
+// @Security ApiKeyAuth
+// @Produce json
+// @Param gameMode query string false "게임 모드 (hanabi, 6nimmt, tile_push)"
+// @Param hasPassword query bool false "true: 비밀번호 방만, false: 공개 방만"
+// @Param joinable query bool false "시작 전이고 자리가 남은 방만"
+// @Param notStarted query bool false "게임이 시작되지 않은 방만"
+// @Param search query string false "방 이름 검색"
+// @Param sort query string false "newest(기본), most_players"
+// @Param cursor query string false "이전 응답의 nextCursor"
+// @Param limit query int false "페이지 크기 (기본 20, 최대 100)"
+// @Success 200 {object} HttpResult{data=RoomListResult} "방 목록 조회 성공"
+// @Failure 400 {object} HttpResult "잘못된 조회 조건"
+// @Router /board-game/api/rooms [get]
+func GetRoomList(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+
+	query, ok := parseRoomListQuery(c)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, resp.Fail(resp.ErrorCodeRoomListInvalidQuery, lang,
+			resp.ErrorDetail{},
+		))
+	}
+
+	rooms, nextCursor, err := ws.GlobalRoomService.QueryRoomList(c.Request().Context(), query)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resp.Fail(err.Error(), lang,
+			resp.ErrorDetail{},
+		))
+	}
+
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeRoomListFetch, RoomListResult{
+		Rooms:      room.Summaries(rooms),
+		NextCursor: nextCursor,
+	}, lang))
+}
+
+func parseRoomListQuery(c echo.Context) (room.ListQuery, bool) {
+	query := room.ListQuery{
+		GameMode: game.Mode(c.QueryParam("gameMode")),
+		Search:   c.QueryParam("search"),
+		Sort:     c.QueryParam("sort"),
+		Cursor:   c.QueryParam("cursor"),
+	}
+
+	var err error
+	if v := c.QueryParam("hasPassword"); v != "" {
+		var hasPassword bool
+		if hasPassword, err = strconv.ParseBool(v); err != nil {
+			return query, false
+		}
+		query.HasPassword = &hasPassword
+	}
+	if v := c.QueryParam("joinable"); v != "" {
+		if query.Joinable, err = strconv.ParseBool(v); err != nil {
+			return query, false
+		}
+	}
+	if v := c.QueryParam("notStarted"); v != "" {
+		if query.NotStarted, err = strconv.ParseBool(v); err != nil {
+			return query, false
+		}
+	}
+	if v := c.QueryParam("limit"); v != "" {
+		if query.Limit, err = strconv.Atoi(v); err != nil {
+			return query, false
+		}
+	}
+	return query, true
+}
+
+type InvitePreviewResult struct {
+	service.InvitePreview
+	Link string `json:"link"`
+}
+
+// GetInvitePreview - 초대 코드 미리보기
+// @Summary 초대 코드 미리보기
+// @Description 초대 링크로 들어온 유저에게 입장 전 방 정보를 보여줍니다. 사용 횟수는 차감하지 않으며, 입장은 room.joinByCode 이벤트로 합니다.
+// @Tags Room
+// @Security ApiKeyAuth
+// @Produce json
+// @Param code path string true "초대 코드"
+// @Success 200 {object} HttpResult{data=InvitePreviewResult} "초대 정보 조회 성공"
+// @Failure 404 {object} HttpResult "만료되었거나 폐기된 코드"
+// @Router /board-game/api/invites/{code} [get]
+func GetInvitePreview(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+
+	preview, err := ws.GlobalRoomService.PreviewInvite(c.Request().Context(), c.Param("code"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == resp.ErrorCodeInviteNotFound {
+			status = http.StatusNotFound
+		}
+		return c.JSON(status, resp.Fail(err.Error(), lang,
+			resp.ErrorDetail{},
+		))
+	}
+
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeInvitePreview, InvitePreviewResult{
+		InvitePreview: *preview,
+		Link:          ws.InviteLink(preview.Code),
+	}, lang))
+}
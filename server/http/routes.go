@@ -18,16 +18,47 @@ func InitializeRouter(e *echo.Echo) {
 		{
 			authGroup.POST("/signup", SignUp)
 			authGroup.POST("/login", Login)
+			authGroup.POST("/refresh", RefreshToken)
+			authGroup.POST("/password-reset/request", RequestPasswordReset)
+			authGroup.POST("/password-reset/confirm", ConfirmPasswordReset)
+			authGroup.POST("/verify-email", VerifyEmail)
 		}
 
 		apiGroup := bg.Group("/api")
 		apiGroup.Use(auth.JWTMiddleware)
 		{
 			apiGroup.POST("/auth/logout", Logout)
+			apiGroup.GET("/auth/sessions", GetSessions)
+			apiGroup.DELETE("/auth/sessions", RevokeAllSessions)
+			apiGroup.DELETE("/auth/sessions/:sessionId", RevokeSession)
+			apiGroup.POST("/auth/verify-email/resend", ResendEmailVerification)
 
 			apiGroup.GET("/user/profile", GetUserProfile)
 			apiGroup.PATCH("/user/profile", UpdateUserProfile)
 			apiGroup.POST("/user/change-password", ChangePassword)
+			apiGroup.GET("/user/export", ExportUserData)
+			apiGroup.DELETE("/user", DeleteAccount)
+
+			apiGroup.GET("/rooms", GetRoomList)
+			apiGroup.GET("/invites/:code", GetInvitePreview)
+
+			apiGroup.GET("/friends", GetFriends)
+			apiGroup.DELETE("/friends/:userId", RemoveFriend)
+			apiGroup.GET("/friends/requests", GetFriendRequests)
+			apiGroup.POST("/friends/requests", SendFriendRequest)
+			apiGroup.POST("/friends/requests/:userId/accept", AcceptFriendRequest)
+			apiGroup.DELETE("/friends/requests/:userId", DeclineFriendRequest)
+			apiGroup.GET("/blocks", GetBlockedUsers)
+			apiGroup.POST("/blocks", BlockUser)
+			apiGroup.DELETE("/blocks/:userId", UnblockUser)
+
+			apiGroup.GET("/dm/unread", GetUnreadCounts)
+			apiGroup.GET("/dm/:userId", GetDirectMessages)
+			apiGroup.POST("/dm/:userId/read", MarkDirectMessagesRead)
+
+			adminGroup := apiGroup.Group("/admin", adminOnly)
+			adminGroup.DELETE("/users/:userId/login-lock", UnlockUserLogin)
+			adminGroup.POST("/users/:userId/anonymize", AnonymizeDeletedUser)
 
 		}
 	}
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Ryeom/board-game/internal/auth"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/util"
+	"github.com/Ryeom/board-game/log"
+	"github.com/Ryeom/board-game/server/ws"
+	"github.com/labstack/echo/v4"
+)
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken" validate:"required"`
+}
+
+type SessionEntry struct {
+	auth.Session
+	Current bool `json:"current"` // 이 요청의 access token이 속한 세션
+}
+
+type SessionListResult struct {
+	Sessions []SessionEntry `json:"sessions"`
+}
+
+type SessionRevokeResult struct {
+	Revoked      []string `json:"revoked"`      // 종료한 세션 ID
+	Disconnected int      `json:"disconnected"` // 끊은 WebSocket 연결 수
+}
+
+// sessionFail 에러 코드에 정의된 HTTP 상태로 실패 응답
+func sessionFail(c echo.Context, lang string, err error) error {
+	status := http.StatusInternalServerError
+	if def, ok := resp.GetDefineCode(err.Error(), lang); ok && def.HttpStatus != 0 {
+		status = def.HttpStatus
+	}
+	return c.JSON(status, resp.Fail(err.Error(), lang,
+		resp.ErrorDetail{},
+	))
+}
+
+// RefreshToken - 토큰 갱신
+// @Summary 토큰 갱신
+// @Description refresh token으로 새 access token과 refresh token을 발급합니다. refresh token은 한 번만 사용할 수 있으며, 이미 사용된 토큰이 다시 오면 탈취로 보고 해당 세션을 종료하고 연결된 WebSocket을 끊습니다.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body RefreshRequest true "토큰 갱신 요청"
+// @Success 200 {object} HttpResult{data=auth.TokenPair} "토큰 갱신 성공"
+// @Failure 400 {object} HttpResult "잘못된 요청 형식"
+// @Failure 401 {object} HttpResult "유효하지 않거나 재사용된 refresh token"
+// @Failure 500 {object} HttpResult "서버 오류"
+// @Router /board-game/auth/refresh [post]
+func RefreshToken(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	var req RefreshRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resp.Fail(resp.ErrorCodeAuthBind, lang,
+			resp.ErrorDetail{},
+		))
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resp.Fail(resp.ErrorCodeAuthValidation, lang,
+			resp.ErrorDetail{},
+		))
+	}
+
+	pair, err := auth.Refresh(c.Request().Context(), req.RefreshToken, c.Request().UserAgent(), c.RealIP())
+	if err != nil {
+		var reused *auth.ReuseError
+		if errors.As(err, &reused) {
+			ws.DisconnectAuthSessions(reused.UserID, []string{reused.SessionID})
+		}
+		return sessionFail(c, lang, err)
+	}
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeAuthRefresh, pair, lang))
+}
+
+// GetSessions - 로그인한 기기 목록
+// @Summary 로그인한 기기 목록
+// @Description 종료되지 않은 로그인 세션을 최근 사용 순으로 조회합니다. current는 이 요청을 보낸 세션입니다.
+// @Tags Auth
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} HttpResult{data=SessionListResult} "세션 목록 조회 성공"
+// @Router /board-game/api/auth/sessions [get]
+func GetSessions(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	userID := c.Get("userID").(string)
+	currentID, _ := c.Get("sessionID").(string)
+
+	sessions, err := auth.ActiveSessions(c.Request().Context(), userID)
+	if err != nil {
+		return sessionFail(c, lang, err)
+	}
+	entries := make([]SessionEntry, 0, len(sessions))
+	for _, s := range sessions {
+		entries = append(entries, SessionEntry{Session: s, Current: s.ID == currentID})
+	}
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeAuthSessionList, SessionListResult{Sessions: entries}, lang))
+}
+
+// RevokeSession - 로그인 세션 종료
+// @Summary 로그인 세션 종료
+// @Description 세션 하나를 종료합니다. 해당 세션의 refresh token과 access token을 더 이상 사용할 수 없고, 그 세션으로 연결된 WebSocket이 끊깁니다.
+// @Tags Auth
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "세션 ID"
+// @Success 200 {object} HttpResult{data=SessionRevokeResult} "세션 종료 성공"
+// @Failure 404 {object} HttpResult "없거나 이미 종료된 세션"
+// @Router /board-game/api/auth/sessions/{sessionId} [delete]
+func RevokeSession(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	userID := c.Get("userID").(string)
+	sessionID := c.Param("sessionId")
+
+	if err := auth.RevokeSession(c.Request().Context(), userID, sessionID, auth.RevokeReasonUser); err != nil {
+		return sessionFail(c, lang, err)
+	}
+	revoked := []string{sessionID}
+	disconnected := ws.DisconnectAuthSessions(userID, revoked)
+	log.Logger.Infof("RevokeSession - User %s revoked session %s (%d connections closed)", userID, sessionID, disconnected)
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeAuthSessionRevoke, SessionRevokeResult{
+		Revoked:      revoked,
+		Disconnected: disconnected,
+	}, lang))
+}
+
+// RevokeAllSessions - 모든 기기에서 로그아웃
+// @Summary 모든 기기에서 로그아웃
+// @Description 이 요청을 보낸 세션을 포함한 모든 로그인 세션을 종료하고, 유저의 WebSocket 연결을 모두 끊습니다.
+// @Tags Auth
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} HttpResult{data=SessionRevokeResult} "세션 종료 성공"
+// @Router /board-game/api/auth/sessions [delete]
+func RevokeAllSessions(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	userID := c.Get("userID").(string)
+
+	revoked, err := auth.RevokeAllSessions(c.Request().Context(), userID, auth.RevokeReasonUser)
+	if err != nil {
+		return sessionFail(c, lang, err)
+	}
+	if revoked == nil {
+		revoked = []string{}
+	}
+	disconnected := ws.DisconnectAuthSessions(userID, nil)
+	log.Logger.Infof("RevokeAllSessions - User %s revoked %d sessions (%d connections closed)", userID, len(revoked), disconnected)
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeAuthSessionRevoke, SessionRevokeResult{
+		Revoked:      revoked,
+		Disconnected: disconnected,
+	}, lang))
+}
@@ -1,11 +1,15 @@
 package http
 
 import (
+	"fmt"
 	"github.com/Ryeom/board-game/infra/db"
+	"github.com/Ryeom/board-game/internal/auth"
 	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/service"
 	"github.com/Ryeom/board-game/internal/user"
 	"github.com/Ryeom/board-game/internal/util"
 	"github.com/Ryeom/board-game/log"
+	"github.com/Ryeom/board-game/server/ws"
 	"github.com/labstack/echo/v4"
 	"net/http"
 )
@@ -17,7 +21,7 @@ import (
 // @Security ApiKeyAuth
 // @Accept json
 // @Produce json
-// @Success 200 {object} HttpResult{data=object{userId=string,email=string,nickname=string,profileImage=string,role=string,isActive=bool,lastLoginAt=string}} "사용자 프로필 조회 성공"
+// @Success 200 {object} HttpResult{data=object{userId=string,email=string,nickname=string,profileImage=string,role=string,isActive=bool,emailVerified=bool,lastLoginAt=string}} "사용자 프로필 조회 성공"
 // @Failure 401 {object} HttpResult "인증되지 않은 사용자"
 // @Failure 404 {object} HttpResult "사용자를 찾을 수 없음"
 // @Failure 500 {object} HttpResult "서버 오류"
@@ -47,15 +51,16 @@ func GetUserProfile(c echo.Context) error {
 
 	// 비밀번호와 같은 민감 정보는 제외하고 반환
 	data := map[string]interface{}{
-		"userId":       u.ID.String(),
-		"email":        u.Email,
-		"nickname":     u.Nickname,
-		"profileImage": u.ProfileImage,
-		"role":         u.Role,
-		"isActive":     u.IsActive,
-		"lastLoginAt":  u.LastLoginAt,
-		"createdAt":    u.CreatedAt,
-		"updatedAt":    u.UpdatedAt,
+		"userId":        u.ID.String(),
+		"email":         u.Email,
+		"nickname":      u.Nickname,
+		"profileImage":  u.ProfileImage,
+		"role":          u.Role,
+		"isActive":      u.IsActive,
+		"emailVerified": u.EmailVerified(),
+		"lastLoginAt":   u.LastLoginAt,
+		"createdAt":     u.CreatedAt,
+		"updatedAt":     u.UpdatedAt,
 	}
 
 	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeUserProfileGet, data, lang))
@@ -222,3 +227,131 @@ func ChangePassword(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeUserPasswordChange, nil, lang))
 }
+
+// ExportUserData - 개인 정보 내보내기
+// @Summary 개인 정보 내보내기
+// @Description 로그인한 사용자의 프로필, 전적, 보낸 방 채팅과 1:1 메시지를 하나의 JSON 파일로 내려받습니다.
+// @Tags User
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} HttpResult{data=service.UserExport} "개인 정보 내보내기 성공"
+// @Failure 401 {object} HttpResult "인증되지 않은 사용자"
+// @Failure 404 {object} HttpResult "사용자를 찾을 수 없음"
+// @Failure 500 {object} HttpResult "서버 오류"
+// @Router /board-game/api/user/export [get]
+func ExportUserData(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	userID, ok := c.Get("userID").(string)
+	if !ok || userID == "" {
+		log.Logger.Error("ExportUserData - UserID not found in context")
+		return c.JSON(http.StatusUnauthorized, resp.Fail(resp.ErrorCodeUserUnauthorized, lang,
+			resp.ErrorDetail{},
+		))
+	}
+
+	u, err := user.FindUserByID(userID)
+	if err != nil {
+		log.Logger.Errorf("ExportUserData - FindUserByID Error for ID %s: %v", userID, err)
+		return c.JSON(http.StatusInternalServerError, resp.Fail(resp.ErrorCodeUserProfileFetchFailed, lang,
+			resp.ErrorDetail{},
+		))
+	}
+	if u == nil {
+		return c.JSON(http.StatusNotFound, resp.Fail(resp.ErrorCodeUserNotFound, lang,
+			resp.ErrorDetail{},
+		))
+	}
+
+	export, err := service.ExportUserData(c.Request().Context(), u)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resp.Fail(err.Error(), lang,
+			resp.ErrorDetail{},
+		))
+	}
+
+	// 브라우저에서 열면 파일로 저장되도록
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="board-game-export-%s.json"`, userID))
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeUserDataExport, export, lang))
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" validate:"required"` // 본인 확인용 현재 비밀번호
+}
+
+// DeleteAccount - 회원 탈퇴
+// @Summary 회원 탈퇴
+// @Description 현재 비밀번호를 확인한 뒤 계정을 비활성화합니다. 보낸 방 채팅과 1:1 메시지, 전적의 이름은 "탈퇴한 사용자"로 익명화되고 모든 로그인 세션과 WebSocket 연결이 종료됩니다. 탈퇴한 계정으로는 다시 로그인할 수 없습니다.
+// @Tags User
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body DeleteAccountRequest true "회원 탈퇴 요청"
+// @Success 200 {object} HttpResult "회원 탈퇴 성공"
+// @Success 202 {object} HttpResult "탈퇴는 완료되었으나 익명화 일부가 실패함 (관리자가 다시 실행)"
+// @Failure 400 {object} HttpResult "잘못된 요청 형식 또는 유효성 검사 실패"
+// @Failure 401 {object} HttpResult "인증되지 않은 사용자 또는 비밀번호 불일치"
+// @Failure 404 {object} HttpResult "사용자를 찾을 수 없음"
+// @Failure 500 {object} HttpResult "서버 오류"
+// @Router /board-game/api/user [delete]
+func DeleteAccount(c echo.Context) error {
+	lang := util.GetUserLanguage(c)
+	userID, ok := c.Get("userID").(string)
+	if !ok || userID == "" {
+		log.Logger.Error("DeleteAccount - UserID not found in context")
+		return c.JSON(http.StatusUnauthorized, resp.Fail(resp.ErrorCodeUserUnauthorized, lang,
+			resp.ErrorDetail{},
+		))
+	}
+
+	var req DeleteAccountRequest
+	if err := c.Bind(&req); err != nil {
+		log.Logger.Errorf("DeleteAccount - Bind Error: %v", err)
+		return c.JSON(http.StatusBadRequest, resp.Fail(resp.ErrorCodeUserDeleteValidationFailed, lang,
+			resp.ErrorDetail{},
+		))
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resp.Fail(resp.ErrorCodeUserDeleteValidationFailed, lang,
+			resp.ErrorDetail{},
+		))
+	}
+
+	u, err := user.FindUserByID(userID)
+	if err != nil {
+		log.Logger.Errorf("DeleteAccount - FindUserByID Error for ID %s: %v", userID, err)
+		return c.JSON(http.StatusInternalServerError, resp.Fail(resp.ErrorCodeUserDeleteFailed, lang,
+			resp.ErrorDetail{},
+		))
+	}
+	if u == nil {
+		return c.JSON(http.StatusNotFound, resp.Fail(resp.ErrorCodeUserNotFound, lang,
+			resp.ErrorDetail{},
+		))
+	}
+	if !util.CheckPasswordHash(req.Password, u.Password) {
+		return c.JSON(http.StatusUnauthorized, resp.Fail(resp.ErrorCodeUserCurrentPasswordMismatch, lang,
+			resp.ErrorDetail{},
+		))
+	}
+
+	if err := user.DeactivateUser(db.DB, userID); err != nil {
+		log.Logger.Errorf("DeleteAccount - DeactivateUser Error for ID %s: %v", userID, err)
+		return c.JSON(http.StatusInternalServerError, resp.Fail(resp.ErrorCodeUserDeleteFailed, lang,
+			resp.ErrorDetail{},
+		))
+	}
+	// 비활성화되면 익명화 결과와 관계없이 토큰과 연결을 바로 끊는다.
+	if _, err := auth.RevokeAllSessions(c.Request().Context(), userID, auth.RevokeReasonAccountDelete); err != nil {
+		log.Logger.Errorf("DeleteAccount - Failed to revoke sessions for %s: %v", userID, err)
+	}
+	disconnected := ws.DisconnectAuthSessions(userID, nil)
+	log.Logger.Infof("DeleteAccount - User %s deleted their account (%d connections closed)", userID, disconnected)
+
+	// 익명화가 실패해도 탈퇴는 완료된 것이므로 202로 알리고, 관리자가 POST /admin/users/:userId/anonymize로 다시 실행한다.
+	if err := user.RunDeletionHooks(c.Request().Context(), userID); err != nil {
+		log.Logger.Errorf("DeleteAccount - Anonymization incomplete for deleted user %s: %v", userID, err)
+		return c.JSON(http.StatusAccepted, resp.Success(resp.SuccessCodeUserDeletePending, nil, lang))
+	}
+
+	return c.JSON(http.StatusOK, resp.Success(resp.SuccessCodeUserDelete, nil, lang))
+}
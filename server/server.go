@@ -4,10 +4,15 @@ import (
 	"context"
 	_ "github.com/Ryeom/board-game/docs" // swagger docs import
 	"github.com/Ryeom/board-game/infra/db"
+	"github.com/Ryeom/board-game/infra/mail"
 	"github.com/Ryeom/board-game/infra/mongo"
 	redisutil "github.com/Ryeom/board-game/infra/redis"
 	"github.com/Ryeom/board-game/internal/auth"
+	"github.com/Ryeom/board-game/internal/domain/friend"
+	"github.com/Ryeom/board-game/internal/domain/room"
+	"github.com/Ryeom/board-game/internal/domain/tilepush"
 	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/service"
 	"github.com/Ryeom/board-game/internal/user"
 	"github.com/Ryeom/board-game/internal/util"
 	l "github.com/Ryeom/board-game/log"
@@ -17,6 +22,7 @@ import (
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/spf13/viper"
 	echoSwagger "github.com/swaggo/echo-swagger"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -25,6 +31,7 @@ import (
 func Initialize(e *echo.Echo) {
 
 	e.Validator = util.NewValidator()
+	e.IPExtractor = ipExtractor()
 	allowedOrigins := viper.GetString("server.allowed-origins")
 	if allowedOrigins == "" || allowedOrigins == "*" {
 		e.Use(middleware.CORS())
@@ -42,12 +49,42 @@ func Initialize(e *echo.Echo) {
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 	redisutil.Initialize()
 	db.Initialize()
+	// 친구 테이블은 별도 마이그레이션 도구 없이 시작 시 생성
+	if err := friend.Migrate(db.DB); err != nil {
+		l.Logger.Fatalf("Failed to migrate friendships table: %v", err)
+	}
+	if err := user.Migrate(db.DB); err != nil {
+		l.Logger.Fatalf("Failed to migrate users table: %v", err)
+	}
+	if err := auth.MigrateSessions(db.DB); err != nil {
+		l.Logger.Fatalf("Failed to migrate auth session tables: %v", err)
+	}
 	appHttp.InitializeRouter(e)
 	auth.Initialize()
+	mail.Initialize()
+	service.AccountMail = service.AccountMailConfig{
+		LinkBase:  viper.GetString("bg.mail.link-base"),
+		ResetTTL:  viper.GetDuration("bg.auth.password-reset-ttl"),
+		VerifyTTL: viper.GetDuration("bg.auth.email-verify-ttl"),
+	}
 	mongo.Initialize()
+	// 탈퇴(DELETE /api/user → user.RunDeletionHooks) 시 방 채팅, 1:1 메시지, 전적을 익명화
+	user.OnSoftDelete(service.AnonymizeUserData)
 
 	ctx := context.Background()
 
+	// 목록 인덱스 도입 전 저장된 방이 있으면 인덱스 생성
+	if rebuilt, err := room.RebuildIndexes(ctx); err != nil {
+		l.Logger.Errorf("Failed to rebuild room indexes: %v", err)
+	} else if rebuilt > 0 {
+		l.Logger.Infof("Rebuilt room indexes for %d rooms", rebuilt)
+	}
+
+	// tile_push 게임은 시작 시 메모리에 올린 타일 세트 중 하나를 사용
+	if err := tilepush.LoadAllTileSetsFromDB(ctx); err != nil {
+		l.Logger.Errorf("Failed to load tile sets: %v", err)
+	}
+
 	// Initialize broadcaster
 	broadcaster := ws.NewRedisBroadcaster(ctx)
 	ws.GlobalBroadcaster = broadcaster
@@ -61,7 +98,32 @@ func Initialize(e *echo.Echo) {
 		return session, typeOk
 	})
 
+	ws.ConfigureGame()
+	ws.ConfigureChat()
+	ws.StartMatchmaker(ctx)
+	ws.StartJanitor(ctx)
+
 }
+
+// ipExtractor c.RealIP()가 돌려줄 클라이언트 IP. server.trusted-proxies 대역에서 온 요청만 X-Forwarded-For를 믿고,
+// 지정하지 않으면 접속한 주소를 그대로 쓴다. (헤더를 위조해 로그인 실패 제한이나 레이트 리밋 버킷을 바꾸지 못하도록)
+func ipExtractor() echo.IPExtractor {
+	proxies := viper.GetStringSlice("server.trusted-proxies")
+	if len(proxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, cidr := range proxies {
+		_, ipRange, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			l.Logger.Warningf("Ignoring invalid server.trusted-proxies entry %q: %v", cidr, err)
+			continue
+		}
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
 func httpErrorHandler(e *echo.Echo) func(err error, c echo.Context) {
 	return func(err error, c echo.Context) {
 		var code string
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/Ryeom/board-game/internal/domain/room"
 	"github.com/Ryeom/board-game/internal/user"
 	"github.com/Ryeom/board-game/log"
 	"time"
@@ -14,6 +15,7 @@ import (
 
 type Broadcaster interface {
 	BroadcastToRoom(roomID string, payload interface{}) error
+	BroadcastToMembers(roomID string, recipients []string, payload interface{}) error
 	SendToPlayer(playerID string, payload interface{}) error
 	SetSessionGetter(getter func(socketID string) (*user.Session, bool))
 }
@@ -46,12 +48,52 @@ func (rb *RedisBroadcaster) SetSessionGetter(getter func(socketID string) (*user
 	rb.sessionGetter = getter
 }
 
+// BroadcastToRoom 방 이벤트에 시퀀스 번호(seq)를 부여하고 재접속 재전송용 버퍼에 저장한 뒤 발행한다.
 func (rb *RedisBroadcaster) BroadcastToRoom(roomID string, payload interface{}) error {
-	msg := map[string]any{
+	data, err := toEventMap(payload)
+	if err != nil {
+		return fmt.Errorf("브로드캐스트 직렬화 실패: %w", err)
+	}
+
+	seq, err := room.NextEventSeq(rb.ctx, roomID)
+	if err != nil {
+		log.Logger.Errorf("BroadcastToRoom - Failed to issue event seq for room %s: %v", roomID, err)
+	} else {
+		data["seq"] = seq
+		if buffered, marshalErr := json.Marshal(data); marshalErr == nil {
+			if bufErr := room.BufferEvent(rb.ctx, roomID, seq, buffered); bufErr != nil {
+				log.Logger.Errorf("BroadcastToRoom - Failed to buffer event %d for room %s: %v", seq, roomID, bufErr)
+			}
+		}
+	}
+
+	return rb.publish(map[string]any{
 		"roomId": roomID,
-		"data":   payload,
+		"seq":    seq,
+		"data":   data,
 		"ts":     time.Now().UnixMilli(),
+	})
+}
+
+// BroadcastToMembers 방 채널을 거치되 recipients에게만 전달한다. (팀/관전자 채팅 등)
+// 받지 못한 유저가 재접속 때 재전송받지 않도록 seq를 부여하거나 버퍼에 저장하지 않는다.
+func (rb *RedisBroadcaster) BroadcastToMembers(roomID string, recipients []string, payload interface{}) error {
+	if len(recipients) == 0 { // 비어 있으면 listen에서 방 전체로 전달되므로 발행하지 않음
+		return nil
+	}
+	data, err := toEventMap(payload)
+	if err != nil {
+		return fmt.Errorf("브로드캐스트 직렬화 실패: %w", err)
 	}
+	return rb.publish(map[string]any{
+		"roomId":     roomID,
+		"recipients": recipients,
+		"data":       data,
+		"ts":         time.Now().UnixMilli(),
+	})
+}
+
+func (rb *RedisBroadcaster) publish(msg map[string]any) error {
 	b, err := json.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("브로드캐스트 직렬화 실패: %w", err)
@@ -85,9 +127,11 @@ func (rb *RedisBroadcaster) SendToPlayer(playerID string, payload interface{}) e
 func (rb *RedisBroadcaster) listen() {
 	for msg := range rb.pubsub.Channel() {
 		var parsed struct {
-			RoomID string         `json:"roomId"`
-			Data   map[string]any `json:"data"`
-			Ts     int64          `json:"ts"`
+			RoomID     string         `json:"roomId"`
+			Seq        int64          `json:"seq"`
+			Recipients []string       `json:"recipients"` // 비어 있으면 방 전체
+			Data       map[string]any `json:"data"`
+			Ts         int64          `json:"ts"`
 		}
 		if err := json.Unmarshal([]byte(msg.Payload), &parsed); err != nil {
 			log.Logger.Error("❌ Redis 메시지 파싱 실패:", err)
@@ -100,15 +144,33 @@ func (rb *RedisBroadcaster) listen() {
 			continue
 		}
 
+		var recipients map[string]bool
+		if len(parsed.Recipients) > 0 {
+			recipients = make(map[string]bool, len(parsed.Recipients))
+			for _, id := range parsed.Recipients {
+				recipients[id] = true
+			}
+		}
+
+		// 수신자 언어별로 한 번만 지역화
+		byLang := make(map[string]map[string]any)
 		for _, sID := range sessionIDsInRoom {
+			if recipients != nil && !recipients[sID] {
+				continue
+			}
 			if rb.sessionGetter == nil {
 				log.Logger.Error("❌ Broadcaster session getter not set. Cannot broadcast to live sessions.")
 				continue
 			}
 			liveSession, found := rb.sessionGetter(sID)
 			if found && liveSession.Conn != nil {
+				data, ok := byLang[liveSession.Lang]
+				if !ok {
+					data = localizeEvent(parsed.Data, liveSession.Lang)
+					byLang[liveSession.Lang] = data
+				}
 				liveSession.WriteMutex.Lock()
-				err := liveSession.Conn.WriteJSON(parsed.Data)
+				err := liveSession.Conn.WriteJSON(data)
 				liveSession.WriteMutex.Unlock()
 				if err != nil {
 					log.Logger.Error("❌ Failed to write JSON to WebSocket for session", sID, ":", err)
@@ -120,3 +182,16 @@ func (rb *RedisBroadcaster) listen() {
 		}
 	}
 }
+
+// toEventMap 임의의 페이로드를 seq를 덧붙일 수 있는 map 형태로 변환한다.
+func toEventMap(payload interface{}) (map[string]any, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]any{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,133 @@
+package ws
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	chat "github.com/Ryeom/board-game/internal/domain/chat"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/Ryeom/board-game/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultChatMaxLength = 300
+	defaultChatMaxMute   = 24 * time.Hour
+	chatFilterModeOff    = "off"
+)
+
+// chatModeration settings.toml의 [bg.chat] 설정. ConfigureChat 전에는 기본값(필터 없음, 도배 제한 없음)을 쓴다.
+type chatModeration struct {
+	maxLength   int
+	maxMute     time.Duration
+	floodLimit  int
+	floodWindow time.Duration
+	floodMute   time.Duration
+	filterMode  string
+	filter      *chat.WordFilter
+}
+
+var chatConfig = &chatModeration{
+	maxLength:  defaultChatMaxLength,
+	maxMute:    defaultChatMaxMute,
+	filterMode: chatFilterModeOff,
+}
+
+// ConfigureChat 채팅 길이/도배/음소거 설정과 금칙어 파일을 읽는다. 금칙어 파일을 읽지 못하면 필터 없이 동작한다.
+func ConfigureChat() {
+	cfg := &chatModeration{
+		maxLength:   viper.GetInt("bg.chat.max-length"),
+		maxMute:     viper.GetDuration("bg.chat.max-mute"),
+		floodLimit:  viper.GetInt("bg.chat.flood-limit"),
+		floodWindow: viper.GetDuration("bg.chat.flood-window"),
+		floodMute:   viper.GetDuration("bg.chat.flood-mute"),
+		filterMode:  viper.GetString("bg.chat.filter.mode"),
+	}
+	if cfg.maxLength <= 0 {
+		cfg.maxLength = defaultChatMaxLength
+	}
+	if cfg.maxMute <= 0 {
+		cfg.maxMute = defaultChatMaxMute
+	}
+
+	switch cfg.filterMode {
+	case chat.FilterModeMask, chat.FilterModeReject:
+		filter, err := chat.LoadWordFilter(viper.GetStringSlice("bg.chat.filter.files")...)
+		if err != nil {
+			log.Logger.Errorf("ConfigureChat - Failed to load chat word filter, filtering disabled: %v", err)
+			cfg.filterMode = chatFilterModeOff
+			break
+		}
+		cfg.filter = filter
+		log.Logger.Infof("Chat word filter loaded (%d words, mode=%s)", filter.Len(), cfg.filterMode)
+	case chatFilterModeOff:
+	default:
+		log.Logger.Warningf("ConfigureChat - Unknown bg.chat.filter.mode %q, filtering disabled", cfg.filterMode)
+		cfg.filterMode = chatFilterModeOff
+	}
+	chatConfig = cfg
+}
+
+// moderateChatMessage 음소거/도배/길이/금칙어를 확인하고 전송할 메시지를 반환한다. 거부하면 응답 에러 코드.
+// roomID가 비어 있으면(DM) 전체 음소거만 확인하고, 도배에 걸려도 자동 음소거는 하지 않는다.
+func moderateChatMessage(ctx context.Context, u *user.Session, roomID string, message string) (string, string) {
+	cfg := chatConfig
+
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return "", resp.ErrorCodeChatEmptyMessage
+	}
+	if utf8.RuneCountInString(message) > cfg.maxLength {
+		return "", resp.ErrorCodeChatMessageTooLong
+	}
+	if chat.ActiveMute(ctx, roomID, u.ID) != nil {
+		return "", resp.ErrorCodeChatMuted
+	}
+	if !chat.AllowMessage(ctx, u.ID, cfg.floodLimit, cfg.floodWindow) {
+		if cfg.floodMute > 0 && roomID != "" {
+			m := &chat.Mute{UserID: u.ID, RoomID: roomID, MutedBy: chat.MutedBySystem, Reason: "flood", Until: time.Now().Add(cfg.floodMute)}
+			if err := chat.SetMute(ctx, m); err != nil {
+				log.Logger.Errorf("moderateChatMessage - Failed to auto-mute %s in room %s: %v", u.ID, roomID, err)
+			} else {
+				notifyChatMute(m, true)
+			}
+		}
+		return "", resp.ErrorCodeChatRateLimited
+	}
+
+	if cfg.filterMode == chatFilterModeOff {
+		return message, ""
+	}
+	masked, matched := cfg.filter.Mask(message)
+	if matched && cfg.filterMode == chat.FilterModeReject {
+		return "", resp.ErrorCodeChatMessageBlocked
+	}
+	return masked, ""
+}
+
+// notifyChatMute chat.muted 알림. 방 음소거는 방 전체에, 전체 음소거는 본인에게만 보낸다.
+func notifyChatMute(m *chat.Mute, muted bool) {
+	payload := ChatMutedPayload{
+		UserID:  m.UserID,
+		RoomID:  m.RoomID,
+		Muted:   muted,
+		Global:  m.Global(),
+		MutedBy: m.MutedBy,
+		Reason:  m.Reason,
+	}
+	msgCode := resp.SuccessCodeChatUnmute
+	if muted {
+		payload.Until = &m.Until
+		msgCode = resp.SuccessCodeChatMute
+	}
+
+	b := &WsBroadcaster{}
+	if m.Global() {
+		b.SendToPlayer(m.UserID, string(EventChatMuted), payload, msgCode)
+		return
+	}
+	b.BroadcastToRoom(m.RoomID, string(EventChatMuted), payload, msgCode)
+}
@@ -0,0 +1,23 @@
+package ws
+
+import (
+	"context"
+
+	chat "github.com/Ryeom/board-game/internal/domain/chat"
+	"github.com/Ryeom/board-game/log"
+)
+
+// ChatTimeline service.Timeline 구현. 기록을 채팅 내역에 저장하고 chat.message로 방 전체에 전달한다.
+// (수신자 언어 렌더링은 localizeEvent에서 처리)
+type ChatTimeline struct{}
+
+func (ChatTimeline) Post(roomID string, recordType chat.RecordType, key string, params map[string]string, subjects ...chat.Subject) {
+	record := chat.NewEventRecord(recordType, key, params, subjects...)
+	if err := chat.SaveChatMessage(context.Background(), roomID, record); err != nil {
+		log.Logger.Errorf("ChatTimeline - Failed to save %s record %s for room %s: %v", recordType, key, roomID, err)
+	}
+	GlobalBroadcaster.BroadcastToRoom(roomID, map[string]any{
+		"type": EventChatMessage,
+		"data": record,
+	})
+}
@@ -3,6 +3,7 @@ package ws
 import (
 	"context"
 	chat "github.com/Ryeom/board-game/internal/domain/chat"
+	"github.com/Ryeom/board-game/internal/domain/room"
 	resp "github.com/Ryeom/board-game/internal/response"
 	"github.com/Ryeom/board-game/internal/user"
 	"github.com/Ryeom/board-game/log"
@@ -22,11 +23,32 @@ func HandleChatSend(ctx context.Context, u *user.Session, event SocketEvent) {
 		return
 	}
 
+	r, ok := room.GetRoom(ctx, u.RoomID)
+	if !ok {
+		sendError(u, resp.ErrorCodeChatNotInRoom)
+		return
+	}
+
+	member := chatMember(r, u.ID)
+	channel, err := member.WriteChannel(req.Channel)
+	if err != nil {
+		sendError(u, err.Error())
+		return
+	}
+
+	text, errCode := moderateChatMessage(ctx, u, u.RoomID, req.Message)
+	if errCode != "" {
+		sendError(u, errCode)
+		return
+	}
+
 	chatRecord := chat.ChatRecord{
 		SenderID:   u.ID,
 		SenderName: u.Name,
-		Message:    req.Message,
+		Message:    text,
 		Timestamp:  time.Now(),
+		Spectator:  member.Spectator,
+		Channel:    channel,
 	}
 
 	if err := chat.SaveChatMessage(ctx, u.RoomID, &chatRecord); err != nil {
@@ -35,12 +57,42 @@ func HandleChatSend(ctx context.Context, u *user.Session, event SocketEvent) {
 		return
 	}
 
-	GlobalBroadcaster.BroadcastToRoom(u.RoomID, map[string]any{
+	message := map[string]any{
 		"type": EventChatMessage,
 		"data": chatRecord,
-	})
+	}
+	if channel == chat.ChannelAll {
+		GlobalBroadcaster.BroadcastToRoom(u.RoomID, message)
+	} else {
+		// 채널을 읽을 수 있는 유저에게만 전달 (다른 서버에 연결된 유저 포함)
+		if err := GlobalBroadcaster.BroadcastToMembers(u.RoomID, channelMembers(r, channel), message); err != nil {
+			log.Logger.Warningf("HandleChatSend - Failed to deliver %s chat in room %s: %v", channel, u.RoomID, err)
+		}
+	}
+
+	// 금칙어가 가려졌을 수 있으므로 실제로 전송된 메시지를 함께 돌려준다
+	sendResult(u, event.Type, map[string]string{"status": "sent", "message": text, "channel": string(channel)}, resp.SuccessCodeChatSend)
+}
+
+// chatMember 방 안에서 유저의 채널 권한
+func chatMember(r *room.Room, userID string) chat.Member {
+	return chat.Member{
+		Player:         r.HasPlayer(userID),
+		Spectator:      r.HasSpectator(userID),
+		Team:           r.TeamOf(userID),
+		GameInProgress: r.IsGameStarted,
+	}
+}
 
-	sendResult(u, event.Type, map[string]string{"status": "sent"}, resp.SuccessCodeChatSend)
+// channelMembers 채널을 읽을 수 있는 방 안의 유저
+func channelMembers(r *room.Room, channel chat.Channel) []string {
+	var members []string
+	for _, id := range append(append([]string{}, r.Players...), r.Spectators...) {
+		if chatMember(r, id).CanRead(channel) {
+			members = append(members, id)
+		}
+	}
+	return members
 }
 
 // HandleChatHistory 채팅 내역 조회
@@ -50,20 +102,110 @@ func HandleChatHistory(ctx context.Context, u *user.Session, event SocketEvent)
 		return
 	}
 
-	chatRecords, err := chat.GetChatHistory(ctx, u.RoomID)
+	r, ok := room.GetRoom(ctx, u.RoomID)
+	if !ok {
+		sendError(u, resp.ErrorCodeChatNotInRoom)
+		return
+	}
+
+	var query chat.HistoryQuery
+	if event.Filter != nil {
+		if err := bindEventFilter(event, &query); err != nil {
+			sendError(u, resp.ErrorCodeChatHistoryInvalidQuery)
+			return
+		}
+	}
+	channels, err := chatMember(r, u.ID).HistoryChannels(query.Channel)
 	if err != nil {
+		sendError(u, err.Error())
+		return
+	}
+	query.Channels = channels
+
+	chatRecords, nextCursor, err := chat.GetChatHistory(ctx, u.RoomID, query)
+	if err != nil {
+		if err.Error() == resp.ErrorCodeChatHistoryInvalidQuery {
+			sendError(u, resp.ErrorCodeChatHistoryInvalidQuery)
+			return
+		}
 		log.Logger.Errorf("HandleChatHistory - Failed to retrieve chat history via chat service for room %s: %v", u.RoomID, err)
 		sendError(u, resp.ErrorCodeChatHistoryFetchFailed)
 		return
 	}
 
-	sendResult(u, event.Type, map[string]any{
-		"roomId":  u.RoomID,
-		"history": chatRecords,
+	for _, record := range chatRecords {
+		record.Localize(u.Lang)
+	}
+
+	sendResult(u, event.Type, ChatHistoryResponse{
+		RoomID:     u.RoomID,
+		History:    chatRecords,
+		NextCursor: nextCursor,
 	}, resp.SuccessCodeChatHistoryFetch)
 }
 
-// HandleChatMute 유저 채팅 제한
+// HandleChatMute 유저 채팅 제한. 방장은 자기 방에서, 관리자는 어느 방에서나 또는 global로 전체 제한할 수 있다.
 func HandleChatMute(ctx context.Context, u *user.Session, event SocketEvent) {
-	sendError(u, resp.ErrorCodeChatMuteFailed)
+	var req ChatMuteRequest
+	if err := bindEventData(event, &req); err != nil || req.UserID == "" || req.UserID == u.ID || req.DurationSecs < 0 {
+		sendError(u, resp.ErrorCodeRoomInvalidRequest)
+		return
+	}
+	duration := time.Duration(req.DurationSecs) * time.Second
+	if duration > chatConfig.maxMute {
+		sendError(u, resp.ErrorCodeRoomInvalidRequest)
+		return
+	}
+
+	roomID := ""
+	if req.Global {
+		if !u.IsAdmin {
+			sendError(u, resp.ErrorCodeChatMuteForbidden)
+			return
+		}
+	} else {
+		if u.RoomID == "" {
+			sendError(u, resp.ErrorCodeChatNotInRoom)
+			return
+		}
+		r, ok := room.GetRoom(ctx, u.RoomID)
+		if !ok {
+			sendError(u, resp.ErrorCodeChatNotInRoom)
+			return
+		}
+		if r.Host != u.ID && !u.IsAdmin {
+			sendError(u, resp.ErrorCodeChatMuteForbidden)
+			return
+		}
+		if !r.HasPlayer(req.UserID) && !r.HasSpectator(req.UserID) {
+			sendError(u, resp.ErrorCodeRoomUserNotInRoom)
+			return
+		}
+		roomID = r.ID
+	}
+
+	m := &chat.Mute{UserID: req.UserID, RoomID: roomID, MutedBy: u.ID, Reason: req.Reason, Until: time.Now().Add(duration)}
+	if duration == 0 {
+		cleared, err := chat.ClearMute(ctx, roomID, req.UserID)
+		if err != nil {
+			log.Logger.Errorf("HandleChatMute - Failed to clear mute for %s in %q: %v", req.UserID, roomID, err)
+			sendError(u, resp.ErrorCodeChatMuteFailed)
+			return
+		}
+		if !cleared {
+			sendError(u, resp.ErrorCodeChatNotMuted)
+			return
+		}
+		notifyChatMute(m, false)
+		sendResult(u, event.Type, map[string]any{"userId": req.UserID, "roomId": roomID, "muted": false}, resp.SuccessCodeChatUnmute)
+		return
+	}
+
+	if err := chat.SetMute(ctx, m); err != nil {
+		log.Logger.Errorf("HandleChatMute - Failed to mute %s in %q: %v", req.UserID, roomID, err)
+		sendError(u, resp.ErrorCodeChatMuteFailed)
+		return
+	}
+	notifyChatMute(m, true)
+	sendResult(u, event.Type, m, resp.SuccessCodeChatMute)
 }
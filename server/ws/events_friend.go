@@ -0,0 +1,101 @@
+package ws
+
+import (
+	"context"
+
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/service"
+	"github.com/Ryeom/board-game/internal/user"
+)
+
+// GlobalSocialService 친구/DM 서비스 (REST 핸들러도 같이 사용)
+var GlobalSocialService = service.NewSocialService(&WsBroadcaster{})
+
+// isMember 친구/DM은 회원 전용 (게스트는 DB 사용자가 없음)
+func isMember(u *user.Session) bool {
+	if u.IsGuest {
+		sendError(u, resp.ErrorCodeFriendGuestNotAllowed)
+		return false
+	}
+	return true
+}
+
+// HandleFriendList 친구 목록 조회
+func HandleFriendList(ctx context.Context, u *user.Session, event SocketEvent) {
+	if !isMember(u) {
+		return
+	}
+	friends, err := GlobalSocialService.Friends(ctx, u.ID)
+	if err != nil {
+		sendError(u, err.Error())
+		return
+	}
+	sendResult(u, event.Type, map[string]any{"friends": friends}, resp.SuccessCodeFriendList)
+}
+
+// HandleDMSend 친구에게 메시지 전송. 채팅과 같은 길이/전체 음소거/도배/금칙어 규칙을 적용한다.
+func HandleDMSend(ctx context.Context, u *user.Session, event SocketEvent) {
+	if !isMember(u) {
+		return
+	}
+	var req DMSendRequest
+	if err := bindEventData(event, &req); err != nil || req.To == "" {
+		sendError(u, resp.ErrorCodeFriendInvalidTarget)
+		return
+	}
+
+	text, errCode := moderateChatMessage(ctx, u, "", req.Message)
+	if errCode != "" {
+		sendError(u, errCode)
+		return
+	}
+
+	m, err := GlobalSocialService.SendDirectMessage(ctx, u.ID, u.Name, req.To, text)
+	if err != nil {
+		sendError(u, err.Error())
+		return
+	}
+	sendResult(u, event.Type, m, resp.SuccessCodeDMSend)
+}
+
+// HandleDMHistory 친구와의 대화 내역 조회
+func HandleDMHistory(ctx context.Context, u *user.Session, event SocketEvent) {
+	if !isMember(u) {
+		return
+	}
+	var filter DMHistoryFilter
+	if err := bindEventFilter(event, &filter); err != nil || filter.UserID == "" {
+		sendError(u, resp.ErrorCodeDMInvalidQuery)
+		return
+	}
+
+	messages, nextCursor, err := GlobalSocialService.DirectMessages(ctx, u.ID, filter.UserID, filter.HistoryQuery)
+	if err != nil {
+		sendError(u, err.Error())
+		return
+	}
+	sendResult(u, event.Type, DMHistoryResponse{
+		UserID:     filter.UserID,
+		History:    messages,
+		NextCursor: nextCursor,
+	}, resp.SuccessCodeDMHistory)
+}
+
+// HandleDMRead 상대에게서 받은 메시지 읽음 처리
+func HandleDMRead(ctx context.Context, u *user.Session, event SocketEvent) {
+	if !isMember(u) {
+		return
+	}
+	var req DMReadRequest
+	if err := bindEventData(event, &req); err != nil || req.UserID == "" {
+		sendError(u, resp.ErrorCodeDMInvalidQuery)
+		return
+	}
+
+	n, err := GlobalSocialService.MarkRead(ctx, u.ID, req.UserID)
+	if err != nil {
+		sendError(u, err.Error())
+		return
+	}
+	sendResult(u, event.Type, map[string]any{"userId": req.UserID, "read": n}, resp.SuccessCodeDMRead)
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 
 	"github.com/Ryeom/board-game/internal/ai"
+	"github.com/Ryeom/board-game/internal/domain/match"
 	"github.com/Ryeom/board-game/internal/game"
 	resp "github.com/Ryeom/board-game/internal/response"
 	"github.com/Ryeom/board-game/internal/service"
 	"github.com/Ryeom/board-game/internal/user"
+	"github.com/Ryeom/board-game/internal/util"
+	"github.com/spf13/viper"
 )
 
 // WsBroadcaster implements service.Broadcaster using the WebSocket GlobalBroadcaster
@@ -17,17 +20,41 @@ func (b *WsBroadcaster) SendToPlayer(playerID string, eventName string, payload
 	if ai.IsAIPlayer(playerID) {
 		return
 	}
-	res := createWebSocketResult(EventType(eventName), payload, msgCode, "ko")
+	res := createWebSocketResult(EventType(eventName), payload, msgCode, sessionLang(playerID))
 	GlobalBroadcaster.SendToPlayer(playerID, res)
 }
 
+// BroadcastToRoom 기본 언어로 만들어 발행하고, 수신자별 언어는 RedisBroadcaster.listen에서 다시 적용한다.
 func (b *WsBroadcaster) BroadcastToRoom(roomID string, eventName string, payload any, msgCode string) {
-	res := createWebSocketResult(EventType(eventName), payload, msgCode, "ko")
+	res := createWebSocketResult(EventType(eventName), payload, msgCode, util.DefaultLanguage)
 	GlobalBroadcaster.BroadcastToRoom(roomID, res)
 }
 
+// sessionLang 현재 인스턴스에 연결된 플레이어의 언어 (없으면 기본 언어)
+func sessionLang(playerID string) string {
+	if val, ok := ActiveSessions().Load(playerID); ok {
+		if s, ok := val.(*user.Session); ok && s.Lang != "" {
+			return s.Lang
+		}
+	}
+	return util.DefaultLanguage
+}
+
 // GlobalGameService is the entry point for all game logic
-var GlobalGameService = service.NewGameService(game.NewManager(), &WsBroadcaster{})
+var GlobalGameService = newGameService()
+
+func newGameService() *service.GameService {
+	s := service.NewGameService(game.NewManager(), &WsBroadcaster{})
+	s.Rooms = GlobalRoomService
+	s.Timeline = ChatTimeline{}
+	s.SaveResult = match.SaveResult
+	return s
+}
+
+// ConfigureGame 설정 파일의 게임 진행 옵션을 적용한다. (bg.rematch.vote-timeout = 0s면 재대결 투표를 열지 않음)
+func ConfigureGame() {
+	GlobalGameService.RematchTimeout = viper.GetDuration("bg.rematch.vote-timeout")
+}
 
 // HandleGameStart (game.start)게임 시작
 func HandleGameStart(ctx context.Context, u *user.Session, event SocketEvent) {
@@ -123,3 +150,30 @@ func HandleGameInfo(ctx context.Context, user *user.Session, event SocketEvent)
 		"info":     info,
 	}, resp.SuccessCodeSystemOK)
 }
+
+// HandleGameRematch (game.rematch) 게임 종료 후 열린 재대결 투표에 수락/거절. 거절하면 방에서 나간다.
+func HandleGameRematch(ctx context.Context, u *user.Session, event SocketEvent) {
+	if u.RoomID == "" {
+		sendError(u, resp.ErrorCodeRoomNotInRoom)
+		return
+	}
+	var req GameRematchRequest
+	if err := bindEventData(event, &req); err != nil || req.Accept == nil {
+		sendError(u, resp.ErrorCodeRoomInvalidRequest)
+		return
+	}
+
+	roomID := u.RoomID
+	if err := GlobalGameService.VoteRematch(ctx, roomID, u.ID, *req.Accept); err != nil {
+		sendError(u, err.Error())
+		return
+	}
+	if !*req.Accept {
+		updateLiveRoomSession(u, "", false)
+	}
+
+	sendResult(u, event.Type, map[string]any{
+		"roomId": roomID,
+		"accept": *req.Accept,
+	}, resp.SuccessCodeGameRematchVote)
+}
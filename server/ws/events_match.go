@@ -0,0 +1,111 @@
+package ws
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Ryeom/board-game/internal/domain/match"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/service"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/Ryeom/board-game/log"
+	"github.com/spf13/viper"
+)
+
+// GlobalMatchService 빠른 대전 대기열/매처
+var GlobalMatchService = newMatchService(service.DefaultMatchConfig)
+
+func newMatchService(config service.MatchConfig) *service.MatchService {
+	s := service.NewMatchService(GlobalRoomService, &WsBroadcaster{}, config)
+	s.OnJoined = updateLiveRoomSessionByID
+	return s
+}
+
+// StartMatchmaker 설정을 읽어 매처를 시작한다. (bg.match.enabled = false면 대기열 등록만 받고 매칭하지 않음)
+func StartMatchmaker(ctx context.Context) {
+	GlobalMatchService = newMatchService(service.MatchConfig{
+		Interval:      viper.GetDuration("bg.match.interval"),
+		BackfillAfter: viper.GetDuration("bg.match.bot-backfill-after"),
+		TicketTTL:     viper.GetDuration("bg.match.ticket-ttl"),
+	})
+	if !viper.GetBool("bg.match.enabled") {
+		log.Logger.Infof("Matchmaker disabled")
+		return
+	}
+	go GlobalMatchService.Run(ctx)
+}
+
+// HandleMatchQueue (match.queue) 빠른 대전 대기열 등록
+func HandleMatchQueue(ctx context.Context, u *user.Session, event SocketEvent) {
+	if u.RoomID != "" {
+		sendError(u, resp.ErrorCodeMatchInRoom)
+		return
+	}
+	var req MatchQueueRequest
+	if err := bindEventData(event, &req); err != nil {
+		sendError(u, resp.ErrorCodeMatchInvalidRequest)
+		return
+	}
+
+	ticket := &match.Ticket{
+		UserID:      u.ID,
+		UserName:    u.Name,
+		GameMode:    req.GameMode,
+		PlayerCount: req.PlayerCount,
+	}
+	// 레이팅 매칭은 이메일 인증을 마친 계정만, 레이팅은 계정에 저장된 값으로 (일반 매칭은 누구나)
+	if req.Ranked {
+		account, err := requireVerifiedEmail(u)
+		if err != nil {
+			sendError(u, err.Error())
+			return
+		}
+		ticket.Ranked = true
+		ticket.Rating = account.Rating
+		ticket.RatingBand = req.RatingBand
+	}
+	if err := GlobalMatchService.Enqueue(ctx, ticket); err != nil {
+		sendError(u, err.Error())
+		return
+	}
+
+	sendResult(u, event.Type, MatchQueueResponse{
+		GameMode:    ticket.GameMode,
+		PlayerCount: ticket.PlayerCount,
+		Ranked:      ticket.Ranked,
+		Rating:      ticket.Rating,
+		QueuedAt:    ticket.EnqueuedAt,
+	}, resp.SuccessCodeMatchQueue)
+}
+
+// requireVerifiedEmail 인증을 마친 계정. 게스트나 이메일 인증 전 계정이면 ERROR_MATCH_EMAIL_NOT_VERIFIED
+func requireVerifiedEmail(u *user.Session) (*user.User, error) {
+	if u.IsGuest {
+		return nil, errors.New(resp.ErrorCodeMatchEmailNotVerified)
+	}
+	account, err := user.FindUserByID(u.ActualUserID)
+	if err != nil {
+		log.Logger.Errorf("requireVerifiedEmail - FindUserByID Error for ID %s: %v", u.ActualUserID, err)
+		return nil, errors.New(resp.ErrorCodeMatchQueueFailed)
+	}
+	if account == nil || !account.EmailVerified() {
+		return nil, errors.New(resp.ErrorCodeMatchEmailNotVerified)
+	}
+	return account, nil
+}
+
+// HandleMatchCancel (match.cancel) 빠른 대전 대기 취소
+func HandleMatchCancel(ctx context.Context, u *user.Session, event SocketEvent) {
+	if err := GlobalMatchService.Cancel(ctx, u.ID); err != nil {
+		sendError(u, err.Error())
+		return
+	}
+	sendResult(u, event.Type, nil, resp.SuccessCodeMatchCancel)
+}
+
+// leaveMatchQueue 직접 방에 들어가거나 연결이 끊기면 대기열에서 뺀다. (대기 중이 아니면 무시)
+func leaveMatchQueue(ctx context.Context, userID string) {
+	if _, err := match.Cancel(ctx, userID); err != nil {
+		log.Logger.Warningf("leaveMatchQueue - Failed to remove user %s from match queue: %v", userID, err)
+	}
+}
@@ -2,36 +2,52 @@ package ws
 
 import (
 	"context"
+	"time"
 
 	"github.com/Ryeom/board-game/internal/domain/room"
+	"github.com/Ryeom/board-game/internal/game"
 	resp "github.com/Ryeom/board-game/internal/response"
 	"github.com/Ryeom/board-game/internal/service"
 	"github.com/Ryeom/board-game/internal/user"
+	"github.com/spf13/viper"
 )
 
 // GlobalRoomService entry point
-var GlobalRoomService = service.NewRoomService(&WsBroadcaster{})
+var GlobalRoomService = newRoomService()
+
+func newRoomService() *service.RoomService {
+	s := service.NewRoomService(&WsBroadcaster{})
+	s.Timeline = ChatTimeline{}
+	return s
+}
 
 // HandleRoomCreate 방 생성하기
 func HandleRoomCreate(ctx context.Context, u *user.Session, event SocketEvent) {
 	var req RoomCreateRequest
-	if err := bindEventData(event, &req); err != nil || req.RoomName == "" || req.MaxPlayers < 2 || req.MaxPlayers > 6 {
+	if err := bindEventData(event, &req); err != nil || req.RoomName == "" || req.MaxPlayers < 0 {
 		sendError(u, resp.ErrorCodeRoomInvalidRequest)
 		return
 	}
+	if req.GameMode == "" {
+		req.GameMode = game.ModeHanabi
+	}
 
-	r, err := GlobalRoomService.CreateRoom(ctx, u.ID, u.Name, req.RoomName, req.Password, req.MaxPlayers)
+	// 인원/설정은 게임 모드 규칙(game.LookupRules)으로 검증
+	r, err := GlobalRoomService.CreateRoom(ctx, u.ID, u.Name, req.RoomName, req.Password, req.MaxPlayers, req.GameMode, req.Settings)
 	if err != nil {
 		sendError(u, err.Error()) // Service returns error code string
 		return
 	}
+	leaveMatchQueue(ctx, u.ID)
 	updateLiveRoomSession(u, r.ID, true)
 
-	rooms, _ := GlobalRoomService.GetRoomList(ctx)
+	rooms, _, _ := GlobalRoomService.QueryRoomList(ctx, room.ListQuery{})
 	sendResult(u, event.Type, RoomCreateResponse{
 		RoomID:     r.ID,
 		RoomName:   r.RoomName,
 		MaxPlayers: r.MaxPlayers,
+		GameMode:   r.GameMode,
+		Settings:   r.Settings,
 		RoomList:   roomSummaries(rooms),
 	}, resp.SuccessCodeRoomCreate)
 }
@@ -49,11 +65,116 @@ func HandleRoomJoin(ctx context.Context, u *user.Session, event SocketEvent) {
 		sendError(u, err.Error())
 		return
 	}
+	leaveMatchQueue(ctx, u.ID)
+	updateLiveRoomSession(u, r.ID, r.Host == u.ID)
+
+	sendResult(u, event.Type, r, resp.SuccessCodeRoomJoin)
+}
+
+// HandleRoomJoinByCode (room.joinByCode) 초대 코드로 방에 참여하기. 비밀번호가 있는 방도 비밀번호 없이 입장한다.
+func HandleRoomJoinByCode(ctx context.Context, u *user.Session, event SocketEvent) {
+	var req RoomJoinByCodeRequest
+	if err := bindEventData(event, &req); err != nil || req.Code == "" {
+		sendError(u, resp.ErrorCodeInviteInvalidRequest)
+		return
+	}
+
+	r, err := GlobalRoomService.JoinRoomByCode(ctx, u.ID, u.Name, req.Code)
+	if err != nil {
+		sendError(u, err.Error())
+		return
+	}
+	leaveMatchQueue(ctx, u.ID)
 	updateLiveRoomSession(u, r.ID, r.Host == u.ID)
 
 	sendResult(u, event.Type, r, resp.SuccessCodeRoomJoin)
 }
 
+// HandleRoomSpectate (room.spectate) 관전자로 입장. 게임 진행 중인 방에도 들어갈 수 있다.
+func HandleRoomSpectate(ctx context.Context, u *user.Session, event SocketEvent) {
+	var req RoomSpectateRequest
+	if err := bindEventData(event, &req); err != nil || req.RoomID == "" {
+		sendError(u, resp.ErrorCodeRoomInvalidRequest)
+		return
+	}
+
+	r, err := GlobalRoomService.SpectateRoom(ctx, u.ID, u.Name, req.RoomID, req.Password)
+	if err != nil {
+		sendError(u, err.Error())
+		return
+	}
+	leaveMatchQueue(ctx, u.ID)
+	updateLiveRoomSession(u, r.ID, false)
+
+	sendResult(u, event.Type, r, resp.SuccessCodeRoomSpectate)
+}
+
+// HandleRoomPromote (room.promote) 관전자를 빈 자리의 플레이어로 옮긴다. 게임 시작 전에만 가능.
+func HandleRoomPromote(ctx context.Context, u *user.Session, event SocketEvent) {
+	if u.RoomID == "" {
+		sendError(u, resp.ErrorCodeRoomNotInRoom)
+		return
+	}
+	var req RoomPromoteRequest
+	if event.Data != nil {
+		if err := bindEventData(event, &req); err != nil {
+			sendError(u, resp.ErrorCodeRoomInvalidRequest)
+			return
+		}
+	}
+	if req.UserID == "" {
+		req.UserID = u.ID
+	}
+
+	r, err := GlobalRoomService.PromoteSpectator(ctx, u.ID, u.RoomID, req.UserID)
+	if err != nil {
+		sendError(u, err.Error())
+		return
+	}
+	updateLiveRoomSessionByID(req.UserID, r.ID, r.Host == req.UserID)
+
+	sendResult(u, event.Type, r, resp.SuccessCodeRoomPromote)
+}
+
+// HandleRoomInvite (room.invite) 방장이 초대 코드 발급
+func HandleRoomInvite(ctx context.Context, u *user.Session, event SocketEvent) {
+	if u.RoomID == "" {
+		sendError(u, resp.ErrorCodeRoomNotInRoom)
+		return
+	}
+	var req RoomInviteRequest
+	if err := bindEventData(event, &req); err != nil {
+		sendError(u, resp.ErrorCodeInviteInvalidRequest)
+		return
+	}
+	ttl := viper.GetDuration("bg.invite.default-ttl")
+	if req.TTLSeconds > 0 {
+		ttl = time.Duration(req.TTLSeconds) * time.Second
+	}
+	if req.MaxUses < 0 || ttl <= 0 || ttl > viper.GetDuration("bg.invite.max-ttl") {
+		sendError(u, resp.ErrorCodeInviteInvalidRequest)
+		return
+	}
+
+	inv, err := GlobalRoomService.CreateInvite(ctx, u.ID, u.RoomID, ttl, req.MaxUses)
+	if err != nil {
+		sendError(u, err.Error())
+		return
+	}
+	sendResult(u, event.Type, RoomInviteResponse{
+		Code:      inv.Code,
+		RoomID:    inv.RoomID,
+		MaxUses:   inv.MaxUses,
+		ExpiresAt: inv.ExpiresAt,
+		Link:      InviteLink(inv.Code),
+	}, resp.SuccessCodeRoomInviteCreate)
+}
+
+// InviteLink 초대 코드 딥링크 (bg.invite.link-base + 코드)
+func InviteLink(code string) string {
+	return viper.GetString("bg.invite.link-base") + code
+}
+
 // HandleRoomLeave 방 나가기
 func HandleRoomLeave(ctx context.Context, u *user.Session, event SocketEvent) {
 	if u.RoomID == "" {
@@ -78,8 +199,23 @@ func HandleRoomLeave(ctx context.Context, u *user.Session, event SocketEvent) {
 
 // HandleRoomList 현재 방 조회 (WebSocket)
 func HandleRoomList(ctx context.Context, u *user.Session, event SocketEvent) {
-	rooms, _ := GlobalRoomService.GetRoomList(ctx)
-	sendResult(u, event.Type, RoomListResponse{Rooms: roomSummaries(rooms)}, resp.SuccessCodeRoomListFetch)
+	var query room.ListQuery
+	if event.Filter != nil {
+		if err := bindEventFilter(event, &query); err != nil {
+			sendError(u, resp.ErrorCodeRoomListInvalidQuery)
+			return
+		}
+	}
+
+	rooms, nextCursor, err := GlobalRoomService.QueryRoomList(ctx, query)
+	if err != nil {
+		sendError(u, err.Error())
+		return
+	}
+	sendResult(u, event.Type, RoomListResponse{
+		Rooms:      roomSummaries(rooms),
+		NextCursor: nextCursor,
+	}, resp.SuccessCodeRoomListFetch)
 }
 
 // HandleRoomUpdate 방 설정 변경
@@ -142,22 +278,76 @@ func HandleRoomKick(ctx context.Context, u *user.Session, event SocketEvent) {
 		"userId":  req.UserID,
 		"newHost": newHost,
 	}, resp.SuccessCodeRoomKick)
+	// 강퇴된 유저에게는 서비스가 user.kicked를 직접 보낸다 (클라이언트는 로비로 이동)
 	updateLiveRoomSessionByID(req.UserID, "", false)
+}
+
+// HandleRoomBan (room.ban) 방장이 유저를 차단. 방에 있으면 내보내고 이후 참여/관전을 막는다.
+func HandleRoomBan(ctx context.Context, u *user.Session, event SocketEvent) {
+	var req RoomModerationRequest
+	if err := bindEventData(event, &req); err != nil || req.UserID == "" {
+		sendError(u, resp.ErrorCodeRoomInvalidRequest)
+		return
+	}
+
+	roomID := u.RoomID
+	r, err := GlobalRoomService.BanUser(ctx, u.ID, roomID, req.UserID)
+	if err != nil {
+		sendError(u, err.Error())
+		return
+	}
+	if val, ok := ActiveSessions().Load(req.UserID); ok {
+		if target, ok := val.(*user.Session); ok && target.RoomID == roomID {
+			updateLiveRoomSession(target, "", false)
+		}
+	}
+
+	sendResult(u, event.Type, map[string]any{
+		"userId": req.UserID,
+		"banned": r.Banned,
+	}, resp.SuccessCodeRoomBan)
+}
+
+// HandleRoomUnban (room.unban) 차단 해제
+func HandleRoomUnban(ctx context.Context, u *user.Session, event SocketEvent) {
+	var req RoomModerationRequest
+	if err := bindEventData(event, &req); err != nil || req.UserID == "" {
+		sendError(u, resp.ErrorCodeRoomInvalidRequest)
+		return
+	}
+
+	r, err := GlobalRoomService.UnbanUser(ctx, u.ID, u.RoomID, req.UserID)
+	if err != nil {
+		sendError(u, err.Error())
+		return
+	}
+
+	sendResult(u, event.Type, map[string]any{
+		"userId": req.UserID,
+		"banned": r.Banned,
+	}, resp.SuccessCodeRoomUnban)
+}
+
+// HandleRoomTransferHost (room.transferHost) 방장 권한 넘기기
+func HandleRoomTransferHost(ctx context.Context, u *user.Session, event SocketEvent) {
+	var req RoomModerationRequest
+	if err := bindEventData(event, &req); err != nil || req.UserID == "" {
+		sendError(u, resp.ErrorCodeRoomInvalidRequest)
+		return
+	}
+
+	r, err := GlobalRoomService.TransferHost(ctx, u.ID, u.RoomID, req.UserID)
+	if err != nil {
+		sendError(u, err.Error())
+		return
+	}
+	updateLiveRoomSession(u, r.ID, false)
+	updateLiveRoomSessionByID(r.Host, r.ID, true)
 
-	// Note: Service handles broadcasting "user.kicked".
-	// Service also logic to notify the kicked user specifically via Broadcaster?
-	// Wait, Service Broadcaster typically supports "SendToPlayer".
-	// The original code did: "targetSession.Conn.WriteJSON(...)".
-	// The Service logic currently broadcasts "user.kicked" to room.
-	// We might want Service to also notify the specific user "kicked_from_room".
-	// Let's check Service implementation.
-	// Service KickUser does NOT notify the specific user directly with "kicked_from_room" message, only "user.kicked" broadcast.
-	// Original code: _ = targetSession.Conn.WriteJSON(...)
-	// I should update Service to send this notification as well if possible, or do it here.
-	// But Service encapsulates the logic.
-	// Let's rely on Broadcast "user.kicked" which the client presumably handles?
-	// Or we can add it to Service later.
-	// For now, this is a reasonable subset.
+	sendResult(u, event.Type, map[string]string{
+		"oldHost": u.ID,
+		"newHost": r.Host,
+	}, resp.SuccessCodeRoomTransferHost)
 }
 
 func updateLiveRoomSession(session *user.Session, roomID string, isHost bool) {
@@ -179,18 +369,5 @@ func updateLiveRoomSessionByID(userID string, roomID string, isHost bool) {
 }
 
 func roomSummaries(rooms []*room.Room) []RoomSummary {
-	summaryList := make([]RoomSummary, 0, len(rooms))
-	for _, r := range rooms {
-		summaryList = append(summaryList, RoomSummary{
-			ID:          r.ID,
-			RoomName:    r.RoomName,
-			Host:        r.Host,
-			PlayerCount: len(r.Players),
-			MaxPlayers:  r.MaxPlayers,
-			GameMode:    string(r.GameMode),
-			HasPassword: r.Password != "",
-			CreatedAt:   r.CreatedAt,
-		})
-	}
-	return summaryList
+	return room.Summaries(rooms)
 }
@@ -2,8 +2,10 @@ package ws
 
 import (
 	"context"
+	"github.com/Ryeom/board-game/internal/domain/room"
 	resp "github.com/Ryeom/board-game/internal/response"
 	"github.com/Ryeom/board-game/internal/user"
+	"github.com/Ryeom/board-game/log"
 )
 
 // HandleSystemPing 핑 체크에 대한 응답
@@ -15,4 +17,27 @@ func HandleSystemError(ctx context.Context, user *user.Session, event SocketEven
 
 func HandleSystemNotice(ctx context.Context, user *user.Session, event SocketEvent) {}
 
-func HandleSystemSync(ctx context.Context, user *user.Session, event SocketEvent) {}
+// HandleSystemSync 현재 방 전체 상태와 마지막 이벤트 seq를 전송 (이벤트 재전송이 불가능할 때의 전체 동기화)
+func HandleSystemSync(ctx context.Context, u *user.Session, event SocketEvent) {
+	if u.RoomID == "" {
+		sendError(u, resp.ErrorCodeRoomNotInRoom)
+		return
+	}
+	sendRoomSnapshot(ctx, u)
+}
+
+func sendRoomSnapshot(ctx context.Context, u *user.Session) {
+	r, ok := room.GetRoom(ctx, u.RoomID)
+	if !ok {
+		sendError(u, resp.ErrorCodeRoomNotFound)
+		return
+	}
+	lastSeq, err := room.LastEventSeq(ctx, r.ID)
+	if err != nil {
+		log.Logger.Errorf("sendRoomSnapshot - Failed to read last event seq for room %s: %v", r.ID, err)
+	}
+	sendResult(u, EventSystemSync, SystemSyncResponse{
+		Room:    r,
+		LastSeq: lastSeq,
+	}, resp.SuccessCodeSystemSync)
+}
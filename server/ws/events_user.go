@@ -2,30 +2,48 @@ package ws
 
 import (
 	"context"
+	"errors"
 	redisutil "github.com/Ryeom/board-game/infra/redis"
+	"github.com/Ryeom/board-game/internal/auth"
+	chat "github.com/Ryeom/board-game/internal/domain/chat"
 	"github.com/Ryeom/board-game/internal/domain/room"
 	resp "github.com/Ryeom/board-game/internal/response"
 	"github.com/Ryeom/board-game/internal/user"
+	"github.com/Ryeom/board-game/internal/util"
 	log "github.com/Ryeom/board-game/log"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"strings"
 	"time"
 )
 
 // HandleUserIdentify 유저 초기 식별 (재접속 포함)
 func HandleUserIdentify(ctx context.Context, u *user.Session, event SocketEvent) {
 	var req UserIdentifyRequest
-	if err := bindEventData(event, &req); err != nil || req.UserID == "" || req.UserName == "" {
+	if err := bindEventData(event, &req); err != nil {
 		sendError(u, resp.ErrorCodeAuthInvalidRequest)
 		return
 	}
 
+	identity, guestToken, err := resolveIdentity(ctx, u, req)
+	if err != nil {
+		sendError(u, err.Error())
+		return
+	}
+	userID, userName := identity.UserID, identity.Name
+	u.ActualUserID = identity.UserID
+	u.IsGuest = identity.Guest
+	u.IsAdmin = identity.Admin
+	u.AuthSessionID = identity.SessionID
+
 	oldSessionID := u.ID
 
 	// 기존 세션 확인 (재접속 감지)
-	prevSession, err := user.GetSession(req.UserID)
+	prevSession, err := user.GetSession(userID)
 	if err == nil && prevSession != nil && prevSession.Status == "disconnected" && prevSession.RoomID != "" {
 		// 재접속: 기존 세션 정보 복원하되 새 연결 사용
 		u.ID = prevSession.ID
-		u.Name = prevSession.Name
+		u.Name = userName
 		u.RoomID = prevSession.RoomID
 		u.IsHost = prevSession.IsHost
 		u.Status = "connected"
@@ -56,6 +74,9 @@ func HandleUserIdentify(ctx context.Context, u *user.Session, event SocketEvent)
 			},
 		})
 
+		// 끊겨 있는 동안 놓친 방 이벤트 재전송 (불가능하면 전체 동기화)
+		lastSeq, resynced := resumeRoomEvents(ctx, u, req.ResumeFrom)
+
 		// 게임 진행 중이면 게임 상태 전송
 		r, roomOk := room.GetRoom(ctx, u.RoomID)
 		if roomOk && r.IsGameStarted {
@@ -73,18 +94,21 @@ func HandleUserIdentify(ctx context.Context, u *user.Session, event SocketEvent)
 		sendResult(u, EventUserIdentify, map[string]any{
 			"userId":      u.ID,
 			"userName":    u.Name,
+			"guest":       u.IsGuest,
 			"roomId":      u.RoomID,
 			"reconnected": true,
+			"lastSeq":     lastSeq,
+			"resynced":    resynced,
 		}, resp.SuccessCodeUserReconnected)
 		return
 	}
 
 	// 일반 식별 (신규 접속)
-	u.ID = req.UserID
-	u.Name = req.UserName
+	u.ID = userID
+	u.Name = userName
 	u.Status = "connected"
 
-	if oldSessionID != req.UserID {
+	if oldSessionID != userID {
 		ActiveSessions().Delete(oldSessionID)
 		ActiveSessions().Store(u.ID, u)
 	} else {
@@ -101,29 +125,140 @@ func HandleUserIdentify(ctx context.Context, u *user.Session, event SocketEvent)
 		u.ID, u.Name, u.IP, u.ConnectedAt.Format(time.RFC3339),
 	)
 
-	sendResult(u, EventUserIdentify, map[string]string{
+	result := map[string]any{
 		"userId":   u.ID,
 		"userName": u.Name,
-	}, resp.SuccessCodeUserIdentify)
+		"guest":    u.IsGuest,
+	}
+	if guestToken != "" {
+		result["guestToken"] = guestToken // 재접속 시 token으로 다시 보내야 같은 게스트로 식별됨
+	}
+	sendResult(u, EventUserIdentify, result, resp.SuccessCodeUserIdentify)
+}
+
+// resolveIdentity 업그레이드 시 검증된 토큰, identify의 토큰, 게스트 모드 순으로 접속 주체를 결정한다.
+// 클라이언트가 보낸 ID는 신뢰하지 않으며, 가입 유저의 닉네임은 DB에서 불러온다.
+// 새 게스트에게는 재접속용 게스트 토큰을 함께 반환한다.
+func resolveIdentity(ctx context.Context, u *user.Session, req UserIdentifyRequest) (*auth.Identity, string, error) {
+	var identity *auth.Identity
+	newGuest := false
+
+	switch {
+	case req.Token != "":
+		verified, err := auth.Authenticate(ctx, req.Token)
+		if err != nil {
+			return nil, "", err
+		}
+		// 업그레이드 시 인증된 연결은 다른 사용자로 바꿀 수 없음
+		if u.ActualUserID != "" && u.ActualUserID != verified.UserID {
+			log.Logger.Warningf("resolveIdentity - Token user %s does not match connection user %s", verified.UserID, u.ActualUserID)
+			return nil, "", errors.New(resp.ErrorCodeAuthInvalidToken)
+		}
+		identity = verified
+	case u.ActualUserID != "":
+		identity = &auth.Identity{UserID: u.ActualUserID, Name: u.Name, Guest: u.IsGuest, SessionID: u.AuthSessionID}
+	case req.Guest:
+		guestID := "guest-" + uuid.NewString()
+		name := strings.TrimSpace(req.UserName)
+		if name == "" {
+			name = "Guest-" + guestID[len("guest-"):len("guest-")+4]
+		}
+		identity = &auth.Identity{UserID: guestID, Name: name, Guest: true}
+		newGuest = true
+	default:
+		return nil, "", errors.New(resp.ErrorCodeWSExpectedIdentify)
+	}
+
+	if identity.Guest {
+		if !viper.GetBool("bg.ws.guest-enabled") {
+			return nil, "", errors.New(resp.ErrorCodeWSGuestDisabled)
+		}
+		if identity.Name == "" {
+			identity.Name = "Guest"
+		}
+		if !newGuest {
+			return identity, "", nil
+		}
+		guestToken, err := auth.GenerateGuestJWT(identity.UserID, identity.Name)
+		if err != nil {
+			log.Logger.Errorf("resolveIdentity - Failed to issue guest token: %v", err)
+			return nil, "", errors.New(resp.ErrorCodeAuthJwtGenerationFailed)
+		}
+		return identity, guestToken, nil
+	}
+
+	account, err := user.FindUserByID(identity.UserID)
+	if err != nil {
+		log.Logger.Errorf("resolveIdentity - FindUserByID Error for ID %s: %v", identity.UserID, err)
+		return nil, "", errors.New(resp.ErrorCodeAuthUserLookupFailed)
+	}
+	if account == nil || !account.IsActive {
+		return nil, "", errors.New(resp.ErrorCodeUserNotFound)
+	}
+	identity.Name = account.Nickname
+	identity.Admin = user.IsAdmin(account)
+	return identity, "", nil
+}
+
+// isIdentified user.identify를 통해 토큰으로 검증된 사용자에 연결이 묶였는지 확인
+func isIdentified(u *user.Session) bool {
+	return u.ActualUserID != "" && u.ID == u.ActualUserID
+}
+
+// resumeRoomEvents resumeFrom 이후의 방 이벤트를 버퍼에서 꺼내 순서대로 재전송한다.
+// 버퍼가 끊김 구간을 모두 담고 있지 않으면 system.sync 전체 동기화로 대체하고 resynced=true를 반환한다.
+// 재전송은 room_sessions 재등록 이후에 수행되므로 실시간 이벤트와 겹칠 수 있으며, 클라이언트는 seq로 중복을 제거한다.
+func resumeRoomEvents(ctx context.Context, u *user.Session, resumeFrom int64) (int64, bool) {
+	if resumeFrom <= 0 {
+		lastSeq, _ := room.LastEventSeq(ctx, u.RoomID)
+		return lastSeq, false
+	}
+
+	events, lastSeq, complete, err := room.EventsSince(ctx, u.RoomID, resumeFrom)
+	if err != nil {
+		log.Logger.Errorf("resumeRoomEvents - Failed to load buffered events for room %s: %v", u.RoomID, err)
+	}
+	if err != nil || !complete {
+		log.Logger.Infof("resumeRoomEvents - Gap too large for %s in room %s (resumeFrom=%d, lastSeq=%d). Sending full sync.",
+			u.ID, u.RoomID, resumeFrom, lastSeq)
+		sendRoomSnapshot(ctx, u)
+		return lastSeq, true
+	}
+
+	for _, ev := range events {
+		sendBufferedEvent(u, ev)
+	}
+	log.Logger.Debugf("resumeRoomEvents - Replayed %d events to %s in room %s", len(events), u.ID, u.RoomID)
+	return lastSeq, false
 }
 
 // HandleUserUpdate 유저 정보 업데이트
 func HandleUserUpdate(ctx context.Context, u *user.Session, event SocketEvent) {
 	var req UserUpdateRequest
-	if err := bindEventData(event, &req); err != nil {
+	if err := bindEventData(event, &req); err != nil || (req.Lang != "" && !util.SupportedLanguages[req.Lang]) {
 		sendError(u, resp.ErrorCodeUserInvalidRequest)
 		return
 	}
-	updated := map[string]string{}
-
+	// 닉네임은 검증된 토큰(게스트) 또는 DB(가입 유저)에서만 정해진다.
 	if req.Name != "" {
-		u.Name = req.Name
-		updated["name"] = req.Name
+		sendError(u, resp.ErrorCodeUserNameNotEditable)
+		return
+	}
+	// 연결 상태(Status)는 재접속 대기, 방 정리, 친구 접속 상태의 기준이므로 표시 상태만 바꿀 수 있다.
+	if req.Status != "" && !user.Activities[req.Status] {
+		sendError(u, resp.ErrorCodeUserInvalidStatus)
+		return
 	}
+	updated := map[string]string{}
+
 	if req.Status != "" {
-		u.Status = req.Status
+		u.Activity = req.Status
 		updated["status"] = req.Status
 	}
+	if req.Lang != "" {
+		u.Lang = util.NormalizeLanguage(req.Lang)
+		updated["lang"] = u.Lang
+	}
 
 	if len(updated) == 0 {
 		sendError(u, resp.ErrorCodeUserNoUpdates)
@@ -142,6 +277,7 @@ func HandleUserUpdate(ctx context.Context, u *user.Session, event SocketEvent) {
 // HandleUserDisconnect 유저 연결 종료
 func HandleUserDisconnect(ctx context.Context, u *user.Session, event SocketEvent) {
 	ActiveSessions().Delete(u.ID)
+	leaveMatchQueue(ctx, u.ID)
 
 	roomID := u.RoomID
 	if roomID == "" {
@@ -151,18 +287,37 @@ func HandleUserDisconnect(ctx context.Context, u *user.Session, event SocketEven
 		return
 	}
 
-	r, ok := room.GetRoom(ctx, roomID)
-	if !ok {
-		log.Logger.Warningf("HandleUserDisconnect - Room %s not found for user %s. Cleaning up session.", u.RoomID, u.ID)
+	// 게임 진행 여부 확인과 방에서의 제거를 한 트랜잭션으로 처리 (그 사이 게임이 시작되어도 플레이어가 빠지지 않도록)
+	gameInProgress := false
+	wasHost := false
+	r, mutation, err := room.Update(ctx, roomID, func(r *room.Room) (room.Mutation, error) {
+		gameInProgress = false
+		wasHost = r.Host == u.ID
+		// 관전자는 재접속을 기다리지 않고 게임 중에도 바로 제거
+		if r.RemoveSpectator(u.ID) {
+			return room.MutationSave, nil
+		}
+		gameInProgress = r.IsGameStarted
+		if gameInProgress || !r.Leave(u.ID) {
+			return room.MutationSkip, nil
+		}
+		if len(r.Players) == 0 {
+			return room.MutationDelete, nil
+		}
+		return room.MutationSave, nil
+	})
+	if err != nil {
+		log.Logger.Warningf("HandleUserDisconnect - Could not update room %s for user %s: %v. Cleaning up session.", roomID, u.ID, err)
 		if err := user.DeleteUserSession(u.ID); err != nil {
-			log.Logger.Errorf("HandleUserDisconnect - Failed to delete user session %s (room not found): %v", u.ID, err)
+			log.Logger.Errorf("HandleUserDisconnect - Failed to delete user session %s (room not updated): %v", u.ID, err)
 		}
 		return
 	}
 
 	// 게임 진행 중이면 세션 보존 (재접속 대기)
-	if r.IsGameStarted {
+	if gameInProgress {
 		u.Status = "disconnected"
+		u.DisconnectedAt = time.Now()
 		u.Conn = nil
 		if err := user.SaveUserSession(u); err != nil {
 			log.Logger.Errorf("HandleUserDisconnect - Failed to save disconnected session %s: %v", u.ID, err)
@@ -184,29 +339,12 @@ func HandleUserDisconnect(ctx context.Context, u *user.Session, event SocketEven
 		log.Logger.Errorf("HandleUserDisconnect - Failed to remove user %s from room %s sessions set: %v", u.ID, roomID, err)
 	}
 
-	updatedPlayers := make([]string, 0, len(r.Players))
-	for _, pid := range r.Players {
-		if pid != u.ID {
-			updatedPlayers = append(updatedPlayers, pid)
-		}
-	}
-	r.Players = updatedPlayers
-	r.ResetReady()
-
-	if len(r.Players) == 0 {
-		_ = room.DeleteRoom(ctx, r.ID)
+	if mutation == room.MutationDelete {
 		if err := redisutil.Delete(redisutil.RedisTargetUser, user.RoomIndexKey(r.ID)); err != nil {
 			log.Logger.Errorf("HandleUserDisconnect - Failed to delete room %s sessions set: %v", r.ID, err)
 		}
 		log.Logger.Infof("Room %s deleted as no players left after disconnect.", r.ID)
 	} else {
-		if r.Host == u.ID {
-			r.Host = updatedPlayers[0]
-			log.Logger.Infof("Host of room %s changed from %s to %s due to disconnect.", r.ID, u.ID, r.Host)
-		}
-		if err := r.Save(); err != nil {
-			log.Logger.Errorf("HandleUserDisconnect - Failed to save room %s after disconnect: %v", r.ID, err)
-		}
 		GlobalBroadcaster.BroadcastToRoom(r.ID, map[string]any{
 			"type": EventUserLeft,
 			"data": map[string]string{
@@ -215,6 +353,9 @@ func HandleUserDisconnect(ctx context.Context, u *user.Session, event SocketEven
 				"newHost":  r.Host,
 			},
 		})
+		if mutation == room.MutationSave {
+			postDisconnectTimeline(r, u, wasHost)
+		}
 	}
 
 	u.RoomID = ""
@@ -248,10 +389,25 @@ func HandleUserStatus(ctx context.Context, u *user.Session, event SocketEvent) {
 	}
 
 	sendResult(u, event.Type, map[string]any{
-		"online": true,
-		"userId": target.ID,
-		"name":   target.Name,
-		"roomId": target.RoomID,
-		"status": target.Status,
+		"online":   true,
+		"userId":   target.ID,
+		"name":     target.Name,
+		"roomId":   target.RoomID,
+		"status":   target.Status,
+		"activity": target.Activity,
 	}, resp.SuccessCodeUserStatusFetch)
 }
+
+// postDisconnectTimeline 접속 종료로 방을 나간 기록(방장이 바뀌었으면 그것까지)을 채팅 타임라인에 남긴다.
+func postDisconnectTimeline(r *room.Room, u *user.Session, wasHost bool) {
+	timeline := ChatTimeline{}
+	timeline.Post(r.ID, chat.RecordSystem, resp.TimelineCodeLeft, map[string]string{"user": u.Name}, chat.Subject{Param: "user", UserID: u.ID})
+	if !wasHost || r.Host == u.ID {
+		return
+	}
+	hostName := r.Host
+	if hostSession, err := user.GetSession(r.Host); err == nil && hostSession.Name != "" {
+		hostName = hostSession.Name
+	}
+	timeline.Post(r.ID, chat.RecordSystem, resp.TimelineCodeHostChanged, map[string]string{"user": hostName}, chat.Subject{Param: "user", UserID: r.Host})
+}
@@ -2,13 +2,19 @@ package ws
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/Ryeom/board-game/internal/game"
+	resp "github.com/Ryeom/board-game/internal/response"
 	"github.com/Ryeom/board-game/internal/user"
 )
 
 func dispatchSocketEvent(ctx context.Context, user *user.Session, event SocketEvent) {
+	if !isIdentified(user) && !unauthenticatedEvents[event.Type] {
+		sendError(user, resp.ErrorCodeWSExpectedIdentify)
+		return
+	}
 	handler := getHandler(event.Type)
 	handler(ctx, user, event)
 }
@@ -34,12 +40,21 @@ func mergeHandlers(maps ...map[EventType]ExecutionEvent) map[EventType]Execution
 
 var eventHandlers = mergeHandlers(
 	roomEvents,
+	matchEvents,
 	userEvents,
 	gameEvents,
 	chatEvents,
+	friendEvents,
 	systemEvents,
 )
 
+// user.identify 이전에도 허용되는 이벤트
+var unauthenticatedEvents = map[EventType]bool{
+	EventUserIdentify:   true,
+	EventUserDisconnect: true,
+	EventSystemPing:     true,
+}
+
 // 방 관련 이벤트 핸들러
 var roomEvents = map[EventType]ExecutionEvent{
 	EventRoomCreate: HandleRoomCreate, // 방 생성
@@ -49,9 +64,23 @@ var roomEvents = map[EventType]ExecutionEvent{
 	EventRoomUpdate: HandleRoomUpdate, // 방 설정 변경
 	EventRoomReady:  HandleRoomReady,  // 준비 상태 토글
 	EventRoomKick:   HandleRoomKick,   // 강제 퇴장
+
+	EventRoomJoinByCode:   HandleRoomJoinByCode,   // 초대 코드로 참가
+	EventRoomInvite:       HandleRoomInvite,       // 초대 코드 발급
+	EventRoomSpectate:     HandleRoomSpectate,     // 관전자로 입장
+	EventRoomPromote:      HandleRoomPromote,      // 관전자를 플레이어로
+	EventRoomBan:          HandleRoomBan,          // 유저 차단
+	EventRoomUnban:        HandleRoomUnban,        // 차단 해제
+	EventRoomTransferHost: HandleRoomTransferHost, // 방장 넘기기
 	//"room.delete": HandleRoomDelete, // 방 삭제
 }
 
+// 빠른 대전 관련 이벤트 핸들러
+var matchEvents = map[EventType]ExecutionEvent{
+	EventMatchQueue:  HandleMatchQueue,  // 대기열 등록
+	EventMatchCancel: HandleMatchCancel, // 대기 취소
+}
+
 // 유저 관련 이벤트 핸들러
 var userEvents = map[EventType]ExecutionEvent{
 	EventUserIdentify:   HandleUserIdentify,   // 유저 초기 식별
@@ -68,6 +97,8 @@ var gameEvents = map[EventType]ExecutionEvent{
 	EventGameSync:   HandleGameSync,   // 게임 상태 동기화
 	EventGamePause:  HandleGamePause,  // 게임 일시정지
 	EventGameInfo:   HandleGameInfo,   // 게임 설명 출력
+
+	EventGameRematch: HandleGameRematch, // 재대결 투표
 }
 
 // 채팅 관련 이벤트 핸들러
@@ -77,6 +108,14 @@ var chatEvents = map[EventType]ExecutionEvent{
 	EventChatMute:    HandleChatMute,    // 유저 채팅 제한
 }
 
+// 친구/DM 관련 이벤트 핸들러 (친구 요청/수락/차단은 REST API)
+var friendEvents = map[EventType]ExecutionEvent{
+	EventFriendList: HandleFriendList, // 친구 목록 (접속 상태, 안 읽은 메시지 수)
+	EventDMSend:     HandleDMSend,     // 친구에게 메시지 전송
+	EventDMHistory:  HandleDMHistory,  // 대화 내역 조회
+	EventDMRead:     HandleDMRead,     // 읽음 처리
+}
+
 // 시스템 관련 이벤트 핸들러
 var systemEvents = map[EventType]ExecutionEvent{
 	EventSystemPing:   HandleSystemPing,   // 핑 체크
@@ -89,7 +128,7 @@ func sendResult(u *user.Session, eventType EventType, data interface{}, resultMs
 	if u.Conn == nil {
 		return
 	}
-	res := createWebSocketResult(eventType, data, resultMsgCode, "ko")
+	res := createWebSocketResult(eventType, data, resultMsgCode, u.Lang)
 	u.WriteMutex.Lock()
 	defer u.WriteMutex.Unlock()
 	_ = u.Conn.WriteJSON(res)
@@ -98,12 +137,26 @@ func sendError(u *user.Session, resultMsgCode string) {
 	if u.Conn == nil {
 		return
 	}
-	res := createWebSocketResult(EventError, nil, resultMsgCode, "ko")
+	res := createWebSocketResult(EventError, nil, resultMsgCode, u.Lang)
 	u.WriteMutex.Lock()
 	defer u.WriteMutex.Unlock()
 	_ = u.Conn.WriteJSON(res)
 }
 
+// sendBufferedEvent 버퍼에 저장된 방 이벤트를 수신자 언어로 지역화하여 재전송
+func sendBufferedEvent(u *user.Session, payload []byte) {
+	if u.Conn == nil {
+		return
+	}
+	var data map[string]any
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return
+	}
+	u.WriteMutex.Lock()
+	defer u.WriteMutex.Unlock()
+	_ = u.Conn.WriteJSON(localizeEvent(data, u.Lang))
+}
+
 // GameStatePayload WebSocketResult.Data
 type GameStatePayload struct {
 	RoomId              string      `json:"roomId"`              // 현재 게임이 진행 중인 방의 ID
@@ -0,0 +1,27 @@
+package ws
+
+import (
+	"context"
+
+	"github.com/Ryeom/board-game/internal/service"
+	"github.com/Ryeom/board-game/log"
+	"github.com/spf13/viper"
+)
+
+// StartJanitor 설정을 읽어 유휴 방/오래된 세션 정리 작업을 시작한다. (bg.janitor.enabled = false면 시작하지 않음)
+func StartJanitor(ctx context.Context) {
+	if !viper.GetBool("bg.janitor.enabled") {
+		log.Logger.Infof("Janitor disabled")
+		return
+	}
+	j := service.NewJanitor(GlobalGameService, &WsBroadcaster{}, service.JanitorConfig{
+		Interval:        viper.GetDuration("bg.janitor.interval"),
+		RoomIdle:        viper.GetDuration("bg.janitor.room-idle"),
+		WaitingMaxIdle:  viper.GetDuration("bg.janitor.waiting-max-idle"),
+		DisconnectGrace: viper.GetDuration("bg.janitor.disconnect-grace"),
+	})
+	j.OnClosed = func(userID string) {
+		updateLiveRoomSessionByID(userID, "", false)
+	}
+	go j.Run(ctx)
+}
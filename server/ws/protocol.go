@@ -6,7 +6,9 @@ import (
 	"net/http"
 	"time"
 
+	chat "github.com/Ryeom/board-game/internal/domain/chat"
 	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/util"
 )
 
 type EventType string
@@ -39,8 +41,17 @@ func bindEventData[T any](event SocketEvent, dest *T) error {
 	return json.Unmarshal(b, dest)
 }
 
+// bindEventFilter 목록 조회 이벤트의 filter 필드를 구조체로 변환
+func bindEventFilter[T any](event SocketEvent, dest *T) error {
+	b, err := json.Marshal(event.Filter)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dest)
+}
+
 func createWebSocketResult(eventType EventType, data interface{}, resultMsgCode, lang string) *WebSocketResult {
-	msgData, found := resp.GetDefineCode(resultMsgCode, lang)
+	msgData, found := resp.GetDefineCode(resultMsgCode, util.NormalizeLanguage(lang))
 	if !found {
 		msgData.Message = fmt.Sprintf("Unknown response code: %s", resultMsgCode)
 		msgData.HttpStatus = http.StatusOK
@@ -58,3 +69,60 @@ func createWebSocketResult(eventType EventType, data interface{}, resultMsgCode,
 		Timestamp:  time.Now(),
 	}
 }
+
+// localizeEvent 방 브로드캐스트 이벤트의 message/action을 수신자 언어로 다시 채운 사본을 반환한다.
+// 응답 코드(errorCode)가 없는 이벤트는 그대로 반환한다.
+func localizeEvent(data map[string]any, lang string) map[string]any {
+	data = localizeChatRecord(data, lang)
+	code, ok := data["errorCode"].(string)
+	if !ok || code == "" {
+		return data
+	}
+	msgData, found := resp.GetDefineCode(code, util.NormalizeLanguage(lang))
+	if !found {
+		return data
+	}
+	localized := make(map[string]any, len(data))
+	for k, v := range data {
+		localized[k] = v
+	}
+	localized["message"] = msgData.Message
+	localized["action"] = msgData.Action
+	return localized
+}
+
+// localizeChatRecord 타임라인 기록(chat.message 중 key가 있는 것)의 본문을 수신자 언어로 다시 만든다.
+func localizeChatRecord(data map[string]any, lang string) map[string]any {
+	if data["type"] != string(EventChatMessage) {
+		return data
+	}
+	record, ok := data["data"].(map[string]any)
+	if !ok {
+		return data
+	}
+	key, ok := record["key"].(string)
+	if !ok || key == "" {
+		return data
+	}
+	params := make(map[string]string)
+	if raw, ok := record["params"].(map[string]any); ok {
+		for k, v := range raw {
+			if str, ok := v.(string); ok {
+				params[k] = str
+			}
+		}
+	}
+
+	localizedRecord := make(map[string]any, len(record))
+	for k, v := range record {
+		localizedRecord[k] = v
+	}
+	localizedRecord["message"] = chat.Render(key, params, lang)
+
+	localized := make(map[string]any, len(data))
+	for k, v := range data {
+		localized[k] = v
+	}
+	localized["data"] = localizedRecord
+	return localized
+}
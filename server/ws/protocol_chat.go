@@ -1,5 +1,37 @@
 package ws
 
+import (
+	"time"
+
+	chat "github.com/Ryeom/board-game/internal/domain/chat"
+)
+
 type ChatSendRequest struct {
-	Message string `json:"message"`
+	Message string       `json:"message"`
+	Channel chat.Channel `json:"channel,omitempty"` // all, players, spectators, team:<팀>. 비우면 플레이어는 all, 관전자는 spectators
+}
+
+type ChatHistoryResponse struct {
+	RoomID     string             `json:"roomId"`
+	History    []*chat.ChatRecord `json:"history"`              // 오래된 순
+	NextCursor string             `json:"nextCursor,omitempty"` // 더 오래된 메시지 요청 시 filter.before로 전달
+}
+
+// ChatMuteRequest chat.mute 대상과 기간. durationSecs가 0이면 음소거 해제.
+type ChatMuteRequest struct {
+	UserID       string `json:"userId"`
+	DurationSecs int    `json:"durationSecs"`
+	Reason       string `json:"reason,omitempty"`
+	Global       bool   `json:"global,omitempty"` // 관리자 전용: 모든 방에서 채팅 금지
+}
+
+// ChatMutedPayload chat.muted 알림
+type ChatMutedPayload struct {
+	UserID  string     `json:"userId"`
+	RoomID  string     `json:"roomId,omitempty"`
+	Muted   bool       `json:"muted"`
+	Global  bool       `json:"global"`
+	MutedBy string     `json:"mutedBy,omitempty"`
+	Reason  string     `json:"reason,omitempty"`
+	Until   *time.Time `json:"until,omitempty"`
 }
@@ -9,6 +9,19 @@ const (
 	EventRoomReady  EventType = "room.ready"
 	EventRoomKick   EventType = "room.kick"
 
+	EventRoomJoinByCode   EventType = "room.joinByCode"
+	EventRoomInvite       EventType = "room.invite"
+	EventRoomSpectate     EventType = "room.spectate"
+	EventRoomPromote      EventType = "room.promote"
+	EventRoomBan          EventType = "room.ban"
+	EventRoomUnban        EventType = "room.unban"
+	EventRoomTransferHost EventType = "room.transferHost"
+	EventRoomClosed       EventType = "room.closed"
+
+	EventMatchQueue  EventType = "match.queue"
+	EventMatchCancel EventType = "match.cancel"
+	EventMatchFound  EventType = "match.found"
+
 	EventUserIdentify     EventType = "user.identify"
 	EventUserUpdate       EventType = "user.update"
 	EventUserDisconnect   EventType = "user.disconnect"
@@ -31,11 +44,26 @@ const (
 	EventGameTimerStarted    EventType = "game.timer.started"
 	EventGameTimerReset      EventType = "game.timer.reset"
 	EventGameTimerExpired    EventType = "game.timer.expired"
+	EventGameSpectateSync    EventType = "game.spectate.sync"
+	EventGameRematch         EventType = "game.rematch"
+	EventGameRematchVote     EventType = "game.rematch.vote"
+	EventGameRematchClosed   EventType = "game.rematch.closed"
 
 	EventChatSend    EventType = "chat.send"
 	EventChatMessage EventType = "chat.message"
 	EventChatHistory EventType = "chat.history"
 	EventChatMute    EventType = "chat.mute"
+	EventChatMuted   EventType = "chat.muted"
+
+	EventFriendList     EventType = "friend.list"
+	EventFriendRequest  EventType = "friend.request"
+	EventFriendAccepted EventType = "friend.accepted"
+	EventFriendRemoved  EventType = "friend.removed"
+
+	EventDMSend    EventType = "dm.send"
+	EventDMMessage EventType = "dm.message"
+	EventDMHistory EventType = "dm.history"
+	EventDMRead    EventType = "dm.read"
 
 	EventSystemPing   EventType = "system.ping"
 	EventSystemPong   EventType = "system.pong"
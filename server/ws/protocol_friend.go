@@ -0,0 +1,28 @@
+package ws
+
+import (
+	"github.com/Ryeom/board-game/internal/domain/dm"
+)
+
+// DMSendRequest dm.send 받는 친구와 내용
+type DMSendRequest struct {
+	To      string `json:"to"`
+	Message string `json:"message"`
+}
+
+// DMHistoryFilter dm.history의 filter. 대화 상대와 페이지 조건.
+type DMHistoryFilter struct {
+	UserID string `json:"userId"`
+	dm.HistoryQuery
+}
+
+type DMHistoryResponse struct {
+	UserID     string        `json:"userId"`
+	History    []*dm.Message `json:"history"`              // 오래된 순
+	NextCursor string        `json:"nextCursor,omitempty"` // 더 오래된 메시지 요청 시 filter.before로 전달
+}
+
+// DMReadRequest dm.read 상대에게서 받은 메시지를 모두 읽음 처리
+type DMReadRequest struct {
+	UserID string `json:"userId"`
+}
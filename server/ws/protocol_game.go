@@ -6,6 +6,10 @@ type GameActionRequest struct {
 	Action map[string]interface{} `json:"action"`
 }
 
+type GameRematchRequest struct {
+	Accept *bool `json:"accept"`
+}
+
 type GameInfoRequest struct {
 	GameMode string `json:"gameMode"`
 }
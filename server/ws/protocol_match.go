@@ -0,0 +1,22 @@
+package ws
+
+import (
+	"time"
+
+	"github.com/Ryeom/board-game/internal/game"
+)
+
+type MatchQueueRequest struct {
+	GameMode    game.Mode `json:"gameMode"`
+	PlayerCount int       `json:"playerCount"`
+	Ranked      bool      `json:"ranked,omitempty"`     // 레이팅 매칭 (레이팅은 계정에 저장된 값을 사용)
+	RatingBand  int       `json:"ratingBand,omitempty"` // 레이팅 매칭에서 허용하는 레이팅 차이 (생략 시 제한 없음)
+}
+
+type MatchQueueResponse struct {
+	GameMode    game.Mode `json:"gameMode"`
+	PlayerCount int       `json:"playerCount"`
+	Ranked      bool      `json:"ranked"`
+	Rating      int       `json:"rating,omitempty"` // 레이팅 매칭에 쓰인 계정 레이팅
+	QueuedAt    time.Time `json:"queuedAt"`
+}
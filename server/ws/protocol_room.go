@@ -1,11 +1,18 @@
 package ws
 
-import "time"
+import (
+	"time"
+
+	"github.com/Ryeom/board-game/internal/domain/room"
+	"github.com/Ryeom/board-game/internal/game"
+)
 
 type RoomCreateRequest struct {
-	RoomName   string `json:"roomName"`
-	Password   string `json:"password,omitempty"`
-	MaxPlayers int    `json:"maxPlayers"`
+	RoomName   string        `json:"roomName"`
+	Password   string        `json:"password,omitempty"`
+	MaxPlayers int           `json:"maxPlayers"`         // 0(생략)이면 모드의 최대 인원
+	GameMode   game.Mode     `json:"gameMode,omitempty"` // 생략 시 hanabi
+	Settings   game.Settings `json:"settings"`           // 생략한 항목은 모드 기본값
 }
 
 type RoomJoinRequest struct {
@@ -13,14 +20,47 @@ type RoomJoinRequest struct {
 	Password string `json:"password,omitempty"`
 }
 
+type RoomJoinByCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type RoomInviteRequest struct {
+	TTLSeconds int `json:"ttlSeconds,omitempty"` // 생략 시 bg.invite.default-ttl
+	MaxUses    int `json:"maxUses,omitempty"`    // 1이면 1회용, 0(생략)이면 만료 전까지 여러 번 사용
+}
+
+type RoomInviteResponse struct {
+	Code      string    `json:"code"`
+	RoomID    string    `json:"roomId"`
+	MaxUses   int       `json:"maxUses"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Link      string    `json:"link"`
+}
+
+type RoomSpectateRequest struct {
+	RoomID   string `json:"roomId"`
+	Password string `json:"password,omitempty"`
+}
+
+type RoomPromoteRequest struct {
+	UserID string `json:"userId,omitempty"` // 생략하면 본인
+}
+
 type RoomKickRequest struct {
 	UserID string `json:"userId"`
 }
 
+// RoomModerationRequest room.ban / room.unban / room.transferHost 대상 유저
+type RoomModerationRequest struct {
+	UserID string `json:"userId"`
+}
+
 type RoomCreateResponse struct {
 	RoomID     string        `json:"roomId"`
 	RoomName   string        `json:"roomName"`
 	MaxPlayers int           `json:"maxPlayers"`
+	GameMode   game.Mode     `json:"gameMode"`
+	Settings   game.Settings `json:"settings"`
 	RoomList   []RoomSummary `json:"roomList"`
 }
 
@@ -31,16 +71,8 @@ type RoomLeaveResponse struct {
 }
 
 type RoomListResponse struct {
-	Rooms []RoomSummary `json:"rooms"`
+	Rooms      []RoomSummary `json:"rooms"`
+	NextCursor string        `json:"nextCursor,omitempty"` // 다음 페이지 요청 시 filter.cursor로 전달
 }
 
-type RoomSummary struct {
-	ID          string    `json:"id"`
-	RoomName    string    `json:"roomName"`
-	Host        string    `json:"host"`
-	PlayerCount int       `json:"playerCount"`
-	MaxPlayers  int       `json:"maxPlayers"`
-	GameMode    string    `json:"gameMode"`
-	HasPassword bool      `json:"hasPassword"`
-	CreatedAt   time.Time `json:"createdAt"`
-}
+type RoomSummary = room.Summary
@@ -0,0 +1,8 @@
+package ws
+
+import "github.com/Ryeom/board-game/internal/domain/room"
+
+type SystemSyncResponse struct {
+	Room    *room.Room `json:"room"`
+	LastSeq int64      `json:"lastSeq"`
+}
@@ -0,0 +1,54 @@
+package ws
+
+import (
+	"testing"
+
+	resp "github.com/Ryeom/board-game/internal/response"
+)
+
+func TestLocalizeEvent_UsesRecipientLanguage(t *testing.T) {
+	ko := createWebSocketResult(EventRoomReady, nil, resp.SuccessCodeRoomReady, "ko")
+	en := createWebSocketResult(EventRoomReady, nil, resp.SuccessCodeRoomReady, "en-US")
+	if ko.Message == en.Message {
+		t.Fatalf("ko/en 메시지가 달라야 하지만 둘 다 %q", ko.Message)
+	}
+
+	data, err := toEventMap(ko)
+	if err != nil {
+		t.Fatalf("toEventMap 실패: %v", err)
+	}
+	localized := localizeEvent(data, "en")
+	if localized["message"] != en.Message || localized["action"] != en.Action {
+		t.Errorf("영어 메시지로 바뀌어야 하지만 %v / %v", localized["message"], localized["action"])
+	}
+	if data["message"] != ko.Message {
+		t.Error("localizeEvent가 원본을 변경하면 안됨")
+	}
+}
+
+func TestLocalizeEvent_WithoutCodeIsUnchanged(t *testing.T) {
+	data := map[string]any{"type": string(EventUserReconnected), "data": map[string]any{"userId": "u1"}}
+	if got := localizeEvent(data, "en"); got["message"] != nil {
+		t.Errorf("응답 코드가 없는 이벤트는 그대로여야 하지만 %v", got)
+	}
+}
+
+func TestLocalizeEvent_RendersTimelineRecord(t *testing.T) {
+	data := map[string]any{
+		"type": string(EventChatMessage),
+		"data": map[string]any{
+			"type":    "system",
+			"key":     resp.TimelineCodeJoined,
+			"params":  map[string]any{"user": "Alice"},
+			"message": "Alice님이 입장했습니다.",
+		},
+	}
+	localized := localizeEvent(data, "en")
+	record := localized["data"].(map[string]any)
+	if record["message"] != "Alice joined the room." {
+		t.Errorf("영어로 다시 렌더링되어야 하지만 %v", record["message"])
+	}
+	if data["data"].(map[string]any)["message"] != "Alice님이 입장했습니다." {
+		t.Error("localizeEvent가 원본 기록을 변경하면 안됨")
+	}
+}
@@ -1,13 +1,16 @@
 package ws
 
 type UserIdentifyRequest struct {
-	UserID   string `json:"userId"`
-	UserName string `json:"userName"`
+	Token      string `json:"token,omitempty"`      // 업그레이드 시 토큰을 보내지 않았다면 필수 (로그인 토큰 또는 발급받은 게스트 토큰)
+	Guest      bool   `json:"guest,omitempty"`      // 토큰 없이 게스트로 접속 (bg.ws.guest-enabled 필요)
+	UserName   string `json:"userName,omitempty"`   // 게스트 닉네임 (가입 유저는 DB 닉네임 사용)
+	ResumeFrom int64  `json:"resumeFrom,omitempty"` // 재접속 시 클라이언트가 마지막으로 받은 방 이벤트 seq
 }
 
 type UserUpdateRequest struct {
-	Name   string `json:"name,omitempty"`
-	Status string `json:"status,omitempty"`
+	Name   string `json:"name,omitempty"`   // 받지 않음 (ERROR_USER_NAME_NOT_EDITABLE)
+	Status string `json:"status,omitempty"` // 표시 상태 (online, away, busy)
+	Lang   string `json:"lang,omitempty"`   // 응답 메시지 언어 (ko, en)
 }
 
 type UserStatusRequest struct {
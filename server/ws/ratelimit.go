@@ -0,0 +1,197 @@
+package ws
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Ryeom/board-game/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultMaxMessageSize = 8 * 1024 // 바이트
+	defaultMaxViolations  = 10       // violationWindow 안에 이만큼 제한에 걸리면 연결 종료
+	violationWindow       = time.Minute
+	ipBucketIdleTTL       = 10 * time.Minute // 이 시간 동안 쓰이지 않은 IP 버킷은 정리
+	defaultRuleEvent      = "*"
+)
+
+// RateRule 이벤트 하나에 대한 토큰 버킷 설정 (rate: 초당 충전 토큰, burst: 최대 토큰)
+// IP 값이 0이면 해당 이벤트는 IP 단위로 제한하지 않는다.
+type RateRule struct {
+	Event   string  `mapstructure:"event"`
+	Rate    float64 `mapstructure:"rate"`
+	Burst   float64 `mapstructure:"burst"`
+	IPRate  float64 `mapstructure:"ip-rate"`
+	IPBurst float64 `mapstructure:"ip-burst"`
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// take 경과 시간만큼 토큰을 충전한 뒤 하나를 소모한다.
+func (b *tokenBucket) take(now time.Time, rate, burst float64) bool {
+	if b.last.IsZero() {
+		b.tokens = burst
+	} else {
+		b.tokens += now.Sub(b.last).Seconds() * rate
+		if b.tokens > burst {
+			b.tokens = burst
+		}
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// rateLimitConfig settings.toml의 [bg.ws] 설정
+type rateLimitConfig struct {
+	maxMessageSize int64
+	maxViolations  int
+	defaultRule    RateRule
+	rules          map[EventType]RateRule
+}
+
+func (c *rateLimitConfig) ruleFor(eventType EventType) RateRule {
+	if rule, ok := c.rules[eventType]; ok {
+		return rule
+	}
+	return c.defaultRule
+}
+
+var (
+	rateConfig     *rateLimitConfig
+	rateConfigOnce sync.Once
+)
+
+func getRateLimitConfig() *rateLimitConfig {
+	rateConfigOnce.Do(func() {
+		rateConfig = loadRateLimitConfig()
+	})
+	return rateConfig
+}
+
+func loadRateLimitConfig() *rateLimitConfig {
+	cfg := &rateLimitConfig{
+		maxMessageSize: viper.GetInt64("bg.ws.max-message-size"),
+		maxViolations:  viper.GetInt("bg.ws.rate-limit.max-violations"),
+		defaultRule:    RateRule{Event: defaultRuleEvent, Rate: 10, Burst: 20},
+		rules:          make(map[EventType]RateRule),
+	}
+	if cfg.maxMessageSize <= 0 {
+		cfg.maxMessageSize = defaultMaxMessageSize
+	}
+	if cfg.maxViolations <= 0 {
+		cfg.maxViolations = defaultMaxViolations
+	}
+
+	var rules []RateRule
+	if err := viper.UnmarshalKey("bg.ws.rate-limit.rules", &rules); err != nil {
+		log.Logger.Errorf("loadRateLimitConfig - Invalid bg.ws.rate-limit.rules, using defaults: %v", err)
+	}
+	for _, rule := range rules {
+		if rule.Rate <= 0 || rule.Burst < 1 {
+			log.Logger.Warningf("loadRateLimitConfig - Ignoring rule for %q: rate and burst must be positive", rule.Event)
+			continue
+		}
+		if rule.Event == defaultRuleEvent {
+			cfg.defaultRule = rule
+			continue
+		}
+		cfg.rules[EventType(rule.Event)] = rule
+	}
+	return cfg
+}
+
+// sessionLimiter 연결 하나에 대한 규칙별 버킷과 위반 횟수. 읽기 루프 goroutine에서만 사용한다.
+// 버킷은 적용되는 규칙(RateRule.Event)으로 나누므로, 규칙이 없는 이벤트는 모두 기본 규칙("*") 버킷 하나를 함께 쓴다.
+// (클라이언트가 보낸 임의의 이벤트 이름마다 버킷이 생기지 않도록)
+type sessionLimiter struct {
+	cfg         *rateLimitConfig
+	buckets     map[string]*tokenBucket
+	violations  int
+	windowStart time.Time
+}
+
+func newSessionLimiter(cfg *rateLimitConfig) *sessionLimiter {
+	return &sessionLimiter{
+		cfg:     cfg,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+func (l *sessionLimiter) allow(eventType EventType, now time.Time) bool {
+	rule := l.cfg.ruleFor(eventType)
+	b, ok := l.buckets[rule.Event]
+	if !ok {
+		b = &tokenBucket{}
+		l.buckets[rule.Event] = b
+	}
+	return b.take(now, rule.Rate, rule.Burst)
+}
+
+// recordViolation 위반을 기록하고 연결을 끊어야 하면 true를 반환한다.
+func (l *sessionLimiter) recordViolation(now time.Time) bool {
+	if now.Sub(l.windowStart) > violationWindow {
+		l.windowStart = now
+		l.violations = 0
+	}
+	l.violations++
+	return l.violations >= l.cfg.maxViolations
+}
+
+// ipLimiter 같은 IP에서 여러 연결을 열어 세션 제한을 우회하는 것을 막기 위한 인스턴스 전역 버킷
+type ipLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+var globalIPLimiter = &ipLimiter{buckets: make(map[string]*tokenBucket)}
+
+// allow IP와 규칙(RateRule.Event)별 버킷에서 토큰을 소모한다.
+func (l *ipLimiter) allow(ip string, rule RateRule, now time.Time) bool {
+	if rule.IPRate <= 0 || rule.IPBurst < 1 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > ipBucketIdleTTL {
+		for key, b := range l.buckets {
+			if now.Sub(b.last) > ipBucketIdleTTL {
+				delete(l.buckets, key)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	key := ip + "|" + rule.Event
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{}
+		l.buckets[key] = b
+	}
+	return b.take(now, rule.IPRate, rule.IPBurst)
+}
+
+// allowEvent 세션 버킷과 IP 버킷을 모두 통과해야 이벤트를 처리한다.
+func allowEvent(sl *sessionLimiter, ip string, eventType EventType, now time.Time) bool {
+	if !sl.allow(eventType, now) {
+		return false
+	}
+	return globalIPLimiter.allow(ip, sl.cfg.ruleFor(eventType), now)
+}
+
+// rejectMalformed 파싱할 수 없는 프레임도 기본 규칙의 세션/IP 버킷을 소모하고 위반으로 기록한다.
+// (잘못된 프레임을 보내 제한을 우회하지 못하도록) 연결을 끊어야 하면 true를 반환한다.
+func rejectMalformed(sl *sessionLimiter, ip string, now time.Time) bool {
+	sl.allow("", now)
+	globalIPLimiter.allow(ip, sl.cfg.defaultRule, now)
+	return sl.recordViolation(now)
+}
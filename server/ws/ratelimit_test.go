@@ -0,0 +1,154 @@
+package ws
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestTokenBucket_BurstThenRefill(t *testing.T) {
+	b := &tokenBucket{}
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		if !b.take(now, 1, 3) {
+			t.Fatalf("burst 안의 %d번째 요청은 허용되어야 함", i+1)
+		}
+	}
+	if b.take(now, 1, 3) {
+		t.Fatal("burst를 넘는 요청은 거절되어야 함")
+	}
+	if !b.take(now.Add(time.Second), 1, 3) {
+		t.Fatal("1초 뒤에는 토큰 하나가 충전되어야 함")
+	}
+}
+
+func TestLoadRateLimitConfig_FromSettings(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.SetConfigType("toml")
+	err := viper.ReadConfig(strings.NewReader(`
+[bg.ws]
+max-message-size = 1024
+
+[bg.ws.rate-limit]
+max-violations = 3
+
+[[bg.ws.rate-limit.rules]]
+event = "*"
+rate = 5
+burst = 5
+
+[[bg.ws.rate-limit.rules]]
+event = "chat.send"
+rate = 1
+burst = 2
+ip-rate = 2
+ip-burst = 4
+`))
+	if err != nil {
+		t.Fatalf("설정 읽기 실패: %v", err)
+	}
+
+	cfg := loadRateLimitConfig()
+	if cfg.maxMessageSize != 1024 || cfg.maxViolations != 3 {
+		t.Errorf("maxMessageSize=1024, maxViolations=3 이어야 하지만 %d, %d", cfg.maxMessageSize, cfg.maxViolations)
+	}
+	chat := cfg.ruleFor(EventChatSend)
+	if chat.Burst != 2 || chat.IPBurst != 4 {
+		t.Errorf("chat.send 규칙이 잘못됨: %+v", chat)
+	}
+	if def := cfg.ruleFor(EventRoomList); def.Rate != 5 || def.Burst != 5 {
+		t.Errorf("목록에 없는 이벤트는 기본 규칙을 따라야 하지만 %+v", def)
+	}
+}
+
+func TestSessionLimiter_DisconnectAfterRepeatedViolations(t *testing.T) {
+	cfg := &rateLimitConfig{
+		maxViolations: 3,
+		defaultRule:   RateRule{Event: defaultRuleEvent, Rate: 1, Burst: 1},
+		rules:         map[EventType]RateRule{EventChatSend: {Event: string(EventChatSend), Rate: 1, Burst: 1}},
+	}
+	sl := newSessionLimiter(cfg)
+	now := time.Now()
+
+	if !sl.allow(EventChatSend, now) {
+		t.Fatal("첫 요청은 허용되어야 함")
+	}
+	if sl.allow(EventChatSend, now) {
+		t.Fatal("두 번째 요청은 제한되어야 함")
+	}
+	if !sl.allow(EventRoomList, now) {
+		t.Fatal("규칙별로 버킷이 분리되어야 함")
+	}
+	if sl.allow("made.up.event", now) {
+		t.Fatal("규칙이 없는 이벤트는 기본 규칙 버킷을 함께 써야 함")
+	}
+	if len(sl.buckets) != 2 {
+		t.Fatalf("버킷은 규칙 수만큼만 생겨야 하지만 %d개", len(sl.buckets))
+	}
+
+	if sl.recordViolation(now) || sl.recordViolation(now) {
+		t.Fatal("maxViolations 전에는 연결을 유지해야 함")
+	}
+	if !sl.recordViolation(now) {
+		t.Fatal("maxViolations에 도달하면 연결을 끊어야 함")
+	}
+	if sl.recordViolation(now.Add(2 * violationWindow)) {
+		t.Fatal("윈도우가 지나면 위반 횟수가 초기화되어야 함")
+	}
+}
+
+func TestIPLimiter_SharedAcrossSessions(t *testing.T) {
+	l := &ipLimiter{buckets: make(map[string]*tokenBucket)}
+	rule := RateRule{Rate: 10, Burst: 10, IPRate: 1, IPBurst: 2}
+	now := time.Now()
+
+	if !l.allow("1.2.3.4", rule, now) || !l.allow("1.2.3.4", rule, now) {
+		t.Fatal("IP burst 안의 요청은 허용되어야 함")
+	}
+	if l.allow("1.2.3.4", rule, now) {
+		t.Fatal("같은 IP의 초과 요청은 제한되어야 함")
+	}
+	if !l.allow("5.6.7.8", rule, now) {
+		t.Fatal("다른 IP는 영향을 받지 않아야 함")
+	}
+	if !l.allow("1.2.3.4", RateRule{Rate: 1, Burst: 1}, now) {
+		t.Fatal("IP 제한이 없는 규칙은 항상 허용되어야 함")
+	}
+}
+
+func TestRejectMalformed_ChargesBucketsAndCountsViolations(t *testing.T) {
+	cfg := &rateLimitConfig{
+		maxViolations: 2,
+		defaultRule:   RateRule{Event: defaultRuleEvent, Rate: 1, Burst: 2, IPRate: 1, IPBurst: 4},
+		rules:         map[EventType]RateRule{},
+	}
+	sl := newSessionLimiter(cfg)
+	now := time.Now()
+	const ip = "10.0.0.29"
+
+	if rejectMalformed(sl, ip, now) {
+		t.Fatal("maxViolations 전에는 연결을 유지해야 함")
+	}
+	if !sl.allow(EventRoomList, now) {
+		t.Fatal("기본 규칙 버킷에 토큰이 하나 남아 있어야 함")
+	}
+	if sl.allow(EventRoomList, now) {
+		t.Fatal("잘못된 프레임도 기본 규칙 버킷을 소모해야 함")
+	}
+	if !rejectMalformed(sl, ip, now) {
+		t.Fatal("잘못된 프레임이 반복되면 연결을 끊어야 함")
+	}
+
+	other := newSessionLimiter(cfg)
+	for i := 0; i < 2; i++ {
+		if !allowEvent(other, ip, EventRoomList, now) {
+			t.Fatalf("IP 버킷에 토큰이 남아 있어야 함 (%d)", i)
+		}
+	}
+	if allowEvent(newSessionLimiter(cfg), ip, EventRoomList, now) {
+		t.Fatal("잘못된 프레임도 같은 IP의 버킷을 소모해야 함")
+	}
+}
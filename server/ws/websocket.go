@@ -2,8 +2,10 @@ package ws
 
 import (
 	"encoding/json"
+	"github.com/Ryeom/board-game/internal/auth"
 	resp "github.com/Ryeom/board-game/internal/response"
 	"github.com/Ryeom/board-game/internal/user"
+	"github.com/Ryeom/board-game/internal/util"
 	"github.com/Ryeom/board-game/log"
 	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v4"
@@ -44,12 +46,25 @@ func ActiveSessions() *sync.Map {
 }
 
 func Websocket(c echo.Context) error {
+	// 토큰이 있으면 업그레이드 전에 검증 (없으면 첫 user.identify에서 토큰 또는 게스트 모드로 인증)
+	var identity *auth.Identity
+	if tokenStr := wsToken(c); tokenStr != "" {
+		var authErr error
+		identity, authErr = auth.Authenticate(c.Request().Context(), tokenStr)
+		if authErr != nil {
+			log.Logger.Warningf("WebSocket upgrade rejected from IP %s: %v", c.RealIP(), authErr)
+			return c.JSON(http.StatusUnauthorized, resp.Fail(authErr.Error(), util.GetUserLanguage(c), resp.ErrorDetail{}))
+		}
+	}
+
 	conn, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
 	if err != nil {
 		return err
 	}
 	defer conn.Close()
 
+	limitCfg := getRateLimitConfig()
+	conn.SetReadLimit(limitCfg.maxMessageSize) // 초과 시 ReadMessage가 실패하여 연결 종료
 	conn.SetReadDeadline(time.Now().Add(60 * time.Second))
 	conn.SetPongHandler(func(string) error {
 		conn.SetReadDeadline(time.Now().Add(60 * time.Second))
@@ -59,6 +74,13 @@ func Websocket(c echo.Context) error {
 	tempSocketID := generateSocketID(c, conn.RemoteAddr())
 
 	currentUserSession := user.NewUserSession(tempSocketID, "", "", c.RealIP(), c.Request().UserAgent(), false, conn)
+	currentUserSession.Lang = util.NormalizeLanguage(util.GetUserLanguage(c))
+	if identity != nil {
+		currentUserSession.ActualUserID = identity.UserID
+		currentUserSession.IsGuest = identity.Guest
+		currentUserSession.Name = identity.Name
+		currentUserSession.AuthSessionID = identity.SessionID
+	}
 
 	activeSessions.Store(currentUserSession.ID, currentUserSession)
 
@@ -89,6 +111,7 @@ func Websocket(c echo.Context) error {
 		}
 	}()
 
+	limiter := newSessionLimiter(limitCfg)
 	for {
 		_, msg, err := conn.ReadMessage()
 		if err != nil {
@@ -100,20 +123,74 @@ func Websocket(c echo.Context) error {
 			break
 		}
 
+		now := time.Now()
 		var event SocketEvent
 		if err := json.Unmarshal(msg, &event); err != nil {
 			log.Logger.Warningf("WebSocket invalid message format from ID: %s, Error: %v, Message: %s", currentUserSession.ID, err, string(msg))
 			sendError(currentUserSession, resp.ErrorCodeWSInvalidMessageFormat)
+			if rejectMalformed(limiter, currentUserSession.IP, now) {
+				log.Logger.Warningf("WebSocket closing ID: %s | IP: %s after repeated invalid messages", currentUserSession.ID, currentUserSession.IP)
+				closeForPolicyViolation(currentUserSession, "invalid message format")
+			}
 			continue
 		}
 
-		currentUserSession.LastPingAt = time.Now()
+		if !allowEvent(limiter, currentUserSession.IP, event.Type, now) {
+			log.Logger.Warningf("WebSocket rate limited ID: %s | IP: %s | Event: %s", currentUserSession.ID, currentUserSession.IP, event.Type)
+			sendError(currentUserSession, resp.ErrorCodeWSRateLimited)
+			if limiter.recordViolation(now) {
+				log.Logger.Warningf("WebSocket closing ID: %s | IP: %s after repeated rate limit violations", currentUserSession.ID, currentUserSession.IP)
+				closeForPolicyViolation(currentUserSession, "rate limit exceeded") // 다음 ReadMessage가 실패하면서 연결 종료 처리
+			}
+			continue
+		}
+
+		currentUserSession.LastPingAt = now
 		activeSessions.Store(currentUserSession.ID, currentUserSession)
 		dispatchSocketEvent(c.Request().Context(), currentUserSession, event)
 	}
 	return nil
 }
 
+// closeForPolicyViolation 정책 위반 Close 프레임을 보내고 연결을 닫는다.
+func closeForPolicyViolation(u *user.Session, reason string) {
+	u.WriteMutex.Lock()
+	defer u.WriteMutex.Unlock()
+	_ = u.Conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.ClosePolicyViolation, reason),
+		time.Now().Add(time.Second))
+	_ = u.Conn.Close()
+}
+
+// DisconnectAuthSessions 종료된 로그인 세션(sessionIDs가 nil이면 유저의 모든 세션)으로 연결된 WebSocket을 끊는다.
+// 끊긴 연결은 일반 연결 끊김과 같이 처리된다. (게임 중이면 재접속 유예)
+func DisconnectAuthSessions(userID string, sessionIDs []string) int {
+	revoked := make(map[string]bool, len(sessionIDs))
+	for _, id := range sessionIDs {
+		revoked[id] = true
+	}
+	closed := 0
+	activeSessions.Range(func(_, value any) bool {
+		u, ok := value.(*user.Session)
+		if !ok || u.ActualUserID != userID || (sessionIDs != nil && !revoked[u.AuthSessionID]) {
+			return true
+		}
+		sendError(u, resp.ErrorCodeAuthSessionRevoked)
+		closeForPolicyViolation(u, "session revoked")
+		closed++
+		return true
+	})
+	return closed
+}
+
+// wsToken 브라우저는 WebSocket 요청에 헤더를 붙일 수 없으므로 token 쿼리 파라미터도 허용한다.
+func wsToken(c echo.Context) string {
+	if tokenStr := c.QueryParam("token"); tokenStr != "" {
+		return tokenStr
+	}
+	return strings.TrimPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+}
+
 func generateSocketID(c echo.Context, addr net.Addr) string {
 	ip := c.RealIP()
 	remoteIP := addr.String()
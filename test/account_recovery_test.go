@@ -0,0 +1,198 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	netmail "net/mail"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ryeom/board-game/infra/mail"
+	"github.com/Ryeom/board-game/internal/auth"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/service"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/Ryeom/board-game/internal/util"
+	appHttp "github.com/Ryeom/board-game/server/http"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var mailTokenPattern = regexp.MustCompile(`token=(\S+)`)
+
+// useFileMailer 테스트 동안 메일을 임시 디렉터리에 저장한다.
+func useFileMailer(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	previous := mail.Default
+	mail.Default = &mail.FileMailer{Dir: dir, From: "test@localhost"}
+	t.Cleanup(func() { mail.Default = previous })
+	return dir
+}
+
+// sentMails 받는 사람에게 저장된 메일 본문을 보낸 순서대로 반환한다.
+func sentMails(t *testing.T, dir, to string) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*-"+to+".eml"))
+	require.NoError(t, err)
+	bodies := make([]string, 0, len(files))
+	for _, file := range files {
+		f, err := os.Open(file)
+		require.NoError(t, err)
+		msg, err := netmail.ReadMessage(f)
+		require.NoError(t, err)
+		raw, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, msg.Body))
+		f.Close()
+		require.NoError(t, err)
+		bodies = append(bodies, string(raw))
+	}
+	return bodies
+}
+
+func mailToken(t *testing.T, body string) string {
+	t.Helper()
+	match := mailTokenPattern.FindStringSubmatch(body)
+	require.Len(t, match, 2, "메일에 토큰 링크가 있어야 함: %s", body)
+	token, err := url.QueryUnescape(match[1])
+	require.NoError(t, err)
+	return token
+}
+
+func TestPasswordReset_ResetsPasswordAndRevokesSessions(t *testing.T) {
+	dir := useFileMailer(t)
+	userID := seedSessionUser(t)
+	ctx := context.Background()
+	u, err := user.FindUserByID(userID)
+	require.NoError(t, err)
+
+	pair, err := auth.StartSession(ctx, userID, user.RoleUser, "test-agent", "127.0.0.1")
+	require.NoError(t, err)
+
+	require.NoError(t, service.RequestPasswordReset(ctx, u.Email, "en"))
+	mails := sentMails(t, dir, u.Email)
+	require.Len(t, mails, 1)
+	assert.Contains(t, mails[0], "reset your password")
+	token := mailToken(t, mails[0])
+
+	resetID, err := service.ConfirmPasswordReset(ctx, token, "new-password-123")
+	require.NoError(t, err)
+	assert.Equal(t, userID, resetID)
+
+	u, err = user.FindUserByID(userID)
+	require.NoError(t, err)
+	assert.True(t, util.CheckPasswordHash("new-password-123", u.Password))
+	assert.True(t, u.EmailVerified(), "재설정 메일을 받았으므로 이메일도 인증됨")
+
+	_, err = auth.Refresh(ctx, pair.RefreshToken, "test-agent", "127.0.0.1")
+	assert.EqualError(t, err, resp.ErrorCodeAuthRefreshInvalid, "기존 로그인 세션은 종료됨")
+
+	_, err = service.ConfirmPasswordReset(ctx, token, "another-password")
+	assert.EqualError(t, err, resp.ErrorCodeAuthActionTokenInvalid, "토큰은 한 번만 사용")
+}
+
+func TestPasswordReset_UnknownEmailSendsNothing(t *testing.T) {
+	dir := useFileMailer(t)
+	seedSessionUser(t)
+
+	require.NoError(t, service.RequestPasswordReset(context.Background(), "nobody@friend.test", "ko"))
+	assert.Empty(t, sentMails(t, dir, "nobody@friend.test"))
+}
+
+func TestEmailVerification(t *testing.T) {
+	dir := useFileMailer(t)
+	userID := seedSessionUser(t)
+	ctx := context.Background()
+	u, err := user.FindUserByID(userID)
+	require.NoError(t, err)
+	require.False(t, u.EmailVerified())
+
+	require.NoError(t, service.SendEmailVerification(ctx, u, "ko"))
+	require.NoError(t, service.SendEmailVerification(ctx, u, "ko"))
+	mails := sentMails(t, dir, u.Email)
+	require.Len(t, mails, 2)
+	first, second := mailToken(t, mails[0]), mailToken(t, mails[1])
+
+	assert.EqualError(t, service.VerifyEmail(ctx, first), resp.ErrorCodeAuthActionTokenInvalid, "다시 보내면 이전 링크는 사용할 수 없음")
+	id, _, _ := strings.Cut(second, ".")
+	assert.EqualError(t, service.VerifyEmail(ctx, id+".forged"), resp.ErrorCodeAuthActionTokenInvalid, "서명이 맞지 않으면 거부")
+
+	require.NoError(t, service.SendEmailVerification(ctx, u, "ko"))
+	mails = sentMails(t, dir, u.Email)
+	require.Len(t, mails, 3)
+	_, err = service.ConfirmPasswordReset(ctx, mailToken(t, mails[2]), "new-password-123")
+	assert.EqualError(t, err, resp.ErrorCodeAuthActionTokenInvalid, "용도가 다른 토큰은 사용할 수 없음")
+}
+
+func TestEmailVerification_Verifies(t *testing.T) {
+	dir := useFileMailer(t)
+	userID := seedSessionUser(t)
+	ctx := context.Background()
+	u, err := user.FindUserByID(userID)
+	require.NoError(t, err)
+
+	require.NoError(t, service.SendEmailVerification(ctx, u, "ko"))
+	mails := sentMails(t, dir, u.Email)
+	require.Len(t, mails, 1)
+	assert.Contains(t, mails[0], "이메일을 인증해주세요")
+	require.NoError(t, service.VerifyEmail(ctx, mailToken(t, mails[0])))
+
+	u, err = user.FindUserByID(userID)
+	require.NoError(t, err)
+	assert.True(t, u.EmailVerified())
+	assert.EqualError(t, service.SendEmailVerification(ctx, u, "ko"), resp.ErrorCodeAuthEmailAlreadyVerified)
+}
+
+func TestPasswordResetRequest_ThrottledPerEmailAndIP(t *testing.T) {
+	dir := useFileMailer(t)
+	ensureRedis(t)
+	cleanRedis(t)
+	defer cleanRedis(t)
+	target, err := user.FindUserByID(seedSessionUser(t))
+	require.NoError(t, err)
+	other, err := user.FindUserByID(seedSessionUser(t))
+	require.NoError(t, err)
+
+	previous := auth.MailGuard
+	auth.MailGuard = auth.MailGuardConfig{Window: time.Minute, PerEmail: 2, PerIP: 3}
+	defer func() { auth.MailGuard = previous }()
+
+	e := echo.New()
+	e.Validator = util.NewValidator()
+	request := func(email, ip string) {
+		t.Helper()
+		body, _ := json.Marshal(map[string]string{"email": email})
+		req := httptest.NewRequest(http.MethodPost, "/board-game/auth/password-reset/request", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderXRealIP, ip)
+		rec := httptest.NewRecorder()
+		require.NoError(t, appHttp.RequestPasswordReset(e.NewContext(req, rec)))
+
+		// 제한에 걸려도 응답은 같음
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var result resp.HttpResult
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		assert.Equal(t, resp.SuccessCodeAuthPasswordResetRequest, result.Code)
+	}
+
+	for i := 0; i < 3; i++ {
+		request(target.Email, "10.0.49.1")
+	}
+	assert.Len(t, sentMails(t, dir, target.Email), 2, "이메일당 허용 횟수까지만 발송")
+
+	request(other.Email, "10.0.49.1")
+	assert.Empty(t, sentMails(t, dir, other.Email), "IP당 허용 횟수를 넘긴 요청은 다른 이메일이어도 발송하지 않음")
+
+	request(other.Email, "10.0.49.2")
+	assert.Len(t, sentMails(t, dir, other.Email), 1, "다른 IP에서는 발송")
+}
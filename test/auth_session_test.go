@@ -0,0 +1,151 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ryeom/board-game/infra/db"
+	"github.com/Ryeom/board-game/internal/auth"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/Ryeom/board-game/server/ws"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seedSessionUser(t *testing.T) string {
+	t.Helper()
+	auth.Initialize()
+	userID := seedFriendUsers(t, 1)[0]
+	t.Cleanup(func() {
+		db.DB.Where("session_id IN (?)", db.DB.Model(&auth.Session{}).Select("id").Where("user_id = ?", userID)).Delete(&auth.RefreshToken{})
+		db.DB.Where("user_id = ?", userID).Delete(&auth.Session{})
+	})
+	return userID
+}
+
+func TestAuthSession_RefreshRotatesAndDetectsReuse(t *testing.T) {
+	userID := seedSessionUser(t)
+	ctx := context.Background()
+
+	first, err := auth.StartSession(ctx, userID, user.RoleUser, "test-agent", "127.0.0.1")
+	require.NoError(t, err)
+
+	second, err := auth.Refresh(ctx, first.RefreshToken, "test-agent", "127.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, first.SessionID, second.SessionID, "갱신해도 같은 세션")
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+
+	identity, err := auth.Authenticate(ctx, second.Token)
+	require.NoError(t, err)
+	assert.Equal(t, first.SessionID, identity.SessionID)
+
+	// 교체된 토큰을 다시 쓰면 세션 전체가 종료됨
+	_, err = auth.Refresh(ctx, first.RefreshToken, "attacker", "10.0.0.1")
+	var reused *auth.ReuseError
+	require.True(t, errors.As(err, &reused))
+	assert.Equal(t, resp.ErrorCodeAuthRefreshReused, err.Error())
+	assert.Equal(t, first.SessionID, reused.SessionID)
+
+	_, err = auth.Refresh(ctx, second.RefreshToken, "test-agent", "127.0.0.1")
+	assert.EqualError(t, err, resp.ErrorCodeAuthRefreshInvalid, "종료된 세션의 최신 토큰도 사용할 수 없음")
+	_, err = auth.Authenticate(ctx, second.Token)
+	assert.EqualError(t, err, resp.ErrorCodeAuthSessionRevoked, "발급된 access token도 바로 거부")
+
+	_, err = auth.Refresh(ctx, "unknown-token", "test-agent", "127.0.0.1")
+	assert.EqualError(t, err, resp.ErrorCodeAuthRefreshInvalid)
+}
+
+func TestAuthSession_ListAndRevoke(t *testing.T) {
+	userID := seedSessionUser(t)
+	otherID := seedSessionUser(t)
+	ctx := context.Background()
+
+	phone, err := auth.StartSession(ctx, userID, user.RoleUser, "phone", "127.0.0.1")
+	require.NoError(t, err)
+	_, err = auth.StartSession(ctx, userID, user.RoleUser, "laptop", "127.0.0.2")
+	require.NoError(t, err)
+
+	sessions, err := auth.ActiveSessions(ctx, userID)
+	require.NoError(t, err)
+	assert.Len(t, sessions, 2)
+
+	assert.EqualError(t, auth.RevokeSession(ctx, otherID, phone.SessionID, auth.RevokeReasonUser), resp.ErrorCodeAuthSessionNotFound, "다른 유저의 세션은 종료할 수 없음")
+	require.NoError(t, auth.RevokeSession(ctx, userID, phone.SessionID, auth.RevokeReasonUser))
+	assert.EqualError(t, auth.RevokeSession(ctx, userID, phone.SessionID, auth.RevokeReasonUser), resp.ErrorCodeAuthSessionNotFound)
+
+	sessions, err = auth.ActiveSessions(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "laptop", sessions[0].UserAgent)
+
+	revoked, err := auth.RevokeAllSessions(ctx, userID, auth.RevokeReasonUser)
+	require.NoError(t, err)
+	assert.Len(t, revoked, 1)
+	sessions, err = auth.ActiveSessions(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+}
+
+func TestAuthSession_RevokeDisconnectsWebSocket(t *testing.T) {
+	ts, wsURL := startTestServer(t)
+	defer ts.Close()
+	userID := seedSessionUser(t)
+	ctx := context.Background()
+
+	kept, err := auth.StartSession(ctx, userID, user.RoleUser, "laptop", "127.0.0.1")
+	require.NoError(t, err)
+	revoked, err := auth.StartSession(ctx, userID, user.RoleUser, "phone", "127.0.0.2")
+	require.NoError(t, err)
+
+	keptConn, _, err := websocket.DefaultDialer.Dial(wsURL+"?token="+kept.Token, nil)
+	require.NoError(t, err)
+	defer keptConn.Close()
+	revokedConn, _, err := websocket.DefaultDialer.Dial(wsURL+"?token="+revoked.Token, nil)
+	require.NoError(t, err)
+	defer revokedConn.Close()
+	time.Sleep(100 * time.Millisecond)
+
+	require.NoError(t, auth.RevokeSession(ctx, userID, revoked.SessionID, auth.RevokeReasonUser))
+	assert.Equal(t, 1, ws.DisconnectAuthSessions(userID, []string{revoked.SessionID}))
+
+	event := ReadEvent(t, revokedConn, 2*time.Second)
+	assert.Equal(t, "error", event.Type)
+	assert.Equal(t, resp.ErrorCodeAuthSessionRevoked, event.ErrorCode)
+	revokedConn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, _, err = revokedConn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.ClosePolicyViolation), "세션이 종료된 연결은 끊김: %v", err)
+
+	// 다른 세션의 연결은 유지
+	SendEvent(t, keptConn, WSEvent{Type: "user.identify", Data: map[string]any{}})
+	event = ReadEvent(t, keptConn, 2*time.Second)
+	assert.Equal(t, "user.identify", event.Type)
+}
+
+func TestAuthToken_BlacklistByJTI(t *testing.T) {
+	userID := seedSessionUser(t)
+	ctx := context.Background()
+
+	pair, err := auth.StartSession(ctx, userID, user.RoleUser, "test-agent", "127.0.0.1")
+	require.NoError(t, err)
+	identity, err := auth.Authenticate(ctx, pair.Token)
+	require.NoError(t, err)
+	assert.Equal(t, string(user.RoleUser), identity.Role)
+	require.NotEmpty(t, identity.TokenID)
+
+	require.NoError(t, auth.AddTokenToBlacklist(ctx, pair.Token))
+	blacklisted, err := auth.IsTokenBlacklisted(ctx, identity.TokenID)
+	require.NoError(t, err)
+	assert.True(t, blacklisted, "토큰 원문이 아닌 jti로 블랙리스트")
+	_, err = auth.Authenticate(ctx, pair.Token)
+	assert.EqualError(t, err, resp.ErrorCodeAuthTokenBlacklisted)
+
+	// 같은 세션에서 갱신한 토큰은 jti가 달라 영향 없음
+	next, err := auth.Refresh(ctx, pair.RefreshToken, "test-agent", "127.0.0.1")
+	require.NoError(t, err)
+	_, err = auth.Authenticate(ctx, next.Token)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,65 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ryeom/board-game/internal/domain/room"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readUntilType 원하는 타입의 이벤트가 올 때까지 읽는다.
+func readUntilType(t *testing.T, conn *websocket.Conn, eventType string) map[string]any {
+	t.Helper()
+	for {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(10*time.Second)))
+		var ev map[string]any
+		require.NoError(t, conn.ReadJSON(&ev))
+		if ev["type"] == eventType {
+			return ev
+		}
+	}
+}
+
+func TestChatSend_ChannelMessagesGoThroughRoomPubSubToReadersOnly(t *testing.T) {
+	ts, wsURL := startTestServer(t)
+	defer ts.Close()
+	defer cleanRedis(t)
+	ctx := context.Background()
+
+	host := ConnectAndIdentify(t, wsURL, "channel-host", "Host")
+	defer host.Close()
+	readUntilType(t, host, "user.identify")
+	SendEvent(t, host, WSEvent{Type: "room.create", Data: map[string]any{"roomName": "Channels", "maxPlayers": 2}})
+	roomID := readUntilType(t, host, "room.create")["data"].(map[string]any)["roomId"].(string)
+
+	spectator := ConnectAndIdentify(t, wsURL, "channel-spectator", "Spectator")
+	defer spectator.Close()
+	readUntilType(t, spectator, "user.identify")
+	SendEvent(t, spectator, WSEvent{Type: "room.spectate", Data: map[string]any{"roomId": roomID}})
+	readUntilType(t, spectator, "room.spectate")
+
+	lastSeq, err := room.LastEventSeq(ctx, roomID)
+	require.NoError(t, err)
+
+	SendEvent(t, spectator, WSEvent{Type: "chat.send", Data: map[string]any{"message": "psst", "channel": "spectators"}})
+	msg := readUntilType(t, spectator, "chat.message")
+	data := msg["data"].(map[string]any)
+	assert.Equal(t, "psst", data["message"])
+	assert.Equal(t, "spectators", data["channel"])
+	assert.Nil(t, msg["seq"], "수신자가 정해진 메시지는 재전송 버퍼에 남기지 않음")
+
+	afterSeq, err := room.LastEventSeq(ctx, roomID)
+	require.NoError(t, err)
+	assert.Equal(t, lastSeq, afterSeq)
+
+	// 같은 채널로 뒤따라 발행된 방 전체 메시지가 먼저 도착하면 관전자 메시지가 새지 않은 것
+	SendEvent(t, host, WSEvent{Type: "chat.send", Data: map[string]any{"message": "hello", "channel": "all"}})
+	msg = readUntilType(t, host, "chat.message")
+	assert.Equal(t, "hello", msg["data"].(map[string]any)["message"], "플레이어는 관전자 채널 메시지를 받지 않음")
+	msg = readUntilType(t, spectator, "chat.message")
+	assert.Equal(t, "hello", msg["data"].(map[string]any)["message"])
+}
@@ -0,0 +1,143 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Ryeom/board-game/infra/mongo"
+	chat "github.com/Ryeom/board-game/internal/domain/chat"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func seedChat(t *testing.T, roomID string, n int) {
+	t.Helper()
+	if mongo.Client == nil {
+		mongo.Initialize()
+	}
+	ctx := context.Background()
+	_, err := mongo.GetCollection(mongo.ChatCollection).DeleteMany(ctx, bson.M{"roomId": roomID})
+	require.NoError(t, err)
+
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < n; i++ {
+		msg := fmt.Sprintf("message %d", i)
+		if i%5 == 0 {
+			msg = fmt.Sprintf("Good Game %d", i)
+		}
+		// 같은 시각의 메시지가 있어도 커서가 중복/누락 없이 넘어가야 하므로 두 개씩 같은 시각으로 저장
+		record := &chat.ChatRecord{SenderID: "u1", SenderName: "u1", Message: msg, Timestamp: base.Add(time.Duration(i/2) * time.Second)}
+		require.NoError(t, chat.SaveChatMessage(ctx, roomID, record))
+		require.False(t, record.ID.IsZero(), "저장 후 메시지 ID가 채워져야 함")
+	}
+}
+
+func TestChatHistory_PaginatesWithCursor(t *testing.T) {
+	const roomID = "room:chat:history"
+	seedChat(t, roomID, 25)
+	ctx := context.Background()
+
+	var all []string
+	cursor := ""
+	for page := 0; ; page++ {
+		records, next, err := chat.GetChatHistory(ctx, roomID, chat.HistoryQuery{Before: cursor, Limit: 10})
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(records), 10)
+		for i := 1; i < len(records); i++ {
+			assert.False(t, records[i].Timestamp.Before(records[i-1].Timestamp), "페이지 안에서는 오래된 순")
+		}
+		msgs := make([]string, 0, len(records))
+		for _, r := range records {
+			msgs = append(msgs, r.Message)
+		}
+		all = append(msgs, all...)
+		if next == "" {
+			break
+		}
+		cursor = next
+		require.Less(t, page, 5, "페이지가 끝나지 않음")
+	}
+	require.Len(t, all, 25, "모든 메시지를 중복 없이 한 번씩")
+	assert.Equal(t, "Good Game 0", all[0])
+	assert.Equal(t, "message 24", all[24])
+}
+
+func TestChatHistory_SearchAndInvalidCursor(t *testing.T) {
+	const roomID = "room:chat:search"
+	seedChat(t, roomID, 12)
+	ctx := context.Background()
+
+	records, next, err := chat.GetChatHistory(ctx, roomID, chat.HistoryQuery{Search: "good game"})
+	require.NoError(t, err)
+	assert.Empty(t, next)
+	require.Len(t, records, 3, "대소문자 무시 부분 일치")
+	assert.Equal(t, "Good Game 0", records[0].Message)
+
+	_, _, err = chat.GetChatHistory(ctx, roomID, chat.HistoryQuery{Before: "not-a-cursor"})
+	assert.EqualError(t, err, resp.ErrorCodeChatHistoryInvalidQuery)
+	_, _, err = chat.GetChatHistory(ctx, "room:chat:other", chat.HistoryQuery{Before: records[1].ID.Hex()})
+	assert.EqualError(t, err, resp.ErrorCodeChatHistoryInvalidQuery, "다른 방의 메시지 ID는 커서로 쓸 수 없음")
+
+	records, _, err = chat.GetChatHistory(ctx, roomID, chat.HistoryQuery{Before: time.Now().Add(-2 * time.Hour).Format(time.RFC3339)})
+	require.NoError(t, err)
+	assert.Empty(t, records, "시각 커서보다 오래된 메시지가 없음")
+}
+
+func TestChatHistory_IncludesTimelineRecords(t *testing.T) {
+	const roomID = "room:chat:timeline"
+	seedChat(t, roomID, 2)
+	ctx := context.Background()
+
+	record := chat.NewEventRecord(chat.RecordSystem, resp.TimelineCodeJoined, map[string]string{"user": "Alice"})
+	require.NoError(t, chat.SaveChatMessage(ctx, roomID, record))
+
+	records, _, err := chat.GetChatHistory(ctx, roomID, chat.HistoryQuery{})
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, chat.RecordUser, records[0].Type, "기존 유저 메시지는 user 타입")
+
+	last := records[len(records)-1]
+	assert.Equal(t, chat.RecordSystem, last.Type)
+	assert.Equal(t, resp.TimelineCodeJoined, last.Key)
+	assert.Equal(t, "Alice", last.Params["user"])
+
+	last.Localize("en")
+	assert.Equal(t, "Alice joined the room.", last.Message)
+}
+
+func TestChatHistory_FiltersByChannel(t *testing.T) {
+	const roomID = "room:chat:channels"
+	seedChat(t, roomID, 0)
+	ctx := context.Background()
+
+	save := func(msg string, spectator bool, channel chat.Channel) {
+		require.NoError(t, chat.SaveChatMessage(ctx, roomID, &chat.ChatRecord{SenderID: "u1", Message: msg, Timestamp: time.Now(), Spectator: spectator, Channel: channel}))
+	}
+	save("to all", false, chat.ChannelAll)
+	save("players only", false, chat.ChannelPlayers)
+	save("spectators only", true, chat.ChannelSpectators)
+	save("red team", false, chat.TeamChannel("red"))
+	// 채널이 없던 이전 관전자 채팅
+	_, err := mongo.GetCollection(mongo.ChatCollection).InsertOne(ctx, bson.M{"roomId": roomID, "senderId": "s1", "message": "legacy spectator", "timestamp": time.Now(), "spectator": true})
+	require.NoError(t, err)
+
+	messages := func(member chat.Member) []string {
+		channels, err := member.HistoryChannels("")
+		require.NoError(t, err)
+		records, _, err := chat.GetChatHistory(ctx, roomID, chat.HistoryQuery{Channels: channels})
+		require.NoError(t, err)
+		var msgs []string
+		for _, r := range records {
+			msgs = append(msgs, r.Message)
+		}
+		return msgs
+	}
+
+	assert.Equal(t, []string{"to all", "players only"}, messages(chat.Member{Player: true}))
+	assert.Equal(t, []string{"to all", "players only", "red team"}, messages(chat.Member{Player: true, Team: "red"}))
+	assert.Equal(t, []string{"to all", "spectators only", "legacy spectator"}, messages(chat.Member{Spectator: true}))
+}
@@ -0,0 +1,66 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	redisutil "github.com/Ryeom/board-game/infra/redis"
+	chat "github.com/Ryeom/board-game/internal/domain/chat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ensureRedis(t *testing.T) {
+	t.Helper()
+	if redisutil.Client == nil {
+		redisutil.Initialize()
+	}
+}
+
+func TestChatMute_RoomAndGlobalScopes(t *testing.T) {
+	ensureRedis(t)
+	ctx := context.Background()
+	defer func() {
+		_, _ = chat.ClearMute(ctx, "room:mute", "muted-user")
+		_, _ = chat.ClearMute(ctx, "", "muted-user")
+	}()
+
+	assert.Nil(t, chat.ActiveMute(ctx, "room:mute", "muted-user"))
+
+	require.NoError(t, chat.SetMute(ctx, &chat.Mute{UserID: "muted-user", RoomID: "room:mute", MutedBy: "host", Until: time.Now().Add(time.Minute)}))
+	m := chat.ActiveMute(ctx, "room:mute", "muted-user")
+	require.NotNil(t, m)
+	assert.False(t, m.Global())
+	assert.Nil(t, chat.ActiveMute(ctx, "room:other", "muted-user"), "방 음소거는 다른 방에 적용되지 않아야 함")
+
+	require.NoError(t, chat.SetMute(ctx, &chat.Mute{UserID: "muted-user", MutedBy: "admin", Until: time.Now().Add(time.Minute)}))
+	m = chat.ActiveMute(ctx, "room:other", "muted-user")
+	require.NotNil(t, m, "전체 음소거는 모든 방에 적용되어야 함")
+	assert.True(t, m.Global())
+
+	cleared, err := chat.ClearMute(ctx, "", "muted-user")
+	require.NoError(t, err)
+	assert.True(t, cleared)
+	cleared, err = chat.ClearMute(ctx, "", "muted-user")
+	require.NoError(t, err)
+	assert.False(t, cleared)
+
+	ttl := redisutil.GetExpireTime(redisutil.RedisTargetUser, "chat_mute:room:mute:muted-user")
+	assert.Greater(t, ttl, 0, "음소거는 만료 시각에 맞춘 TTL로 저장되어야 함")
+}
+
+func TestChatFlood_LimitsPerWindow(t *testing.T) {
+	ensureRedis(t)
+	ctx := context.Background()
+	defer redisutil.Delete(redisutil.RedisTargetUser, "chat_flood:flood-user")
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, chat.AllowMessage(ctx, "flood-user", 3, time.Second))
+	}
+	assert.False(t, chat.AllowMessage(ctx, "flood-user", 3, time.Second), "윈도우 안에서 제한 초과")
+	assert.True(t, chat.AllowMessage(ctx, "other-user", 0, time.Second), "limit 0이면 제한 없음")
+
+	time.Sleep(1100 * time.Millisecond)
+	assert.True(t, chat.AllowMessage(ctx, "flood-user", 3, time.Second), "윈도우가 지나면 다시 허용")
+}
@@ -0,0 +1,185 @@
+package test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/Ryeom/board-game/infra/db"
+	"github.com/Ryeom/board-game/infra/mongo"
+	"github.com/Ryeom/board-game/internal/domain/dm"
+	"github.com/Ryeom/board-game/internal/domain/friend"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/service"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// recordingBroadcaster 서비스가 보낸 개별 알림을 기록
+type recordingBroadcaster struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (b *recordingBroadcaster) SendToPlayer(playerID string, eventName string, payload any, msgCode string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events = append(b.events, playerID+" "+eventName)
+}
+
+func (b *recordingBroadcaster) BroadcastToRoom(roomID string, eventName string, payload any, msgCode string) {
+}
+
+// seedFriendUsers 테스트용 회원 n명을 만들고 끝나면 관계/메시지와 함께 지운다.
+func seedFriendUsers(t *testing.T, n int) []string {
+	t.Helper()
+	ensureRedis(t)
+	if mongo.Client == nil {
+		mongo.Initialize()
+	}
+	ids := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		id := uuid.New()
+		u := &user.User{ID: id, Email: id.String() + "@friend.test", Password: "x", Nickname: "friend-" + id.String()[:8], IsActive: true}
+		require.NoError(t, db.DB.Create(u).Error)
+		ids = append(ids, id.String())
+	}
+	t.Cleanup(func() {
+		db.DB.Where("user_id IN ? OR friend_id IN ?", ids, ids).Delete(&friend.Friendship{})
+		db.DB.Where("id IN ?", ids).Delete(&user.User{})
+		_, _ = mongo.GetCollection(mongo.DirectMessageCollection).DeleteMany(context.Background(), bson.M{"senderId": bson.M{"$in": ids}})
+	})
+	return ids
+}
+
+func TestFriends_RequestAcceptRemove(t *testing.T) {
+	ids := seedFriendUsers(t, 2)
+	a, b := ids[0], ids[1]
+	ctx := context.Background()
+	s := service.NewSocialService(&recordingBroadcaster{})
+
+	status, err := s.RequestFriend(ctx, a, b)
+	require.NoError(t, err)
+	assert.Equal(t, friend.StatusPending, status)
+
+	_, err = s.RequestFriend(ctx, a, b)
+	assert.EqualError(t, err, resp.ErrorCodeFriendRequestExists)
+
+	requests, err := s.Requests(ctx, b)
+	require.NoError(t, err)
+	require.Len(t, requests.Incoming, 1)
+	assert.Equal(t, a, requests.Incoming[0].UserID)
+
+	require.NoError(t, s.AcceptFriend(ctx, b, a))
+	for _, pair := range [][2]string{{a, b}, {b, a}} {
+		ok, err := friend.AreFriends(ctx, pair[0], pair[1])
+		require.NoError(t, err)
+		assert.True(t, ok, "수락하면 양쪽 모두 친구")
+	}
+
+	friends, err := s.Friends(ctx, a)
+	require.NoError(t, err)
+	require.Len(t, friends, 1)
+	assert.Equal(t, b, friends[0].UserID)
+	assert.Equal(t, user.PresenceOffline, friends[0].Presence, "세션이 없으면 offline")
+
+	_, err = s.RequestFriend(ctx, b, a)
+	assert.EqualError(t, err, resp.ErrorCodeFriendAlreadyFriends)
+
+	require.NoError(t, s.RemoveFriend(ctx, a, b))
+	ok, err := friend.AreFriends(ctx, b, a)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.EqualError(t, s.RemoveFriend(ctx, a, b), resp.ErrorCodeFriendNotFound)
+}
+
+func TestFriends_CrossRequestAcceptsAndBlockPreventsRequests(t *testing.T) {
+	ids := seedFriendUsers(t, 2)
+	a, b := ids[0], ids[1]
+	ctx := context.Background()
+	s := service.NewSocialService(&recordingBroadcaster{})
+
+	_, err := s.RequestFriend(ctx, a, b)
+	require.NoError(t, err)
+	status, err := s.RequestFriend(ctx, b, a)
+	require.NoError(t, err)
+	assert.Equal(t, friend.StatusAccepted, status, "서로 요청하면 바로 친구")
+
+	require.NoError(t, s.Block(ctx, b, a))
+	ok, err := friend.AreFriends(ctx, a, b)
+	require.NoError(t, err)
+	assert.False(t, ok, "차단하면 친구 관계가 끊김")
+
+	_, err = s.RequestFriend(ctx, a, b)
+	assert.EqualError(t, err, resp.ErrorCodeFriendBlocked)
+	_, err = s.RequestFriend(ctx, b, a)
+	assert.EqualError(t, err, resp.ErrorCodeFriendBlocked, "차단한 쪽도 해제 전에는 요청 불가")
+
+	blocked, err := s.Blocked(ctx, b)
+	require.NoError(t, err)
+	require.Len(t, blocked, 1)
+	assert.Equal(t, a, blocked[0].UserID)
+
+	require.NoError(t, s.Unblock(ctx, b, a))
+	assert.EqualError(t, s.Unblock(ctx, b, a), resp.ErrorCodeFriendNotBlocked)
+
+	_, err = s.RequestFriend(ctx, a, a)
+	assert.EqualError(t, err, resp.ErrorCodeFriendInvalidTarget)
+}
+
+func TestDirectMessages_OnlyFriendsAndUnreadCounts(t *testing.T) {
+	ids := seedFriendUsers(t, 3)
+	a, b, stranger := ids[0], ids[1], ids[2]
+	ctx := context.Background()
+	s := service.NewSocialService(&recordingBroadcaster{})
+
+	_, err := s.SendDirectMessage(ctx, a, "a", stranger, "hi")
+	assert.EqualError(t, err, resp.ErrorCodeDMNotFriends)
+
+	_, err = s.RequestFriend(ctx, a, b)
+	require.NoError(t, err)
+	require.NoError(t, s.AcceptFriend(ctx, b, a))
+
+	for _, text := range []string{"one", "two", "three"} {
+		m, err := s.SendDirectMessage(ctx, a, "a", b, text)
+		require.NoError(t, err)
+		assert.False(t, m.ID.IsZero())
+	}
+	_, err = s.SendDirectMessage(ctx, b, "b", a, "reply")
+	require.NoError(t, err)
+
+	counts, err := s.UnreadCounts(ctx, b)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), counts[a])
+
+	friends, err := s.Friends(ctx, b)
+	require.NoError(t, err)
+	require.Len(t, friends, 1)
+	assert.Equal(t, int64(3), friends[0].Unread)
+
+	page, next, err := s.DirectMessages(ctx, b, a, dm.HistoryQuery{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, "three", page[0].Message)
+	assert.Equal(t, "reply", page[1].Message)
+	require.NotEmpty(t, next)
+
+	older, next, err := s.DirectMessages(ctx, a, b, dm.HistoryQuery{Before: next, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, older, 2)
+	assert.Equal(t, "one", older[0].Message)
+	assert.Empty(t, next)
+
+	n, err := s.MarkRead(ctx, b, a)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	counts, err = s.UnreadCounts(ctx, b)
+	require.NoError(t, err)
+	assert.Zero(t, counts[a])
+	counts, err = s.UnreadCounts(ctx, a)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), counts[b], "상대가 읽어도 내가 받은 메시지는 그대로")
+}
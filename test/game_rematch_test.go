@@ -0,0 +1,76 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ryeom/board-game/internal/domain/room"
+	"github.com/Ryeom/board-game/internal/game"
+	"github.com/Ryeom/board-game/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startRematchableGame 준비 완료된 두 명으로 게임을 시작했다가 끝내 재대결 투표를 연다.
+func startRematchableGame(t *testing.T, svc *service.GameService, roomID string) {
+	t.Helper()
+	ctx := context.Background()
+	seedRoom(t, roomID, 4, "host", "guest")
+	_, _, err := room.Update(ctx, roomID, func(r *room.Room) (room.Mutation, error) {
+		r.ReadyPlayers = map[string]bool{"host": true, "guest": true}
+		r.RematchSeats = room.RematchSeatsRotate
+		return room.MutationSave, nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, svc.StartGame(ctx, roomID, "host"))
+	require.NoError(t, svc.EndGame(ctx, roomID, "host"))
+}
+
+func TestRematch_AllAcceptStartsNewGameWithRotatedSeats(t *testing.T) {
+	cleanRoomData(t)
+	defer cleanRoomData(t)
+	svc := service.NewGameService(game.NewManager(), noopBroadcaster{})
+	svc.Rooms = service.NewRoomService(noopBroadcaster{})
+	ctx := context.Background()
+	startRematchableGame(t, svc, "room:rematch:accept")
+
+	r, ok := room.GetRoom(ctx, "room:rematch:accept")
+	require.True(t, ok)
+	require.NotNil(t, r.Rematch, "게임이 끝나면 재대결 투표가 열려야 함")
+	assert.False(t, r.IsGameStarted)
+
+	require.NoError(t, svc.VoteRematch(ctx, r.ID, "host", true))
+	require.NoError(t, svc.VoteRematch(ctx, r.ID, "guest", true))
+
+	r, ok = room.GetRoom(ctx, r.ID)
+	require.True(t, ok)
+	assert.True(t, r.IsGameStarted, "전원 수락하면 바로 새 게임이 시작되어야 함")
+	assert.Nil(t, r.Rematch)
+	assert.Equal(t, []string{"guest", "host"}, r.Players, "rotate 설정이면 선 플레이어가 바뀌어야 함")
+	_, running := svc.Manager.GetEngine(r.ID)
+	assert.True(t, running)
+	require.NoError(t, svc.EndGame(ctx, r.ID, r.Host))
+}
+
+func TestRematch_DeclineLeavesRoomAndClosesVote(t *testing.T) {
+	cleanRoomData(t)
+	defer cleanRoomData(t)
+	svc := service.NewGameService(game.NewManager(), noopBroadcaster{})
+	svc.Rooms = service.NewRoomService(noopBroadcaster{})
+	svc.RematchTimeout = 300 * time.Millisecond
+	ctx := context.Background()
+	startRematchableGame(t, svc, "room:rematch:decline")
+
+	require.NoError(t, svc.VoteRematch(ctx, "room:rematch:decline", "host", true))
+	require.NoError(t, svc.VoteRematch(ctx, "room:rematch:decline", "guest", false))
+
+	r, ok := room.GetRoom(ctx, "room:rematch:decline")
+	require.True(t, ok)
+	assert.Equal(t, []string{"host"}, r.Players, "거절한 플레이어는 방에서 나가야 함")
+	assert.False(t, r.IsGameStarted)
+	assert.Nil(t, r.Rematch, "혼자 남으면 투표가 닫혀야 함")
+	assert.True(t, r.ReadyPlayers["host"], "수락한 플레이어는 준비 완료로 남아야 함")
+
+	assert.Error(t, svc.VoteRematch(ctx, r.ID, "host", true), "닫힌 투표에는 투표할 수 없어야 함")
+}
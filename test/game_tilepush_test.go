@@ -0,0 +1,76 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ryeom/board-game/infra/db"
+	"github.com/Ryeom/board-game/internal/domain/room"
+	tilesets "github.com/Ryeom/board-game/internal/domain/tilepush"
+	"github.com/Ryeom/board-game/internal/game"
+	"github.com/Ryeom/board-game/internal/game/tilepush"
+	"github.com/Ryeom/board-game/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedTileSet 시작에 쓸 타일 세트를 저장하고 메모리에 다시 불러온다.
+func seedTileSet(t *testing.T) {
+	t.Helper()
+	ctx := context.Background()
+	set := &tilesets.TileSet{Name: "test-start-set", Tiles: []tilesets.Tile{
+		{Shape: "circle", ImageURL: "/tiles/circle.png"},
+		{Shape: "square", ImageURL: "/tiles/square.png"},
+		{Shape: "star", ImageURL: "/tiles/star.png"},
+	}}
+	require.NoError(t, db.DB.Create(set).Error)
+	t.Cleanup(func() {
+		db.DB.Where("tile_set_id = ?", set.ID).Delete(&tilesets.Tile{})
+		db.DB.Delete(set)
+		_ = tilesets.LoadAllTileSetsFromDB(ctx)
+	})
+	require.NoError(t, tilesets.LoadAllTileSetsFromDB(ctx))
+}
+
+func TestStartGame_TilePushUsesRoomSettings(t *testing.T) {
+	cleanRoomData(t)
+	defer cleanRoomData(t)
+	seedTileSet(t)
+	svc := service.NewGameService(game.NewManager(), noopBroadcaster{})
+	ctx := context.Background()
+
+	const roomID = "room:tilepush:start"
+	seedRoom(t, roomID, 2, "host", "guest")
+	_, _, err := room.Update(ctx, roomID, func(r *room.Room) (room.Mutation, error) {
+		r.GameMode = game.ModeTilePush
+		r.Settings = game.Settings{TurnSeconds: 15, BoardRows: 4, BoardColumns: 7}
+		r.ReadyPlayers = map[string]bool{"host": true, "guest": true}
+		return room.MutationSave, nil
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, svc.StartGame(ctx, roomID, "host"))
+	defer svc.EndGame(ctx, roomID, "host")
+
+	engine, ok := svc.Manager.GetEngine(roomID)
+	require.True(t, ok)
+	tilePushEngine, ok := engine.(*tilepush.Engine)
+	require.True(t, ok)
+	assert.Equal(t, 15*time.Second, tilePushEngine.GetTurnDuration(), "turnSeconds 설정이 턴 제한 시간")
+
+	state, mode, err := svc.GetGameState(ctx, roomID, "host")
+	require.NoError(t, err)
+	assert.Equal(t, game.ModeTilePush, mode)
+	view := state.(*tilepush.State)
+	assert.Equal(t, 4, view.Rows)
+	assert.Equal(t, 7, view.Columns)
+	require.Len(t, view.Board, 4)
+	assert.Len(t, view.Board[0], 7)
+	assert.Equal(t, "host", view.CurrentTurnPlayerID)
+
+	require.NoError(t, svc.ProcessAction(ctx, roomID, "host", map[string]any{
+		"actionType": "tile.push", "column": float64(6), "row": float64(3),
+	}))
+	assert.NotEmpty(t, tilePushEngine.CurrentState.Board[3][6].Shape, "설정한 보드 크기의 끝 칸에 놓을 수 있어야 함")
+}
@@ -0,0 +1,78 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	redisutil "github.com/Ryeom/board-game/infra/redis"
+	"github.com/Ryeom/board-game/internal/domain/room"
+	"github.com/Ryeom/board-game/internal/game"
+	"github.com/Ryeom/board-game/internal/service"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestJanitor() *service.Janitor {
+	games := service.NewGameService(game.NewManager(), noopBroadcaster{})
+	return service.NewJanitor(games, noopBroadcaster{}, service.JanitorConfig{
+		RoomIdle:        time.Minute,
+		DisconnectGrace: 5 * time.Minute,
+	})
+}
+
+func TestJanitor_ClosesIdleRoomAndPurgesOrphans(t *testing.T) {
+	cleanRoomData(t)
+	defer cleanRoomData(t)
+	ctx := context.Background()
+	j := newTestJanitor()
+
+	seedRoom(t, "room:janitor:idle", 4, "ghost-host", "ghost-guest")
+	require.NoError(t, redisutil.AddSet(redisutil.RedisTargetUser, user.RoomIndexKey("room:janitor:orphan"), "ghost"))
+	require.NoError(t, game.SaveGameState(ctx, game.ModeHanabi, "room:janitor:orphan", map[string]any{"version": 1}))
+
+	res := j.SweepOnce(ctx, time.Now())
+	assert.Zero(t, res.ClosedRooms, "유휴 시간이 지나기 전에는 닫지 않아야 함")
+	_, ok := room.GetRoom(ctx, "room:janitor:idle")
+	assert.True(t, ok)
+	assert.GreaterOrEqual(t, res.PurgedRoomSessions, 1, "방이 없는 room_sessions set은 지워야 함")
+	assert.GreaterOrEqual(t, res.PurgedGameStates, 1, "방이 없는 게임 상태는 지워야 함")
+
+	res = j.SweepOnce(ctx, time.Now().Add(2*time.Minute))
+	assert.Equal(t, 1, res.ClosedRooms, "접속한 멤버가 없는 유휴 방은 닫아야 함")
+	_, ok = room.GetRoom(ctx, "room:janitor:idle")
+	assert.False(t, ok)
+}
+
+func TestJanitor_EndsAbandonedGameAfterGrace(t *testing.T) {
+	cleanRoomData(t)
+	defer cleanRoomData(t)
+	ctx := context.Background()
+	j := newTestJanitor()
+
+	seedRoom(t, "room:janitor:abandoned", 4, "janitor-p1", "janitor-p2")
+	_, _, err := room.Update(ctx, "room:janitor:abandoned", func(r *room.Room) (room.Mutation, error) {
+		r.IsGameStarted = true
+		return room.MutationSave, nil
+	})
+	require.NoError(t, err)
+	disconnectedAt := time.Now()
+	for _, pid := range []string{"janitor-p1", "janitor-p2"} {
+		s := user.NewUserSession(pid, pid, "room:janitor:abandoned", "127.0.0.1", "test", false, nil)
+		s.Status = "disconnected"
+		s.DisconnectedAt = disconnectedAt
+		require.NoError(t, user.SaveUserSession(s))
+		defer user.DeleteUserSession(pid)
+	}
+
+	res := j.SweepOnce(ctx, disconnectedAt.Add(time.Minute))
+	assert.Zero(t, res.ClosedRooms, "유예 시간 안에는 재접속을 기다려야 함")
+
+	res = j.SweepOnce(ctx, disconnectedAt.Add(10*time.Minute))
+	assert.Equal(t, 1, res.ClosedRooms, "모두 끊긴 채 유예 시간이 지나면 게임을 끝내고 방을 닫아야 함")
+	_, ok := room.GetRoom(ctx, "room:janitor:abandoned")
+	assert.False(t, ok)
+	_, err = user.GetSession("janitor-p1")
+	assert.Error(t, err, "재접속을 기다리던 세션도 정리되어야 함")
+}
@@ -0,0 +1,193 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Ryeom/board-game/infra/db"
+	"github.com/Ryeom/board-game/internal/auth"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/service"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useLoginGuard 테스트 동안 로그인 실패 제한 설정을 바꾸고, 남은 카운터와 감사 로그를 정리한다.
+func useLoginGuard(t *testing.T, cfg auth.LoginGuardConfig, emails ...string) {
+	t.Helper()
+	ensureRedis(t)
+	previous := auth.LoginGuard
+	auth.LoginGuard = cfg
+	cleanRedis(t)
+	t.Cleanup(func() {
+		auth.LoginGuard = previous
+		db.DB.Where("email IN ?", emails).Delete(&auth.AuditLog{})
+	})
+}
+
+func loginAudits(t *testing.T, email string) []auth.AuditLog {
+	t.Helper()
+	var logs []auth.AuditLog
+	require.NoError(t, db.DB.Where("email = ?", email).Order("created_at").Find(&logs).Error)
+	return logs
+}
+
+func TestLoginGuard_ProgressiveDelay(t *testing.T) {
+	userID := seedSessionUser(t)
+	u, err := user.FindUserByID(userID)
+	require.NoError(t, err)
+	useLoginGuard(t, auth.LoginGuardConfig{
+		Window: time.Minute, FreeAttempts: 2, IPFreeAttempts: 100,
+		BaseDelay: 2 * time.Second, MaxDelay: 8 * time.Second,
+	}, u.Email)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, auth.CheckLogin(ctx, u.Email, "10.0.0.1"))
+		require.NoError(t, auth.RecordLoginFailure(ctx, u.Email, "10.0.0.1", userID))
+	}
+	require.NoError(t, auth.CheckLogin(ctx, u.Email, "10.0.0.1"), "허용 횟수까지는 대기 없음")
+
+	require.NoError(t, auth.RecordLoginFailure(ctx, u.Email, "10.0.0.1", userID))
+	err = auth.CheckLogin(ctx, u.Email, "10.0.0.2")
+	var blocked *auth.LoginBlockedError
+	require.True(t, errors.As(err, &blocked), "IP가 달라도 계정 단위로 대기")
+	assert.Equal(t, resp.ErrorCodeAuthLoginThrottled, err.Error())
+	assert.InDelta(t, 2, blocked.RetryAfter.Seconds(), 1)
+	assert.Error(t, auth.CheckLogin(ctx, "  "+u.Email+" ", "10.0.0.2"), "이메일 앞뒤 공백은 같은 계정")
+
+	time.Sleep(2100 * time.Millisecond)
+	require.NoError(t, auth.CheckLogin(ctx, u.Email, "10.0.0.1"), "대기 시간이 지나면 다시 시도 가능")
+
+	auth.RecordLoginSuccess(ctx, u.Email)
+	require.NoError(t, auth.RecordLoginFailure(ctx, u.Email, "10.0.0.1", userID))
+	assert.NoError(t, auth.CheckLogin(ctx, u.Email, "10.0.0.1"), "로그인 성공 후에는 실패 횟수를 처음부터 셈")
+}
+
+func TestLoginGuard_IPThrottle(t *testing.T) {
+	useLoginGuard(t, auth.LoginGuardConfig{
+		Window: time.Minute, FreeAttempts: 100, IPFreeAttempts: 3,
+		BaseDelay: 5 * time.Second, MaxDelay: time.Minute,
+	})
+	ctx := context.Background()
+
+	// 한 IP에서 여러 계정을 번갈아 시도
+	for _, email := range []string{"a@guard.test", "b@guard.test", "c@guard.test", "d@guard.test"} {
+		require.NoError(t, auth.RecordLoginFailure(ctx, email, "10.0.0.9", ""))
+	}
+	assert.EqualError(t, auth.CheckLogin(ctx, "e@guard.test", "10.0.0.9"), resp.ErrorCodeAuthLoginThrottled)
+	assert.NoError(t, auth.CheckLogin(ctx, "e@guard.test", "10.0.0.10"), "다른 IP는 영향 없음")
+}
+
+func TestLoginGuard_SpoofedForwardedForKeepsIPBucket(t *testing.T) {
+	ts, _ := startTestServer(t)
+	defer ts.Close()
+	useLoginGuard(t, auth.LoginGuardConfig{
+		Window: time.Minute, FreeAttempts: 100, IPFreeAttempts: 2,
+		BaseDelay: 10 * time.Second, MaxDelay: time.Minute,
+	})
+
+	login := func(i int) *http.Response {
+		body, _ := json.Marshal(map[string]string{
+			"email":    fmt.Sprintf("spoof%d@guard.test", i),
+			"password": "wrong-password-123",
+		})
+		req, err := http.NewRequest(http.MethodPost, ts.URL+"/board-game/auth/login", bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		// 시도마다 다른 IP인 척 (신뢰하는 프록시가 없으므로 무시되어야 함)
+		req.Header.Set("X-Forwarded-For", fmt.Sprintf("203.0.113.%d", i))
+		req.Header.Set("X-Real-IP", fmt.Sprintf("198.51.100.%d", i))
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+		return res
+	}
+
+	for i := 1; i <= 3; i++ {
+		assert.Equal(t, http.StatusBadRequest, login(i).StatusCode)
+	}
+	res := login(4)
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode, "헤더를 바꿔도 같은 접속 IP의 실패 횟수로 셈")
+	assert.NotEmpty(t, res.Header.Get("Retry-After"))
+}
+
+func TestLoginGuard_LockoutAndPasswordResetUnlock(t *testing.T) {
+	dir := useFileMailer(t)
+	userID := seedSessionUser(t)
+	u, err := user.FindUserByID(userID)
+	require.NoError(t, err)
+	useLoginGuard(t, auth.LoginGuardConfig{
+		Window: time.Minute, FreeAttempts: 100, IPFreeAttempts: 100,
+		BaseDelay: time.Second, MaxDelay: time.Second,
+		LockAfter: 3, LockDuration: 10 * time.Minute,
+	}, u.Email)
+	ctx := context.Background()
+
+	require.NoError(t, auth.RecordLoginFailure(ctx, u.Email, "10.0.0.1", userID))
+	require.NoError(t, auth.RecordLoginFailure(ctx, u.Email, "10.0.0.1", userID))
+	err = auth.RecordLoginFailure(ctx, u.Email, "10.0.0.1", userID)
+	assert.EqualError(t, err, resp.ErrorCodeAuthAccountLocked, "잠기는 시도에서 바로 알려줌")
+
+	err = auth.CheckLogin(ctx, u.Email, "10.0.0.2")
+	var blocked *auth.LoginBlockedError
+	require.True(t, errors.As(err, &blocked))
+	assert.Equal(t, resp.ErrorCodeAuthAccountLocked, blocked.Code)
+	assert.InDelta(t, 600, blocked.RetryAfter.Seconds(), 2)
+
+	audits := loginAudits(t, u.Email)
+	require.Len(t, audits, 1)
+	assert.Equal(t, auth.AuditActionLoginLocked, audits[0].Action)
+	assert.Equal(t, userID, audits[0].UserID)
+	assert.Equal(t, "10.0.0.1", audits[0].IP)
+
+	// 비밀번호 재설정으로 잠금 해제
+	require.NoError(t, service.RequestPasswordReset(ctx, u.Email, "ko"))
+	mails := sentMails(t, dir, u.Email)
+	require.Len(t, mails, 1)
+	_, err = service.ConfirmPasswordReset(ctx, mailToken(t, mails[0]), "new-password-123")
+	require.NoError(t, err)
+	assert.NoError(t, auth.CheckLogin(ctx, u.Email, "10.0.0.1"))
+
+	audits = loginAudits(t, u.Email)
+	require.Len(t, audits, 2)
+	assert.Equal(t, auth.AuditActionLoginUnlocked, audits[1].Action)
+	assert.Equal(t, auth.UnlockReasonPasswordReset, audits[1].Detail)
+}
+
+func TestLoginGuard_UnknownEmailLocksAndAdminUnlock(t *testing.T) {
+	const email = "nobody@guard.test"
+	adminID := seedSessionUser(t)
+	useLoginGuard(t, auth.LoginGuardConfig{
+		Window: time.Minute, FreeAttempts: 100, IPFreeAttempts: 100,
+		LockAfter: 2, LockDuration: 10 * time.Minute,
+	}, email)
+	ctx := context.Background()
+
+	// 가입되지 않은 이메일도 같은 응답 (가입 여부가 드러나지 않도록)
+	require.NoError(t, auth.RecordLoginFailure(ctx, email, "10.0.0.1", ""))
+	assert.EqualError(t, auth.RecordLoginFailure(ctx, email, "10.0.0.1", ""), resp.ErrorCodeAuthAccountLocked)
+	assert.EqualError(t, auth.CheckLogin(ctx, email, "10.0.0.1"), resp.ErrorCodeAuthAccountLocked)
+
+	unlocked, err := auth.UnlockLogin(ctx, email, "", adminID, auth.UnlockReasonAdmin)
+	require.NoError(t, err)
+	assert.True(t, unlocked)
+	assert.NoError(t, auth.CheckLogin(ctx, email, "10.0.0.1"))
+
+	unlocked, err = auth.UnlockLogin(ctx, email, "", adminID, auth.UnlockReasonAdmin)
+	require.NoError(t, err)
+	assert.False(t, unlocked, "잠겨 있지 않으면 감사 로그를 남기지 않음")
+
+	audits := loginAudits(t, email)
+	require.Len(t, audits, 2)
+	assert.Empty(t, audits[0].UserID)
+	assert.Equal(t, adminID, audits[1].ActorID)
+	assert.Equal(t, auth.UnlockReasonAdmin, audits[1].Detail)
+}
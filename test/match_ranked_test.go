@@ -0,0 +1,80 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ryeom/board-game/infra/db"
+	"github.com/Ryeom/board-game/internal/auth"
+	"github.com/Ryeom/board-game/internal/domain/match"
+	"github.com/Ryeom/board-game/internal/game"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// connectAccount 가입 계정의 로그인 세션 토큰으로 웹소켓에 접속한다.
+func connectAccount(t *testing.T, wsURL, userID string) *websocket.Conn {
+	t.Helper()
+	session, err := auth.StartSession(context.Background(), userID, user.RoleUser, "test-agent", "127.0.0.1")
+	require.NoError(t, err)
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	SendEvent(t, conn, WSEvent{Type: "user.identify", Data: map[string]any{"token": session.Token}})
+	require.Equal(t, "user.identify", ReadEvent(t, conn, 10*time.Second).Type)
+	return conn
+}
+
+func queuedTickets(t *testing.T) []*match.Ticket {
+	t.Helper()
+	tickets, err := match.Pending(context.Background(), game.ModeHanabi)
+	require.NoError(t, err)
+	return tickets
+}
+
+func TestMatchQueue_RankedRequiresVerifiedEmailAndUsesAccountRating(t *testing.T) {
+	ts, wsURL := startTestServer(t)
+	defer ts.Close()
+	cleanRedis(t)
+	defer cleanRedis(t)
+	userID := seedSessionUser(t)
+
+	rankedRequest := WSEvent{Type: "match.queue", Data: map[string]any{
+		"gameMode": "hanabi", "playerCount": 2, "ranked": true, "rating": 9999,
+	}}
+
+	// 게스트는 레이팅 매칭 불가
+	guest := ConnectAndIdentify(t, wsURL, "ranked-guest", "Guest")
+	defer guest.Close()
+	_ = ReadEvent(t, guest, 10*time.Second)
+	SendEvent(t, guest, rankedRequest)
+	res := ReadEvent(t, guest, 10*time.Second)
+	assert.Equal(t, "error", res.Type)
+	assert.Equal(t, "ERROR_MATCH_EMAIL_NOT_VERIFIED", res.ErrorCode)
+
+	// 이메일 인증 전 계정도 불가
+	conn := connectAccount(t, wsURL, userID)
+	defer conn.Close()
+	SendEvent(t, conn, rankedRequest)
+	res = ReadEvent(t, conn, 10*time.Second)
+	assert.Equal(t, "error", res.Type)
+	assert.Equal(t, "ERROR_MATCH_EMAIL_NOT_VERIFIED", res.ErrorCode)
+	assert.Empty(t, queuedTickets(t), "거절된 요청은 대기열에 들어가지 않음")
+
+	// 인증을 마치면 클라이언트가 보낸 값이 아니라 계정의 레이팅으로 대기
+	require.NoError(t, db.DB.Model(&user.User{}).Where("id = ?", userID).
+		Updates(map[string]any{"email_verified_at": time.Now(), "rating": 1650}).Error)
+	SendEvent(t, conn, rankedRequest)
+	res = ReadEvent(t, conn, 10*time.Second)
+	require.Equal(t, "match.queue", res.Type)
+	data := res.Data.(map[string]any)
+	assert.Equal(t, true, data["ranked"])
+	assert.EqualValues(t, 1650, data["rating"])
+
+	tickets := queuedTickets(t)
+	require.Len(t, tickets, 1)
+	assert.True(t, tickets[0].Ranked)
+	assert.Equal(t, 1650, tickets[0].Rating)
+}
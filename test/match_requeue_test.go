@@ -0,0 +1,121 @@
+package test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ryeom/board-game/internal/domain/match"
+	"github.com/Ryeom/board-game/internal/domain/room"
+	"github.com/Ryeom/board-game/internal/game"
+	"github.com/Ryeom/board-game/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seatStealingBroadcaster 첫 참여 알림이 나가는 순간 다른 유저가 남은 자리를 차지하게 한다.
+type seatStealingBroadcaster struct {
+	mu    sync.Mutex
+	found []string
+	stole bool
+}
+
+func (b *seatStealingBroadcaster) SendToPlayer(playerID string, eventName string, payload any, msgCode string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.found = append(b.found, playerID+" "+eventName)
+}
+
+func (b *seatStealingBroadcaster) BroadcastToRoom(roomID string, eventName string, payload any, msgCode string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if eventName != "room.join" || b.stole {
+		return
+	}
+	b.stole = true
+	_, _, _ = room.Update(context.Background(), roomID, func(r *room.Room) (room.Mutation, error) {
+		r.Players = append(r.Players, "stranger")
+		return room.MutationSave, nil
+	})
+}
+
+func TestMatchOnce_RequeuesTicketThatCannotJoin(t *testing.T) {
+	ensureRedis(t)
+	cleanRedis(t)
+	defer cleanRedis(t)
+	ctx := context.Background()
+
+	broadcaster := &seatStealingBroadcaster{}
+	svc := service.NewMatchService(service.NewRoomService(broadcaster), broadcaster, service.MatchConfig{})
+
+	queuedAt := time.Now().Add(-time.Minute).Truncate(time.Millisecond)
+	for i, id := range []string{"requeue-host", "requeue-second", "requeue-late"} {
+		require.NoError(t, match.Enqueue(ctx, &match.Ticket{
+			UserID: id, UserName: id, GameMode: game.ModeHanabi, PlayerCount: 3,
+			EnqueuedAt: queuedAt.Add(time.Duration(i) * time.Millisecond),
+		}, time.Minute))
+	}
+
+	require.Equal(t, 1, svc.MatchOnce(ctx, time.Now()))
+
+	// 자리를 뺏긴 티켓은 원래 대기 시각 그대로 대기열에 돌아오고 match.found를 받지 않음
+	tickets := queuedTickets(t)
+	require.Len(t, tickets, 1)
+	assert.Equal(t, "requeue-late", tickets[0].UserID)
+	assert.True(t, tickets[0].EnqueuedAt.Equal(queuedAt.Add(2*time.Millisecond)), "대기 순서가 유지되어야 함")
+	assert.ElementsMatch(t, []string{"requeue-host match.found", "requeue-second match.found"}, broadcaster.found)
+}
+
+// leavingBroadcaster 첫 참여 알림이 나가는 순간 참여한 유저가 나가고, 다른 유저들이 남은 자리를 모두 차지하게 한다.
+type leavingBroadcaster struct {
+	seatStealingBroadcaster
+}
+
+func (b *leavingBroadcaster) BroadcastToRoom(roomID string, eventName string, payload any, msgCode string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if eventName != "room.join" || b.stole {
+		return
+	}
+	b.stole = true
+	_, _, _ = room.Update(context.Background(), roomID, func(r *room.Room) (room.Mutation, error) {
+		r.Leave("abandon-second")
+		r.Players = append(r.Players, "stranger-1", "stranger-2")
+		return room.MutationSave, nil
+	})
+}
+
+func TestMatchOnce_RequeuesWholeGroupWhenTooFewPlayersStay(t *testing.T) {
+	ensureRedis(t)
+	cleanRedis(t)
+	defer cleanRedis(t)
+	ctx := context.Background()
+
+	broadcaster := &leavingBroadcaster{}
+	svc := service.NewMatchService(service.NewRoomService(broadcaster), broadcaster, service.MatchConfig{})
+
+	ids := []string{"abandon-host", "abandon-second", "abandon-third"}
+	queuedAt := time.Now().Add(-time.Minute).Truncate(time.Millisecond)
+	for i, id := range ids {
+		require.NoError(t, match.Enqueue(ctx, &match.Ticket{
+			UserID: id, UserName: id, GameMode: game.ModeHanabi, PlayerCount: 3,
+			EnqueuedAt: queuedAt.Add(time.Duration(i) * time.Millisecond),
+		}, time.Minute))
+	}
+
+	// 방에 남은 그룹 인원이 방장뿐이라 시작하지 않고, 방장까지 그룹 전체가 원래 대기 순서로 돌아옴
+	require.Equal(t, 0, svc.MatchOnce(ctx, time.Now()))
+	tickets := queuedTickets(t)
+	require.Len(t, tickets, len(ids))
+	for i, ticket := range tickets {
+		assert.Equal(t, ids[i], ticket.UserID)
+		assert.True(t, ticket.EnqueuedAt.Equal(queuedAt.Add(time.Duration(i)*time.Millisecond)), "대기 순서가 유지되어야 함")
+	}
+	assert.Empty(t, broadcaster.found, "match.found를 받으면 안 됨")
+
+	// 방장은 방에서 나가고, 방은 자리를 차지한 유저들의 방으로 남음
+	rooms := room.ListRooms(ctx)
+	require.Len(t, rooms, 1)
+	assert.ElementsMatch(t, []string{"stranger-1", "stranger-2"}, rooms[0].Players)
+}
@@ -0,0 +1,226 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Ryeom/board-game/internal/domain/room"
+	"github.com/Ryeom/board-game/internal/game"
+	"github.com/Ryeom/board-game/internal/service"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noopBroadcaster 서비스 단위 테스트용. 브로드캐스트는 무시한다.
+type noopBroadcaster struct{}
+
+func (noopBroadcaster) SendToPlayer(string, string, any, string)    {}
+func (noopBroadcaster) BroadcastToRoom(string, string, any, string) {}
+
+// seedRoom 방장과 players로 구성된 대기 중인 방 저장
+func seedRoom(t *testing.T, id string, maxPlayers int, players ...string) {
+	t.Helper()
+	r := &room.Room{
+		ID:           id,
+		RoomName:     id,
+		Host:         players[0],
+		Players:      players,
+		ReadyPlayers: map[string]bool{},
+		MaxPlayers:   maxPlayers,
+		GameMode:     game.ModeHanabi,
+		CreatedAt:    time.Now(),
+	}
+	require.NoError(t, r.Save())
+}
+
+func TestJoinRoom_ConcurrentJoinsRespectMaxPlayers(t *testing.T) {
+	cleanRoomData(t)
+	defer cleanRoomData(t)
+	svc := service.NewRoomService(noopBroadcaster{})
+	ctx := context.Background()
+	seedRoom(t, "room:concurrent:join", 2, "host")
+
+	const joiners = 30
+	var wg sync.WaitGroup
+	var succeeded atomic.Int32
+	for i := 0; i < joiners; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := svc.JoinRoom(ctx, fmt.Sprintf("joiner-%d", i), "joiner", "room:concurrent:join", ""); err == nil {
+				succeeded.Add(1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	r, ok := room.GetRoom(ctx, "room:concurrent:join")
+	require.True(t, ok)
+	assert.EqualValues(t, 1, succeeded.Load(), "빈 자리가 하나이므로 한 명만 참여해야 함")
+	assert.Len(t, r.Players, r.MaxPlayers)
+}
+
+func TestLeaveRoom_ConcurrentReadyDoesNotResurrectPlayer(t *testing.T) {
+	cleanRoomData(t)
+	defer cleanRoomData(t)
+	svc := service.NewRoomService(noopBroadcaster{})
+	ctx := context.Background()
+
+	for i := 0; i < 20; i++ {
+		roomID := fmt.Sprintf("room:concurrent:leave:%d", i)
+		seedRoom(t, roomID, 4, "host", "guest")
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, _, _ = svc.SetPlayerReady(ctx, "guest", roomID)
+		}()
+		go func() {
+			defer wg.Done()
+			_, _, err := svc.LeaveRoom(ctx, "guest", roomID)
+			assert.NoError(t, err)
+		}()
+		wg.Wait()
+
+		r, ok := room.GetRoom(ctx, roomID)
+		require.True(t, ok)
+		assert.Equal(t, []string{"host"}, r.Players, "나간 플레이어가 다시 기록되면 안됨")
+		assert.False(t, r.ReadyPlayers["guest"], "나간 플레이어의 레디 상태가 남으면 안됨")
+	}
+}
+
+func TestLeaveRoom_ConcurrentLeavesDeleteRoomOnce(t *testing.T) {
+	cleanRoomData(t)
+	defer cleanRoomData(t)
+	svc := service.NewRoomService(noopBroadcaster{})
+	ctx := context.Background()
+
+	players := []string{"p0", "p1", "p2", "p3", "p4", "p5"}
+	seedRoom(t, "room:concurrent:leaveall", 6, players...)
+
+	var wg sync.WaitGroup
+	var deleted atomic.Int32
+	for _, pid := range players {
+		wg.Add(1)
+		go func(pid string) {
+			defer wg.Done()
+			_, roomDeleted, err := svc.LeaveRoom(ctx, pid, "room:concurrent:leaveall")
+			assert.NoError(t, err)
+			if roomDeleted {
+				deleted.Add(1)
+			}
+		}(pid)
+	}
+	wg.Wait()
+
+	_, ok := room.GetRoom(ctx, "room:concurrent:leaveall")
+	assert.False(t, ok, "모두 나가면 방이 삭제되어야 함")
+	assert.EqualValues(t, 1, deleted.Load(), "마지막으로 나간 한 명만 방을 삭제해야 함")
+}
+
+// seedRoomSession 강퇴 대상 확인에 쓰이는 유저 세션 저장 (방 안에 있는 상태)
+func seedRoomSession(t *testing.T, userID string, roomID string) {
+	t.Helper()
+	require.NoError(t, user.SaveUserSession(user.NewUserSession(userID, userID, roomID, "127.0.0.1", "test", false, nil)))
+	t.Cleanup(func() { _ = user.DeleteUserSession(userID) })
+}
+
+// assertRoomConsistent 레디 목록에는 방에 있는 플레이어만, 플레이어는 중복 없이 정원 안에서
+func assertRoomConsistent(t *testing.T, r *room.Room) {
+	t.Helper()
+	seen := map[string]bool{}
+	for _, pid := range r.Players {
+		assert.False(t, seen[pid], "플레이어 %s가 중복 기록됨", pid)
+		seen[pid] = true
+	}
+	assert.LessOrEqual(t, len(r.Players), r.MaxPlayers)
+	for pid, ready := range r.ReadyPlayers {
+		if ready {
+			assert.True(t, seen[pid], "방에 없는 %s가 레디 상태로 남음", pid)
+		}
+	}
+}
+
+func TestKickUser_ConcurrentJoinKeepsRoomConsistent(t *testing.T) {
+	cleanRoomData(t)
+	defer cleanRoomData(t)
+	svc := service.NewRoomService(noopBroadcaster{})
+	ctx := context.Background()
+
+	for i := 0; i < 20; i++ {
+		roomID := fmt.Sprintf("room:concurrent:kickjoin:%d", i)
+		joiner := fmt.Sprintf("joiner-%d", i)
+		seedRoom(t, roomID, 3, "host", "guest")
+		seedRoomSession(t, "guest", roomID)
+		_, _, err := svc.SetPlayerReady(ctx, "guest", roomID)
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			_, _, err := svc.KickUser(ctx, "host", roomID, "guest")
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := svc.JoinRoom(ctx, joiner, joiner, roomID, "")
+			assert.NoError(t, err, "강퇴와 겹쳐도 빈 자리가 있으면 참여되어야 함")
+		}()
+		go func() {
+			defer wg.Done()
+			// 강퇴되는 유저가 다시 들어오려는 경우 (강퇴 전이면 이미 참여 중으로 거절)
+			_, _ = svc.JoinRoom(ctx, "guest", "guest", roomID, "")
+		}()
+		wg.Wait()
+
+		r, ok := room.GetRoom(ctx, roomID)
+		require.True(t, ok)
+		assertRoomConsistent(t, r)
+		assert.Contains(t, r.Players, joiner)
+		assert.False(t, r.ReadyPlayers["guest"], "강퇴된 유저의 레디 상태가 남으면 안됨")
+	}
+}
+
+func TestKickUser_ConcurrentReadyDoesNotLeaveKickedUserReady(t *testing.T) {
+	cleanRoomData(t)
+	defer cleanRoomData(t)
+	svc := service.NewRoomService(noopBroadcaster{})
+	ctx := context.Background()
+
+	for i := 0; i < 20; i++ {
+		roomID := fmt.Sprintf("room:concurrent:kickready:%d", i)
+		seedRoom(t, roomID, 4, "host", "guest", "other")
+		seedRoomSession(t, "guest", roomID)
+
+		var wg sync.WaitGroup
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			_, _, err := svc.KickUser(ctx, "host", roomID, "guest")
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			_, _, _ = svc.SetPlayerReady(ctx, "guest", roomID)
+		}()
+		go func() {
+			defer wg.Done()
+			_, _, err := svc.SetPlayerReady(ctx, "other", roomID)
+			assert.NoError(t, err)
+		}()
+		wg.Wait()
+
+		r, ok := room.GetRoom(ctx, roomID)
+		require.True(t, ok)
+		assertRoomConsistent(t, r)
+		assert.Equal(t, []string{"host", "other"}, r.Players, "강퇴된 유저가 다시 기록되면 안됨")
+		assert.NotContains(t, r.ReadyPlayers, "guest", "강퇴된 유저가 레디 목록에 남으면 안됨")
+	}
+}
@@ -0,0 +1,118 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	redisutil "github.com/Ryeom/board-game/infra/redis"
+	"github.com/Ryeom/board-game/internal/auth"
+	"github.com/Ryeom/board-game/internal/domain/room"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/Ryeom/board-game/server/ws"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomEvents_BufferExpiresAndReplaysSinceSeq(t *testing.T) {
+	cleanRoomData(t)
+	defer cleanRoomData(t)
+	ctx := context.Background()
+
+	const roomID = "room:events:buffer"
+	for i := 1; i <= 5; i++ {
+		seq, err := room.NextEventSeq(ctx, roomID)
+		require.NoError(t, err)
+		require.EqualValues(t, i, seq)
+		payload, _ := json.Marshal(map[string]any{"type": "room.chat", "seq": seq})
+		require.NoError(t, room.BufferEvent(ctx, roomID, seq, payload))
+	}
+
+	// 두 키 모두 만료 시간이 있어야 방 정리가 누락되어도 남지 않음
+	rdb := redisutil.Client[redisutil.RedisTargetRoom]
+	for _, key := range []string{"room_seq:" + roomID, "room_events:" + roomID} {
+		ttl, err := rdb.TTL(ctx, key).Result()
+		require.NoError(t, err)
+		assert.Greater(t, ttl, time.Duration(0), "%s에 TTL이 없음", key)
+		assert.LessOrEqual(t, ttl, room.EventBufferTTL)
+	}
+
+	events, lastSeq, complete, err := room.EventsSince(ctx, roomID, 2)
+	require.NoError(t, err)
+	assert.True(t, complete)
+	assert.EqualValues(t, 5, lastSeq)
+	require.Len(t, events, 3)
+	for i, raw := range events {
+		var ev struct {
+			Seq int64 `json:"seq"`
+		}
+		require.NoError(t, json.Unmarshal(raw, &ev))
+		assert.EqualValues(t, 3+i, ev.Seq, "놓친 이벤트만 순서대로")
+	}
+
+	// 버퍼에서 밀려난 구간이 있으면 재전송 대신 전체 동기화
+	require.NoError(t, rdb.ZRemRangeByScore(ctx, "room_events:"+roomID, "3", "3").Err())
+	_, _, complete, err = room.EventsSince(ctx, roomID, 2)
+	require.NoError(t, err)
+	assert.False(t, complete)
+
+	// 시퀀스가 만료되어 초기화된 경우도 전체 동기화
+	require.NoError(t, rdb.Del(ctx, "room_seq:"+roomID, "room_events:"+roomID).Err())
+	_, _, complete, err = room.EventsSince(ctx, roomID, 2)
+	require.NoError(t, err)
+	assert.False(t, complete)
+}
+
+func TestUserIdentify_ReconnectReplaysMissedRoomEvents(t *testing.T) {
+	ts, wsURL := startTestServer(t)
+	defer ts.Close()
+	defer cleanRedis(t)
+	ctx := context.Background()
+
+	const roomID = "room:events:resume"
+	seedRoom(t, roomID, 2, "resumer", "stayer")
+	session := user.NewUserSession("resumer", "Resumer", roomID, "127.0.0.1", "test", false, nil)
+	session.Status = "disconnected"
+	require.NoError(t, user.SaveUserSession(session))
+
+	for i := 0; i < 4; i++ {
+		require.NoError(t, ws.GlobalBroadcaster.BroadcastToRoom(roomID, map[string]any{
+			"type": "room.chat",
+			"data": map[string]any{"message": i},
+		}))
+	}
+	const lastSeen = 2
+
+	token, err := auth.GenerateGuestJWT("resumer", "Resumer")
+	require.NoError(t, err)
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	SendEvent(t, conn, WSEvent{Type: "user.identify", Data: map[string]any{"token": token, "resumeFrom": lastSeen}})
+
+	// 식별 응답 전까지 재전송된 이벤트를 모음 (재접속 알림은 실시간으로도 올 수 있어 제외)
+	var replayed []int64
+	var identify map[string]any
+	for identify == nil {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(10*time.Second)))
+		_, msg, err := conn.ReadMessage()
+		require.NoError(t, err)
+		var ev map[string]any
+		require.NoError(t, json.Unmarshal(msg, &ev))
+		switch ev["type"] {
+		case "user.identify":
+			identify = ev["data"].(map[string]any)
+		case "room.chat":
+			replayed = append(replayed, int64(ev["seq"].(float64)))
+		}
+	}
+
+	assert.Equal(t, []int64{3, 4}, replayed, "마지막으로 받은 seq 이후의 이벤트만 정확히 한 번씩")
+	assert.Equal(t, true, identify["reconnected"])
+	assert.Equal(t, false, identify["resynced"])
+	lastSeq, err := room.LastEventSeq(ctx, roomID)
+	require.NoError(t, err)
+	assert.EqualValues(t, lastSeq, identify["lastSeq"])
+}
@@ -0,0 +1,155 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	redisutil "github.com/Ryeom/board-game/infra/redis"
+	"github.com/Ryeom/board-game/internal/domain/room"
+	"github.com/Ryeom/board-game/internal/game"
+	"github.com/stretchr/testify/assert"
+)
+
+// cleanRoomData 방 JSON, 목록 인덱스, 이벤트 버퍼, 초대 코드를 SCAN으로 정리
+func cleanRoomData(tb testing.TB) {
+	tb.Helper()
+	if redisutil.Client == nil {
+		redisutil.Initialize()
+	}
+	ctx := context.Background()
+	rdb := redisutil.Client[redisutil.RedisTargetRoom]
+	for _, pattern := range []string{"room:*", "rooms:*", "room_seq:*", "room_events:*", "invite:*", "room_invites:*"} {
+		keys := redisutil.ScanKeyList(redisutil.RedisTargetRoom, pattern)
+		for start := 0; start < len(keys); start += 1000 {
+			end := min(start+1000, len(keys))
+			if err := rdb.Del(ctx, keys[start:end]...).Err(); err != nil {
+				tb.Fatalf("Failed to clean %s: %v", pattern, err)
+			}
+		}
+	}
+}
+
+// seedRooms 생성 시각이 1ms씩 증가하는 방 n개 저장. i%3==0은 tile_push, i%4==0은 게임 시작, 인원은 1~4명.
+func seedRooms(tb testing.TB, n int) {
+	tb.Helper()
+	base := time.Now().Add(-time.Duration(n) * time.Millisecond)
+	for i := 0; i < n; i++ {
+		mode := game.ModeHanabi
+		if i%3 == 0 {
+			mode = game.ModeTilePush
+		}
+		players := make([]string, i%4+1)
+		for p := range players {
+			players[p] = fmt.Sprintf("p%d", p)
+		}
+		r := &room.Room{
+			ID:            fmt.Sprintf("room:seed:%06d", i),
+			RoomName:      fmt.Sprintf("seed room %d", i),
+			Host:          players[0],
+			Players:       players,
+			ReadyPlayers:  map[string]bool{},
+			MaxPlayers:    4,
+			GameMode:      mode,
+			IsGameStarted: i%4 == 0,
+			CreatedAt:     base.Add(time.Duration(i) * time.Millisecond),
+		}
+		if err := r.Save(); err != nil {
+			tb.Fatalf("Failed to seed room %d: %v", i, err)
+		}
+	}
+}
+
+func TestQueryRooms_IndexedPagination(t *testing.T) {
+	cleanRoomData(t)
+	defer cleanRoomData(t)
+	seedRooms(t, 55)
+	ctx := context.Background()
+
+	seen := map[string]bool{}
+	var prev *room.Room
+	cursor := ""
+	for pages := 0; ; pages++ {
+		rooms, next, err := room.QueryRooms(ctx, room.ListQuery{Limit: 10, Cursor: cursor})
+		assert.NoError(t, err)
+		for _, r := range rooms {
+			assert.False(t, seen[r.ID], "페이지 사이에 중복된 방: %s", r.ID)
+			seen[r.ID] = true
+			if prev != nil {
+				assert.False(t, r.CreatedAt.After(prev.CreatedAt), "최근 생성순이어야 함")
+			}
+			prev = r
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+		assert.Less(t, pages, 10, "페이지가 끝나지 않음")
+	}
+	assert.Len(t, seen, 55)
+
+	// 모드 + 참여 가능 필터: 모드 인덱스를 사용하고 나머지는 불러온 방에 적용
+	rooms, _, err := room.QueryRooms(ctx, room.ListQuery{GameMode: game.ModeTilePush, Joinable: true, Limit: 100})
+	assert.NoError(t, err)
+	for _, r := range rooms {
+		assert.Equal(t, game.ModeTilePush, r.GameMode)
+		assert.False(t, r.IsGameStarted)
+		assert.Less(t, len(r.Players), r.MaxPlayers)
+	}
+
+	// 인원 많은순
+	rooms, _, err = room.QueryRooms(ctx, room.ListQuery{Sort: room.SortMostPlayers, Limit: 5})
+	assert.NoError(t, err)
+	for _, r := range rooms {
+		assert.Len(t, r.Players, 4)
+	}
+
+	// 모드 변경 시 이전 모드 인덱스에서 빠지고, 삭제 시 모든 인덱스에서 빠져야 함
+	r, ok := room.GetRoom(ctx, "room:seed:000000")
+	assert.True(t, ok)
+	r.GameMode = game.ModeHanabi
+	assert.NoError(t, r.Save())
+	rdb := redisutil.Client[redisutil.RedisTargetRoom]
+	_, err = rdb.ZScore(ctx, "rooms:mode:"+string(game.ModeTilePush), r.ID).Result()
+	assert.Error(t, err, "이전 모드 인덱스에 남아있으면 안됨")
+
+	assert.NoError(t, room.DeleteRoom(ctx, r.ID))
+	for _, key := range []string{"rooms:created", "rooms:players", "rooms:mode:hanabi", "rooms:status:playing"} {
+		_, err = rdb.ZScore(ctx, key, r.ID).Result()
+		assert.Error(t, err, "삭제된 방이 %s에 남아있으면 안됨", key)
+	}
+}
+
+// BenchmarkQueryRooms 방 개수가 늘어도 한 페이지 조회 시간이 일정한지 확인
+// go test ./test -run '^$' -bench BenchmarkQueryRooms -benchtime 200x
+func BenchmarkQueryRooms(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range []int{1_000, 10_000, 50_000} {
+		cleanRoomData(b)
+		seedRooms(b, n)
+
+		b.Run(fmt.Sprintf("newest/rooms=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, _, err := room.QueryRooms(ctx, room.ListQuery{Limit: 20}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("joinable_hanabi/rooms=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, _, err := room.QueryRooms(ctx, room.ListQuery{GameMode: game.ModeHanabi, Joinable: true, Limit: 20}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("most_players/rooms=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, _, err := room.QueryRooms(ctx, room.ListQuery{Sort: room.SortMostPlayers, Limit: 20}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+	cleanRoomData(b)
+}
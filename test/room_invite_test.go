@@ -0,0 +1,86 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ryeom/board-game/internal/domain/room"
+	"github.com/Ryeom/board-game/internal/game"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvite_SingleUseBypassesPasswordAndIsRevokedWithRoom(t *testing.T) {
+	cleanRoomData(t)
+	defer cleanRoomData(t)
+	svc := service.NewRoomService(noopBroadcaster{})
+	ctx := context.Background()
+
+	r, err := svc.CreateRoom(ctx, "host", "host", "invite room", "secret", 4, game.ModeHanabi, game.Settings{})
+	require.NoError(t, err)
+
+	_, err = svc.CreateInvite(ctx, "stranger", r.ID, time.Hour, 1)
+	assert.EqualError(t, err, resp.ErrorCodeRoomNotHost, "방장만 초대 코드를 만들 수 있음")
+
+	inv, err := svc.CreateInvite(ctx, "host", r.ID, time.Hour, 1)
+	require.NoError(t, err)
+
+	preview, err := svc.PreviewInvite(ctx, inv.Code)
+	require.NoError(t, err)
+	assert.Equal(t, r.ID, preview.RoomID)
+	assert.Zero(t, preview.Uses, "미리보기는 사용 횟수를 차감하지 않음")
+
+	joined, err := svc.JoinRoomByCode(ctx, "guest", "guest", inv.Code)
+	require.NoError(t, err)
+	assert.Contains(t, joined.Players, "guest")
+
+	_, err = svc.JoinRoomByCode(ctx, "late", "late", inv.Code)
+	assert.EqualError(t, err, resp.ErrorCodeInviteExhausted, "1회용 코드는 다시 쓸 수 없음")
+
+	multi, err := svc.CreateInvite(ctx, "host", r.ID, time.Hour, 0)
+	require.NoError(t, err)
+	_, err = svc.JoinRoomByCode(ctx, "guest", "guest", multi.Code)
+	assert.EqualError(t, err, resp.ErrorCodeRoomAlreadyJoined)
+	after, err := room.GetInvite(ctx, multi.Code)
+	require.NoError(t, err)
+	assert.Zero(t, after.Uses, "입장에 실패하면 사용 횟수를 되돌림")
+
+	require.NoError(t, room.DeleteRoom(ctx, r.ID))
+	_, err = svc.PreviewInvite(ctx, multi.Code)
+	assert.EqualError(t, err, resp.ErrorCodeInviteNotFound, "방이 삭제되면 코드도 폐기")
+}
+
+func TestJoinRoom_PasswordEnforcedAfterReload(t *testing.T) {
+	cleanRoomData(t)
+	defer cleanRoomData(t)
+	svc := service.NewRoomService(noopBroadcaster{})
+	ctx := context.Background()
+
+	r, err := svc.CreateRoom(ctx, "host", "host", "locked room", "secret", 4, game.ModeHanabi, game.Settings{})
+	require.NoError(t, err)
+
+	reloaded, ok := room.GetRoom(ctx, r.ID)
+	require.True(t, ok)
+	assert.NotEmpty(t, reloaded.Password, "Redis에서 다시 읽어도 비밀번호 해시가 남아야 함")
+	assert.True(t, reloaded.HasPassword)
+
+	_, err = svc.JoinRoom(ctx, "nopass", "nopass", r.ID, "")
+	assert.EqualError(t, err, resp.ErrorCodeRoomWrongPassword, "비밀번호 없이 입장 불가")
+	_, err = svc.JoinRoom(ctx, "wrong", "wrong", r.ID, "guess")
+	assert.EqualError(t, err, resp.ErrorCodeRoomWrongPassword, "틀린 비밀번호로 입장 불가")
+
+	inv, err := svc.CreateInvite(ctx, "host", r.ID, time.Hour, 0)
+	require.NoError(t, err)
+	joined, err := svc.JoinRoomByCode(ctx, "invited", "invited", inv.Code)
+	require.NoError(t, err, "초대 코드는 비밀번호 없이 입장")
+	assert.Contains(t, joined.Players, "invited")
+
+	joined, err = svc.JoinRoom(ctx, "friend", "friend", r.ID, "secret")
+	require.NoError(t, err)
+	assert.Contains(t, joined.Players, "friend")
+	assert.NotContains(t, joined.Players, "nopass")
+	assert.NotContains(t, joined.Players, "wrong")
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Ryeom/board-game/internal/auth"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 )
@@ -46,11 +47,12 @@ func ReadEvent(t *testing.T, conn *websocket.Conn, timeout time.Duration) WSEven
 }
 
 func IdentifyUser(t *testing.T, conn *websocket.Conn, userID, userName string) {
+	token, err := auth.GenerateGuestJWT(userID, userName)
+	assert.NoError(t, err)
 	event := WSEvent{
 		Type: "user.identify",
 		Data: map[string]interface{}{
-			"userId":   userID,
-			"userName": userName,
+			"token": token,
 		},
 	}
 	SendEvent(t, conn, event)
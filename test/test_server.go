@@ -27,9 +27,10 @@ func cleanRedis(t *testing.T) {
 
 	// 각 Redis 타겟별로 정리할 키 패턴 정의
 	cleanupMap := map[string][]string{
-		redisutil.RedisTargetRoom: {"room:*"},                                               // 방 데이터
-		redisutil.RedisTargetUser: {"user:session:*", "jwt:blacklist:*", "room_sessions:*"}, // 사용자 세션 및 JWT 블랙리스트
-		redisutil.RedisTargetGame: {"game:*"},                                               // 게임 상태 데이터
+		redisutil.RedisTargetRoom:  {"room:*", "rooms:*", "room_seq:*", "room_events:*", "invite:*", "room_invites:*"}, // 방 데이터, 목록 인덱스, 이벤트 버퍼, 초대 코드
+		redisutil.RedisTargetUser:  {"user:session:*", "jwt:blacklist:*", "room_sessions:*", "auth:*"},                 // 사용자 세션, JWT 블랙리스트, 종료된 로그인 세션
+		redisutil.RedisTargetGame:  {"game:*"},                                                                         // 게임 상태 데이터
+		redisutil.RedisTargetQueue: {"match:*"},                                                                        // 빠른 대전 대기열
 	}
 
 	for target, patterns := range cleanupMap {
@@ -89,6 +90,9 @@ func startTestServer(t *testing.T) (*httptest.Server, string) {
 		t.Fatalf("Failed to load server configuration for tests: %v", err)
 	}
 
+	// 테스트 유저는 DB에 없으므로 게스트 토큰으로 식별
+	viper.Set("bg.ws.guest-enabled", true)
+
 	e := echo.New()
 	server.Initialize(e)
 
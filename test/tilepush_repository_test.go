@@ -9,7 +9,10 @@ import (
 	"testing"
 
 	"github.com/Ryeom/board-game/infra/db"
+	"github.com/Ryeom/board-game/internal/auth"
+	"github.com/Ryeom/board-game/internal/domain/friend"
 	"github.com/Ryeom/board-game/internal/domain/tilepush"
+	"github.com/Ryeom/board-game/internal/user"
 	l "github.com/Ryeom/board-game/log"
 	"github.com/Ryeom/board-game/server"
 	"github.com/spf13/viper"
@@ -54,6 +57,15 @@ func setupTestDB() {
 	if err := db.DB.AutoMigrate(&tilepush.TileSet{}, &tilepush.Tile{}); err != nil {
 		panic(fmt.Errorf("failed to auto migrate tables: %w", err))
 	}
+	if err := friend.Migrate(db.DB); err != nil {
+		panic(fmt.Errorf("failed to migrate friendships: %w", err))
+	}
+	if err := user.Migrate(db.DB); err != nil {
+		panic(fmt.Errorf("failed to migrate users: %w", err))
+	}
+	if err := auth.MigrateSessions(db.DB); err != nil {
+		panic(fmt.Errorf("failed to migrate auth sessions: %w", err))
+	}
 }
 
 func TestMain(m *testing.M) {
@@ -0,0 +1,289 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Ryeom/board-game/infra/db"
+	"github.com/Ryeom/board-game/infra/mongo"
+	"github.com/Ryeom/board-game/internal/auth"
+	chat "github.com/Ryeom/board-game/internal/domain/chat"
+	"github.com/Ryeom/board-game/internal/domain/dm"
+	"github.com/Ryeom/board-game/internal/domain/match"
+	"github.com/Ryeom/board-game/internal/game"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/service"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/Ryeom/board-game/internal/util"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func seedUserData(t *testing.T, roomID string, ids []string) {
+	t.Helper()
+	ctx := context.Background()
+	require.NoError(t, chat.SaveChatMessage(ctx, roomID, &chat.ChatRecord{SenderID: ids[0], SenderName: "alice", Message: "hello", Timestamp: time.Now()}))
+	require.NoError(t, chat.SaveChatMessage(ctx, roomID, &chat.ChatRecord{SenderID: ids[1], SenderName: "bob", Message: "hi", Timestamp: time.Now()}))
+	require.NoError(t, match.SaveResult(ctx, &match.Result{
+		RoomID:    roomID,
+		GameMode:  game.ModeHanabi,
+		Players:   []match.Participant{{UserID: ids[0], Name: "alice"}, {UserID: ids[1], Name: "bob"}},
+		Score:     18,
+		EndReason: "deck_exhausted",
+		EndedAt:   time.Now(),
+	}))
+	t.Cleanup(func() {
+		_, _ = mongo.GetCollection(mongo.ChatCollection).DeleteMany(context.Background(), bson.M{"roomId": roomID})
+		_, _ = mongo.GetCollection(mongo.MatchCollection).DeleteMany(context.Background(), bson.M{"roomId": roomID})
+	})
+}
+
+func TestUserData_Export(t *testing.T) {
+	ids := seedFriendUsers(t, 2)
+	seedUserData(t, "room:user:export", ids)
+
+	u, err := user.FindUserByID(ids[0])
+	require.NoError(t, err)
+	export, err := service.ExportUserData(context.Background(), u)
+	require.NoError(t, err)
+
+	assert.Equal(t, ids[0], export.Profile.UserID)
+	require.Len(t, export.Matches, 1)
+	assert.Equal(t, 18, export.Matches[0].Score)
+	require.Len(t, export.Messages, 1, "다른 유저의 메시지는 포함하지 않음")
+	assert.Equal(t, "hello", export.Messages[0].Message)
+	assert.Equal(t, "room:user:export", export.Messages[0].RoomID)
+}
+
+func TestUserData_SoftDeleteAnonymizes(t *testing.T) {
+	ids := seedFriendUsers(t, 2)
+	seedUserData(t, "room:user:delete", ids)
+	user.OnSoftDelete(service.AnonymizeUserData)
+	ctx := context.Background()
+	require.NoError(t, dm.Save(ctx, &dm.Message{SenderID: ids[0], SenderName: "alice", RecipientID: ids[1], Message: "dm hello", Timestamp: time.Now()}))
+	require.NoError(t, dm.Save(ctx, &dm.Message{SenderID: ids[1], SenderName: "bob", RecipientID: ids[0], Message: "dm hi", Timestamp: time.Now().Add(time.Millisecond)}))
+	t.Cleanup(func() {
+		_, _ = mongo.GetCollection(mongo.DirectMessageCollection).DeleteMany(context.Background(), bson.M{"pair": dm.PairKey(ids[0], ids[1])})
+	})
+
+	require.NoError(t, user.DeactivateUser(db.DB, ids[0]))
+	require.NoError(t, user.RunDeletionHooks(ctx, ids[0]))
+
+	u, err := user.FindUserByID(ids[0])
+	require.NoError(t, err)
+	assert.False(t, u.IsActive)
+
+	messages, err := chat.MessagesBySender(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Empty(t, messages, "탈퇴한 유저의 채팅은 익명으로 바뀜")
+
+	history, _, err := chat.GetChatHistory(ctx, "room:user:delete", chat.HistoryQuery{})
+	require.NoError(t, err)
+	require.Len(t, history, 2, "대화 흐름은 남김")
+	assert.Equal(t, user.DeletedUserID, history[0].SenderID)
+	assert.Equal(t, user.DeletedUserName, history[0].SenderName)
+	assert.Equal(t, ids[1], history[1].SenderID)
+
+	mine, err := match.ResultsByUser(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Empty(t, mine)
+	theirs, err := match.ResultsByUser(ctx, ids[1])
+	require.NoError(t, err)
+	require.Len(t, theirs, 1, "다른 참가자의 전적은 남음")
+	assert.Equal(t, []match.Participant{{UserID: user.DeletedUserID, Name: user.DeletedUserName}, {UserID: ids[1], Name: "bob"}}, theirs[0].Players)
+
+	sent, err := dm.SentBy(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Empty(t, sent, "탈퇴한 유저의 1:1 메시지도 익명으로 바뀜")
+	conversation, _, err := dm.History(ctx, ids[1], ids[0], dm.HistoryQuery{})
+	require.NoError(t, err)
+	require.Len(t, conversation, 2, "상대방의 대화 기록은 남김")
+	for _, m := range conversation {
+		if m.Message == "dm hello" {
+			assert.Equal(t, user.DeletedUserID, m.SenderID)
+			assert.Equal(t, user.DeletedUserName, m.SenderName)
+		} else {
+			assert.Equal(t, ids[1], m.SenderID)
+			assert.Equal(t, user.DeletedUserID, m.RecipientID)
+		}
+	}
+}
+
+func TestUserData_AnonymizesTimelineRecords(t *testing.T) {
+	ids := seedFriendUsers(t, 2)
+	ctx := context.Background()
+	const roomID = "room:user:timeline"
+	t.Cleanup(func() {
+		_, _ = mongo.GetCollection(mongo.ChatCollection).DeleteMany(context.Background(), bson.M{"roomId": roomID})
+	})
+	hint := chat.NewEventRecord(chat.RecordGame, resp.TimelineCodeHintColor,
+		map[string]string{"from": "alice", "to": "bob", "color": "red", "count": "2"},
+		chat.Subject{Param: "from", UserID: ids[0]}, chat.Subject{Param: "to", UserID: ids[1]})
+	require.NoError(t, chat.SaveChatMessage(ctx, roomID, hint))
+	joined := chat.NewEventRecord(chat.RecordSystem, resp.TimelineCodeJoined, map[string]string{"user": "alice"},
+		chat.Subject{Param: "user", UserID: ids[0]})
+	require.NoError(t, chat.SaveChatMessage(ctx, roomID, joined))
+
+	require.NoError(t, chat.AnonymizeSender(ctx, ids[0]))
+
+	history, _, err := chat.GetChatHistory(ctx, roomID, chat.HistoryQuery{})
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	for _, record := range history {
+		for _, value := range record.Params {
+			assert.NotEqual(t, "alice", value, "탈퇴한 유저의 이름이 타임라인에 남지 않음")
+		}
+		assert.NotContains(t, record.Message, "alice")
+		if record.Key == resp.TimelineCodeHintColor {
+			assert.Equal(t, user.DeletedUserName, record.Params["from"])
+			assert.Equal(t, "bob", record.Params["to"], "다른 유저의 이름은 남김")
+		} else {
+			assert.Equal(t, user.DeletedUserName, record.Params["user"])
+		}
+	}
+}
+
+func TestDeleteAccount_Endpoint(t *testing.T) {
+	ts, _ := startTestServer(t)
+	defer ts.Close()
+	userID := seedSessionUser(t)
+	hashed, err := util.HashPassword("delete-me-123")
+	require.NoError(t, err)
+	require.NoError(t, user.UpdatePassword(db.DB, userID, hashed))
+	u, err := user.FindUserByID(userID)
+	require.NoError(t, err)
+	pair, err := auth.StartSession(context.Background(), userID, user.RoleUser, "test-agent", "127.0.0.1")
+	require.NoError(t, err)
+
+	deleteAccount := func(password string) int {
+		body, _ := json.Marshal(map[string]string{"password": password})
+		req, err := http.NewRequest(http.MethodDelete, ts.URL+"/board-game/api/user", bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+pair.Token)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+		return res.StatusCode
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, deleteAccount("wrong-password"), "비밀번호가 틀리면 탈퇴하지 않음")
+	u, err = user.FindUserByID(userID)
+	require.NoError(t, err)
+	assert.True(t, u.IsActive)
+
+	assert.Equal(t, http.StatusOK, deleteAccount("delete-me-123"))
+	u, err = user.FindUserByID(userID)
+	require.NoError(t, err)
+	assert.False(t, u.IsActive)
+	assert.Equal(t, http.StatusUnauthorized, deleteAccount("delete-me-123"), "탈퇴하면 로그인 세션이 종료됨")
+
+	body, _ := json.Marshal(map[string]string{"email": u.Email, "password": "delete-me-123"})
+	res, err := http.Post(ts.URL+"/board-game/auth/login", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode, "탈퇴한 계정으로는 로그인할 수 없음")
+}
+
+func TestDeleteAccount_AnonymizationFailureStillRevokesSessions(t *testing.T) {
+	ts, wsURL := startTestServer(t)
+	defer ts.Close()
+	ids := []string{seedSessionUser(t), seedSessionUser(t)}
+	userID, adminID := ids[0], ids[1]
+	ctx := context.Background()
+	hashed, err := util.HashPassword("delete-me-123")
+	require.NoError(t, err)
+	require.NoError(t, user.UpdatePassword(db.DB, userID, hashed))
+	require.NoError(t, db.DB.Model(&user.User{}).Where("id = ?", adminID).Update("role", user.RoleAdmin).Error)
+
+	// 이 유저의 첫 익명화만 실패
+	failed := false
+	user.OnSoftDelete(func(ctx context.Context, id string) error {
+		if id != userID || failed {
+			return nil
+		}
+		failed = true
+		return errors.New("mongo unavailable")
+	})
+
+	pair, err := auth.StartSession(ctx, userID, user.RoleUser, "test-agent", "127.0.0.1")
+	require.NoError(t, err)
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL+"?token="+pair.Token, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	time.Sleep(100 * time.Millisecond)
+
+	body, _ := json.Marshal(map[string]string{"password": "delete-me-123"})
+	req, err := http.NewRequest(http.MethodDelete, ts.URL+"/board-game/api/user", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+pair.Token)
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusAccepted, res.StatusCode, "익명화 실패는 탈퇴 실패와 구분")
+
+	// 익명화가 실패해도 세션과 연결은 종료
+	_, err = auth.Authenticate(ctx, pair.Token)
+	require.Error(t, err)
+	assert.Equal(t, resp.ErrorCodeAuthSessionRevoked, err.Error())
+	event := ReadEvent(t, conn, 2*time.Second)
+	assert.Equal(t, resp.ErrorCodeAuthSessionRevoked, event.ErrorCode)
+
+	// 관리자가 익명화를 다시 실행
+	admin, err := auth.StartSession(ctx, adminID, user.RoleAdmin, "test-agent", "127.0.0.1")
+	require.NoError(t, err)
+	anonymize := func(target string) int {
+		req, err := http.NewRequest(http.MethodPost, ts.URL+"/board-game/api/admin/users/"+target+"/anonymize", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+admin.Token)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+		return res.StatusCode
+	}
+	assert.Equal(t, http.StatusOK, anonymize(userID))
+	assert.Equal(t, http.StatusConflict, anonymize(adminID), "탈퇴하지 않은 계정은 익명화하지 않음")
+}
+
+func TestChatRetention_TTLIndex(t *testing.T) {
+	if mongo.Client == nil {
+		mongo.Initialize()
+	}
+	ctx := context.Background()
+	ttl := func() (any, bool) {
+		cursor, err := mongo.GetCollection(mongo.ChatCollection).Indexes().List(ctx)
+		require.NoError(t, err)
+		var indexes []bson.M
+		require.NoError(t, cursor.All(ctx, &indexes))
+		for _, idx := range indexes {
+			if idx["name"] == mongo.ChatRetentionIndex {
+				return idx["expireAfterSeconds"], true
+			}
+		}
+		return nil, false
+	}
+	t.Cleanup(func() { _ = mongo.EnsureChatRetention(ctx, 0) })
+
+	require.NoError(t, mongo.EnsureChatRetention(ctx, time.Hour))
+	secs, ok := ttl()
+	require.True(t, ok)
+	assert.EqualValues(t, 3600, secs)
+
+	require.NoError(t, mongo.EnsureChatRetention(ctx, 2*time.Hour), "보관 기간 변경은 collMod")
+	secs, ok = ttl()
+	require.True(t, ok)
+	assert.EqualValues(t, 7200, secs)
+
+	require.NoError(t, mongo.EnsureChatRetention(ctx, 0))
+	_, ok = ttl()
+	assert.False(t, ok, "0이면 TTL 인덱스 제거")
+	require.NoError(t, mongo.EnsureChatRetention(ctx, 0), "이미 없어도 에러 없음")
+}
@@ -0,0 +1,186 @@
+package test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Ryeom/board-game/internal/auth"
+	resp "github.com/Ryeom/board-game/internal/response"
+	"github.com/Ryeom/board-game/internal/user"
+	"github.com/gorilla/websocket"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserUpdate_RejectsNameAndServerManagedStatus(t *testing.T) {
+	ts, wsURL := startTestServer(t)
+	defer ts.Close()
+
+	conn := ConnectAndIdentify(t, wsURL, "update-guard", "Original")
+	defer conn.Close()
+	_ = ReadEvent(t, conn, 10*time.Second)
+
+	SendEvent(t, conn, WSEvent{Type: "user.update", Data: map[string]any{"name": "Admin"}})
+	res := ReadEvent(t, conn, 10*time.Second)
+	assert.Equal(t, "error", res.Type)
+	assert.Equal(t, "ERROR_USER_NAME_NOT_EDITABLE", res.ErrorCode)
+
+	for _, status := range []string{"disconnected", "connected", "ready"} {
+		SendEvent(t, conn, WSEvent{Type: "user.update", Data: map[string]any{"status": status}})
+		res = ReadEvent(t, conn, 10*time.Second)
+		assert.Equal(t, "ERROR_USER_INVALID_STATUS", res.ErrorCode, status)
+	}
+
+	SendEvent(t, conn, WSEvent{Type: "user.update", Data: map[string]any{"status": "away"}})
+	res = ReadEvent(t, conn, 10*time.Second)
+	require.Equal(t, "user.update", res.Type)
+
+	session, err := user.GetSession("update-guard")
+	require.NoError(t, err)
+	assert.Equal(t, "Original", session.Name)
+	assert.Equal(t, "connected", session.Status, "연결 상태는 클라이언트가 바꿀 수 없음")
+	assert.Equal(t, "away", session.Activity)
+}
+
+// dialWithToken 업그레이드 요청에 토큰을 담아 접속한다.
+func dialWithToken(t *testing.T, wsURL, token string) (*websocket.Conn, *http.Response, error) {
+	t.Helper()
+	return websocket.DefaultDialer.Dial(wsURL+"?token="+url.QueryEscape(token), nil)
+}
+
+// assertUpgradeRejected 업그레이드가 401과 함께 거절되었는지 확인
+func assertUpgradeRejected(t *testing.T, wsURL, token, errorCode string) {
+	t.Helper()
+	conn, res, err := dialWithToken(t, wsURL, token)
+	if conn != nil {
+		conn.Close()
+	}
+	require.ErrorIs(t, err, websocket.ErrBadHandshake)
+	require.NotNil(t, res)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	body, _ := io.ReadAll(res.Body)
+	assert.Contains(t, string(body), errorCode)
+}
+
+func TestWebSocketUpgrade_RejectsBlacklistedAndRevokedTokens(t *testing.T) {
+	ts, wsURL := startTestServer(t)
+	defer ts.Close()
+	userID := seedSessionUser(t)
+	ctx := context.Background()
+
+	blacklisted, err := auth.StartSession(ctx, userID, user.RoleUser, "laptop", "127.0.0.1")
+	require.NoError(t, err)
+	require.NoError(t, auth.AddTokenToBlacklist(ctx, blacklisted.Token))
+	assertUpgradeRejected(t, wsURL, blacklisted.Token, resp.ErrorCodeAuthTokenBlacklisted)
+
+	revoked, err := auth.StartSession(ctx, userID, user.RoleUser, "phone", "127.0.0.2")
+	require.NoError(t, err)
+	require.NoError(t, auth.RevokeSession(ctx, userID, revoked.SessionID, auth.RevokeReasonUser))
+	assertUpgradeRejected(t, wsURL, revoked.Token, resp.ErrorCodeAuthSessionRevoked)
+
+	assertUpgradeRejected(t, wsURL, "not-a-jwt", resp.ErrorCodeAuthInvalidToken)
+}
+
+func TestUserIdentify_RejectsTokenOfAnotherUser(t *testing.T) {
+	ts, wsURL := startTestServer(t)
+	defer ts.Close()
+	owner, other := seedSessionUser(t), seedSessionUser(t)
+	ctx := context.Background()
+
+	ownerSession, err := auth.StartSession(ctx, owner, user.RoleUser, "test-agent", "127.0.0.1")
+	require.NoError(t, err)
+	otherSession, err := auth.StartSession(ctx, other, user.RoleUser, "test-agent", "127.0.0.1")
+	require.NoError(t, err)
+
+	conn, _, err := dialWithToken(t, wsURL, ownerSession.Token)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// 업그레이드 때 인증된 연결을 다른 사용자의 토큰으로 바꿀 수 없음
+	SendEvent(t, conn, WSEvent{Type: "user.identify", Data: map[string]any{"token": otherSession.Token}})
+	res := ReadEvent(t, conn, 10*time.Second)
+	assert.Equal(t, "error", res.Type)
+	assert.Equal(t, resp.ErrorCodeAuthInvalidToken, res.ErrorCode)
+
+	// 게스트로 바꾸는 것도 불가. 토큰 없이 보내면 업그레이드 때의 사용자로 식별
+	SendEvent(t, conn, WSEvent{Type: "user.identify", Data: map[string]any{"guest": true, "userName": "Impostor"}})
+	res = ReadEvent(t, conn, 10*time.Second)
+	require.Equal(t, "user.identify", res.Type)
+	data := res.Data.(map[string]any)
+	assert.Equal(t, owner, data["userId"])
+	assert.Equal(t, false, data["guest"])
+	assert.NotEqual(t, "Impostor", data["userName"])
+}
+
+func TestUserIdentify_GuestRejectedWhenGuestModeDisabled(t *testing.T) {
+	ts, wsURL := startTestServer(t)
+	defer ts.Close()
+	viper.Set("bg.ws.guest-enabled", false)
+	defer viper.Set("bg.ws.guest-enabled", true)
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	SendEvent(t, conn, WSEvent{Type: "user.identify", Data: map[string]any{"guest": true, "userName": "Guest"}})
+	res := ReadEvent(t, conn, 10*time.Second)
+	assert.Equal(t, "error", res.Type)
+	assert.Equal(t, resp.ErrorCodeWSGuestDisabled, res.ErrorCode)
+
+	// 예전에 받은 게스트 토큰으로 업그레이드해도 식별되지 않음
+	guestToken, err := auth.GenerateGuestJWT("guest-disabled", "Guest")
+	require.NoError(t, err)
+	guestConn, _, err := dialWithToken(t, wsURL, guestToken)
+	require.NoError(t, err)
+	defer guestConn.Close()
+	SendEvent(t, guestConn, WSEvent{Type: "user.identify", Data: map[string]any{}})
+	res = ReadEvent(t, guestConn, 10*time.Second)
+	assert.Equal(t, "error", res.Type)
+	assert.Equal(t, resp.ErrorCodeWSGuestDisabled, res.ErrorCode)
+
+	SendEvent(t, guestConn, WSEvent{Type: "room.list"})
+	res = ReadEvent(t, guestConn, 10*time.Second)
+	assert.Equal(t, resp.ErrorCodeWSExpectedIdentify, res.ErrorCode, "식별되지 않은 연결은 다른 이벤트를 보낼 수 없음")
+}
+
+func TestUserIdentify_CannotReconnectIntoAnotherUsersSession(t *testing.T) {
+	ts, wsURL := startTestServer(t)
+	defer ts.Close()
+	defer cleanRedis(t)
+	attacker := seedSessionUser(t)
+	ctx := context.Background()
+
+	// 게임 중 연결이 끊겨 재접속을 기다리는 피해자 세션
+	const roomID = "room:auth:reconnect"
+	seedRoom(t, roomID, 2, "victim", "stayer")
+	victim := user.NewUserSession("victim", "Victim", roomID, "127.0.0.1", "test", false, nil)
+	victim.Status = "disconnected"
+	require.NoError(t, user.SaveUserSession(victim))
+
+	session, err := auth.StartSession(ctx, attacker, user.RoleUser, "test-agent", "127.0.0.1")
+	require.NoError(t, err)
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL+"?id=victim&name=Victim&token="+url.QueryEscape(session.Token), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	SendEvent(t, conn, WSEvent{Type: "user.identify", Data: map[string]any{"userId": "victim", "userName": "Victim"}})
+	res := ReadEvent(t, conn, 10*time.Second)
+	require.Equal(t, "user.identify", res.Type)
+	data := res.Data.(map[string]any)
+	assert.Equal(t, attacker, data["userId"], "클라이언트가 보낸 ID는 무시")
+	assert.Nil(t, data["reconnected"])
+	assert.Nil(t, data["roomId"])
+
+	// 다른 사용자의 게스트 토큰을 위조할 수도 없음
+	forged := "eyJhbGciOiJIUzI1NiJ9.eyJ1c2VyX2lkIjoidmljdGltIiwiZ3Vlc3QiOnRydWV9.invalid"
+	assertUpgradeRejected(t, wsURL, forged, resp.ErrorCodeAuthInvalidToken)
+
+	kept, err := user.GetSession("victim")
+	require.NoError(t, err)
+	assert.Equal(t, "disconnected", kept.Status, "피해자의 재접속 대기 세션은 그대로")
+	assert.Equal(t, roomID, kept.RoomID)
+}